@@ -40,6 +40,8 @@ resource "ocm_cluster_rosa_classic" "rosa_sts_cluster" {
   availability_zones = var.aws_availability_zones
   properties = {
     rosa_creator_arn = data.aws_caller_identity.current.arn
+    # Used by the sweeper to find the clusters left behind by failed runs:
+    terraform_provider_ocm_e2e = "true"
   }
   sts                = local.sts_roles
   replicas           = var.replicas
@@ -0,0 +1,194 @@
+// The sweeper finds and deletes the clusters left behind by the end to end tests and by CI, so
+// that failed runs don't leave billable clusters behind. Clusters are selected by a property
+// that the tests add to every cluster that they create, and only the ones older than a minimum
+// age are deleted, so that clusters of runs that are still in progress aren't touched.
+//
+// Example:
+//
+//	go run ./ci/sweeper \
+//		--gateway-url=https://api.stage.openshift.com \
+//		--offline-token=... \
+//		--property=terraform_provider_ocm_e2e=true \
+//		--older-than=6h \
+//		--dry-run
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	client "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+var args struct {
+	tokenURL     string
+	gatewayURL   string
+	offlineToken string
+	clientID     string
+	clientSecret string
+	property     string
+	olderThan    time.Duration
+	dryRun       bool
+}
+
+func init() {
+	flag.StringVar(
+		&args.tokenURL,
+		"token-url",
+		"https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token",
+		"Token URL.",
+	)
+	flag.StringVar(
+		&args.gatewayURL,
+		"gateway-url",
+		"https://api.stage.openshift.com",
+		"Gateway URL.",
+	)
+	flag.StringVar(
+		&args.clientID,
+		"client-id",
+		"cloud-services",
+		"OpenID client identifier.",
+	)
+	flag.StringVar(
+		&args.clientSecret,
+		"client-secret",
+		"",
+		"OpenID client secret.",
+	)
+	flag.StringVar(
+		&args.offlineToken,
+		"offline-token",
+		"",
+		"Offline token for authentication.",
+	)
+	flag.StringVar(
+		&args.property,
+		"property",
+		"terraform_provider_ocm_e2e=true",
+		"Property, in 'name=value' format, that identifies the clusters created by the tests.",
+	)
+	flag.DurationVar(
+		&args.olderThan,
+		"older-than",
+		6*time.Hour,
+		"Minimum age of the clusters that will be deleted.",
+	)
+	flag.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Only print the clusters that would be deleted.",
+	)
+}
+
+func main() {
+	ctx := context.Background()
+	flag.Parse()
+
+	logger, err := logging.NewStdLoggerBuilder().Build()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't create logger: %v\n", err)
+		os.Exit(1)
+	}
+	if args.offlineToken == "" {
+		fmt.Fprintf(os.Stderr, "Parameter 'offline-token' is mandatory\n")
+		os.Exit(2)
+	}
+	name, value, ok := strings.Cut(args.property, "=")
+	if !ok || name == "" {
+		fmt.Fprintf(os.Stderr, "Parameter 'property' must be in 'name=value' format\n")
+		os.Exit(2)
+	}
+
+	connection, err := client.NewConnectionBuilder().
+		Logger(logger).
+		TokenURL(args.tokenURL).
+		URL(args.gatewayURL).
+		Client(args.clientID, args.clientSecret).
+		Tokens(args.offlineToken).
+		BuildContext(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't create connection: %v\n", err)
+		os.Exit(1)
+	}
+	defer connection.Close()
+
+	collection := connection.ClustersMgmt().V1().Clusters()
+	cutoff := time.Now().Add(-args.olderThan)
+	clusters, err := findLeftovers(ctx, collection, name, value, cutoff)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't list clusters: %v\n", err)
+		os.Exit(1)
+	}
+	failed := 0
+	for _, cluster := range clusters {
+		if args.dryRun {
+			fmt.Printf(
+				"Would delete cluster '%s' with name '%s' created at %s\n",
+				cluster.ID(), cluster.Name(), cluster.CreationTimestamp().Format(time.RFC3339),
+			)
+			continue
+		}
+		response, err := collection.Cluster(cluster.ID()).Delete().SendContext(ctx)
+		if err != nil && (response == nil || response.Status() != http.StatusNotFound) {
+			fmt.Fprintf(os.Stderr, "Can't delete cluster '%s': %v\n", cluster.ID(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("Deleted cluster '%s' with name '%s'\n", cluster.ID(), cluster.Name())
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// findLeftovers returns the clusters that have the given property and were created before the
+// given time. Clusters that are already uninstalling are skipped.
+func findLeftovers(ctx context.Context, collection *cmv1.ClustersClient, name, value string,
+	cutoff time.Time) ([]*cmv1.Cluster, error) {
+	var result []*cmv1.Cluster
+	search := fmt.Sprintf("creation_timestamp < '%s'", cutoff.UTC().Format(time.RFC3339))
+	page := 1
+	size := 100
+	for {
+		response, err := collection.List().
+			Search(search).
+			Page(page).
+			Size(size).
+			SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		response.Items().Each(func(cluster *cmv1.Cluster) bool {
+			if isLeftover(cluster, name, value, cutoff) {
+				result = append(result, cluster)
+			}
+			return true
+		})
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
+
+// isLeftover checks if the given cluster was created by the tests and should be deleted.
+func isLeftover(cluster *cmv1.Cluster, name, value string, cutoff time.Time) bool {
+	if cluster.State() == cmv1.ClusterStateUninstalling {
+		return false
+	}
+	if !cluster.CreationTimestamp().Before(cutoff) {
+		return false
+	}
+	actual, ok := cluster.Properties()[name]
+	return ok && actual == value
+}
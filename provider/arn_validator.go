@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+	"github.com/thoas/go-funk"
+)
+
+// Services and resource types of the ARNs accepted by the provider:
+const (
+	iamService            = "iam"
+	kmsService            = "kms"
+	secretsManagerService = "secretsmanager"
+
+	roleResourceType   = "role/"
+	keyResourceType    = "key/"
+	secretResourceType = "secret:"
+)
+
+var (
+	awsPartitions = []string{"aws", "aws-cn", "aws-us-gov"}
+	awsAccountRE  = regexp.MustCompile(`^[0-9]{12}$`)
+)
+
+// accountIDAttributePath is the path of the attribute that contains the AWS account of the
+// objects referenced by the ARNs, when the resource has it.
+var accountIDAttributePath = tftypes.NewAttributePath().WithAttributeName("aws_account_id")
+
+// ARNValidator checks that the value of the attribute is a valid ARN of the given AWS service and
+// resource type. When the resource has an 'aws_account_id' attribute it also checks that the ARN
+// belongs to that account.
+func ARNValidator(service, resourceType string) []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: fmt.Sprintf("Validate ARN of '%s' service and '%s' resource type", service, resourceType),
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				value := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, value)
+				if diag.HasError() || common.IsStringAttributeEmpty(*value) {
+					// No attribute to validate
+					return
+				}
+
+				accountID := &types.String{}
+				diag = req.Config.GetAttribute(ctx, accountIDAttributePath, accountID)
+				if diag.HasError() || common.IsStringAttributeEmpty(*accountID) {
+					// The resource doesn't have an account, or it isn't known yet
+					accountID.Value = ""
+				}
+
+				err := validateARN(value.Value, service, resourceType, accountID.Value)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(req.AttributePath,
+						fmt.Sprintf("Invalid %s.", req.AttributePath.LastStep()),
+						err.Error(),
+					)
+				}
+			},
+		},
+	}
+}
+
+// validateARN checks that the given text is an ARN of the given AWS service and resource type
+// that belongs to the given AWS account. The account isn't compared if it is empty.
+func validateARN(text, service, resourceType, accountID string) error {
+	parsed, err := arn.Parse(text)
+	if err != nil {
+		return fmt.Errorf("expected a valid ARN but got '%s': %v", text, err)
+	}
+	if !funk.ContainsString(awsPartitions, parsed.Partition) {
+		return fmt.Errorf(
+			"expected the partition of ARN '%s' to be one of %s but got '%s'",
+			text, strings.Join(awsPartitions, ", "), parsed.Partition,
+		)
+	}
+	if parsed.Service != service {
+		return fmt.Errorf(
+			"expected ARN '%s' to belong to the '%s' service but it belongs to '%s'",
+			text, service, parsed.Service,
+		)
+	}
+	if !strings.HasPrefix(parsed.Resource, resourceType) ||
+		len(parsed.Resource) == len(resourceType) {
+		return fmt.Errorf(
+			"expected ARN '%s' to be a '%s' resource",
+			text, strings.TrimRight(resourceType, "/:"),
+		)
+	}
+	if !awsAccountRE.MatchString(parsed.AccountID) {
+		return fmt.Errorf(
+			"expected the account of ARN '%s' to be a 12 digit AWS account identifier but got '%s'",
+			text, parsed.AccountID,
+		)
+	}
+	if accountID != "" && parsed.AccountID != accountID {
+		return fmt.Errorf(
+			"ARN '%s' belongs to AWS account '%s' but attribute 'aws_account_id' is '%s'",
+			text, parsed.AccountID, accountID,
+		)
+	}
+	return nil
+}
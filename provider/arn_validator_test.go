@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("ARN validation", func() {
+	It("Accepts valid role ARNs", func() {
+		Expect(validateARN(
+			"arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+			iamService, roleResourceType, "123456789012",
+		)).To(Succeed())
+		Expect(validateARN(
+			"arn:aws:iam::123456789012:role/prefix/ManagedOpenShift-Worker-Role",
+			iamService, roleResourceType, "",
+		)).To(Succeed())
+		Expect(validateARN(
+			"arn:aws-us-gov:iam::123456789012:role/ManagedOpenShift-Support-Role",
+			iamService, roleResourceType, "",
+		)).To(Succeed())
+	})
+
+	It("Accepts valid KMS key and secret ARNs", func() {
+		Expect(validateARN(
+			"arn:aws:kms:us-east-1:123456789012:key/mrk-1234abcd12ab34cd56ef1234567890ab",
+			kmsService, keyResourceType, "123456789012",
+		)).To(Succeed())
+		Expect(validateARN(
+			"arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-oidc",
+			secretsManagerService, secretResourceType, "",
+		)).To(Succeed())
+	})
+
+	It("Rejects text that isn't an ARN", func() {
+		err := validateARN("ManagedOpenShift-Installer-Role", iamService, roleResourceType, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("expected a valid ARN"))
+	})
+
+	It("Rejects an unknown partition", func() {
+		err := validateARN(
+			"arn:foo:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+			iamService, roleResourceType, "",
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("partition"))
+	})
+
+	It("Rejects an ARN of a different service", func() {
+		err := validateARN("arn:aws:kms:us-east-1:123456789012:key/1234", iamService, roleResourceType, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("to belong to the 'iam' service"))
+	})
+
+	It("Rejects an ARN of a different resource type", func() {
+		err := validateARN("arn:aws:iam::123456789012:user/my-user", iamService, roleResourceType, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("to be a 'role' resource"))
+
+		err = validateARN("arn:aws:iam::123456789012:role/", iamService, roleResourceType, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("to be a 'role' resource"))
+	})
+
+	It("Rejects an invalid account", func() {
+		err := validateARN(
+			"arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
+			iamService, roleResourceType, "",
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("12 digit"))
+	})
+
+	It("Rejects an ARN of a different account", func() {
+		err := validateARN(
+			"arn:aws:iam::210987654321:role/ManagedOpenShift-Installer-Role",
+			iamService, roleResourceType, "123456789012",
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("belongs to AWS account '210987654321'"))
+	})
+})
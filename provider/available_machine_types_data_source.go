@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+type AvailableMachineTypesDataSourceType struct {
+}
+
+type AvailableMachineTypesDataSource struct {
+	logger      logging.Logger
+	collection  *cmv1.MachineTypesClient
+	cache       *lookupCache
+	awsSettings awsSettings
+}
+
+func (t *AvailableMachineTypesDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "List of AWS machine types that are available in a region and, " +
+			"optionally, in a set of availability zones. The availability is checked " +
+			"directly against AWS, using the AWS credentials of the provider.",
+		Attributes: map[string]tfsdk.Attribute{
+			"region": {
+				Description: "AWS region, for example 'us-east-1'.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"availability_zones": {
+				Description: "Availability zones, for example 'us-east-1a'. When " +
+					"given, only the machine types offered in all the zones are " +
+					"returned.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+			},
+			"items": {
+				Description: "Items of the list.",
+				Attributes: tfsdk.ListNestedAttributes(
+					machineTypeAttributes(),
+					tfsdk.ListNestedAttributesOptions{},
+				),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *AvailableMachineTypesDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &AvailableMachineTypesDataSource{
+		logger:      parent.logger,
+		collection:  parent.connection.ClustersMgmt().V1().MachineTypes(),
+		cache:       parent.cache,
+		awsSettings: parent.awsSettings,
+	}
+	return
+}
+
+func (s *AvailableMachineTypesDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &AvailableMachineTypesState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the machine types supported by OCM:
+	listItems, err := s.cache.getMachineTypes(func() ([]*cmv1.MachineType, error) {
+		return listMachineTypes(ctx, s.collection)
+	})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't list machine types",
+			err.Error(),
+		)
+		return
+	}
+
+	// Fetch the instance types offered by AWS in the region and zones:
+	var zones []string
+	if !state.AvailabilityZones.Unknown && !state.AvailabilityZones.Null {
+		zones, err = common.StringListToArray(state.AvailabilityZones)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't get availability zones",
+				err.Error(),
+			)
+			return
+		}
+	}
+	sess, err := buildSession(state.Region.Value, s.awsSettings)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't create AWS session",
+			fmt.Sprintf(
+				"Can't create AWS session for region '%s': %v",
+				state.Region.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	offered, err := offeredInstanceTypes(ec2.New(sess), state.Region.Value, zones)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't list instance type offerings",
+			fmt.Sprintf(
+				"Can't list instance type offerings of region '%s': %v",
+				state.Region.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state:
+	state.Items = []*MachineTypeState{}
+	for _, listItem := range listItems {
+		if listItem.CloudProvider().ID() != "aws" || !offered[listItem.ID()] {
+			continue
+		}
+		item, err := machineTypeState(listItem)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't convert machine type",
+				err.Error(),
+			)
+			return
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
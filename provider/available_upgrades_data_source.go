@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ver "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+type AvailableUpgradesDataSourceType struct {
+}
+
+type AvailableUpgradesDataSource struct {
+	logger     logging.Logger
+	clusters   *cmv1.ClustersClient
+	collection *cmv1.VersionsClient
+	refresh    *refreshCache
+}
+
+func (t *AvailableUpgradesDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "List of OpenShift versions that a cluster can be upgraded to.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"current_version": {
+				Description: "Identifier of the version currently installed in the cluster, " +
+					"for example 'openshift-v4.12.1'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"channel_group": {
+				Description: "Channel group of the cluster. Only versions of this channel " +
+					"group are returned.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"items": {
+				Description: "Versions that the cluster can be upgraded to, sorted from " +
+					"the closest to the current version to the most recent one. Versions " +
+					"that aren't enabled for the product of the cluster are excluded.",
+				Attributes: tfsdk.ListNestedAttributes(
+					t.itemAttributes(),
+					tfsdk.ListNestedAttributesOptions{},
+				),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *AvailableUpgradesDataSourceType) itemAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			Description: "Unique identifier of the version, for example " +
+				"'openshift-v4.12.2'.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"name": {
+			Description: "Short name of the version, for example '4.12.2'.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"default": {
+			Description: "Indicates if this is the version used by default when creating " +
+				"clusters.",
+			Type:     types.BoolType,
+			Computed: true,
+		},
+		"end_of_life_timestamp": {
+			Description: "Date and time when the version reaches the end of its " +
+				"support, in RFC 3339 format. Null if the end of life of the version " +
+				"hasn't been announced yet.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+	}
+}
+
+func (t *AvailableUpgradesDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &AvailableUpgradesDataSource{
+		logger:     parent.logger,
+		clusters:   parent.connection.ClustersMgmt().V1().Clusters(),
+		collection: parent.connection.ClustersMgmt().V1().Versions(),
+		refresh:    parent.refresh,
+	}
+	return
+}
+
+func (s *AvailableUpgradesDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &AvailableUpgradesState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the cluster and the version that it is running:
+	cluster, err := s.refresh.getCluster(ctx, s.clusters, state.Cluster.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster",
+			fmt.Sprintf(
+				"Can't find cluster with identifier '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	channelGroup := ocm.DefaultChannelGroup
+	if value, ok := cluster.Version().GetChannelGroup(); ok && value != "" {
+		channelGroup = value
+	}
+	versionID := cluster.Version().ID()
+	version, err := s.collection.Version(versionID).Get().SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find version",
+			fmt.Sprintf(
+				"Can't find version '%s' of cluster '%s': %v",
+				versionID, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Fetch the versions that the cluster can be upgraded to:
+	upgrades, err := s.listUpgrades(ctx, cluster, channelGroup, version.Body().AvailableUpgrades())
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't list available upgrades",
+			fmt.Sprintf(
+				"Can't list available upgrades for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state:
+	state.CurrentVersion = types.String{
+		Value: versionID,
+	}
+	state.ChannelGroup = types.String{
+		Value: channelGroup,
+	}
+	state.Items = make([]*VersionState, len(upgrades))
+	for i, upgrade := range upgrades {
+		state.Items[i] = newVersionState(upgrade)
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// listUpgrades retrieves the versions of the given channel group whose short names are in the
+// given list of available upgrades, and that are enabled for the product of the cluster. The
+// result is sorted by ascending semver.
+func (s *AvailableUpgradesDataSource) listUpgrades(ctx context.Context, cluster *cmv1.Cluster,
+	channelGroup string, rawIDs []string) (result []*cmv1.Version, err error) {
+	if len(rawIDs) == 0 {
+		return
+	}
+	quoted := make([]string, len(rawIDs))
+	for i, rawID := range rawIDs {
+		quoted[i] = fmt.Sprintf("'%s'", rawID)
+	}
+	filters := []string{
+		"enabled = 'true'",
+		fmt.Sprintf("channel_group = '%s'", channelGroup),
+		fmt.Sprintf("raw_id in (%s)", strings.Join(quoted, ", ")),
+	}
+	if cluster.Product().ID() == "rosa" {
+		filters = append(filters, "rosa_enabled = 'true'")
+	}
+	page := 1
+	size := 100
+	for {
+		var response *cmv1.VersionsListResponse
+		response, err = s.collection.List().
+			Search(strings.Join(filters, " AND ")).
+			Page(page).
+			Size(size).
+			SendContext(ctx)
+		if err != nil {
+			return
+		}
+		result = append(result, response.Items().Slice()...)
+		if response.Items().Len() == 0 || len(result) >= response.Total() {
+			break
+		}
+		page++
+	}
+
+	// Sort the list so that the closest version comes first:
+	sort.SliceStable(result, func(i, j int) bool {
+		a, erra := ver.NewVersion(result[i].RawID())
+		b, errb := ver.NewVersion(result[j].RawID())
+		if erra != nil || errb != nil {
+			return result[i].RawID() < result[j].RawID()
+		}
+		return a.LessThan(b)
+	})
+	return
+}
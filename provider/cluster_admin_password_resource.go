@@ -0,0 +1,329 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// defaultClusterAdmin is the name of the identity provider, and of the user, that ROSA creates
+// for the cluster administrator.
+const defaultClusterAdmin = "cluster-admin"
+
+type ClusterAdminPasswordResourceType struct {
+}
+
+type ClusterAdminPasswordResource struct {
+	logger     logging.Logger
+	collection *cmv1.ClustersClient
+}
+
+func (t *ClusterAdminPasswordResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Password of the cluster administrator. Creating the resource " +
+			"generates a new password and sets it for the user of the 'htpasswd' " +
+			"identity provider. Destroying the resource keeps the last password.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				Description: "Unique identifier of the user.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"identity_provider": {
+				Description: "Name of the 'htpasswd' identity provider that contains " +
+					"the user. The default is 'cluster-admin'.",
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"username": {
+				Description: "Name of the user. The default is 'cluster-admin'.",
+				Type:        types.StringType,
+				Optional:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": {
+				Description: "Arbitrary values that, when changed, generate a new " +
+					"password. For example a date to rotate the password periodically.",
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"password": {
+				Description: "Generated password.",
+				Type:        types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+	return
+}
+
+func (t *ClusterAdminPasswordResourceType) NewResource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.Resource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation: use it directly when needed.
+	parent := p.(*Provider)
+
+	// Get the collection of clusters:
+	collection := parent.connection.ClustersMgmt().V1().Clusters()
+
+	// Create the resource:
+	result = &ClusterAdminPasswordResource{
+		logger:     parent.logger,
+		collection: collection,
+	}
+
+	return
+}
+
+func (r *ClusterAdminPasswordResource) Create(ctx context.Context,
+	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	// Get the plan:
+	state := &ClusterAdminPasswordState{}
+	diags := request.Plan.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the user:
+	username := r.username(state)
+	usersResource, user, err := r.findUser(ctx, state)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster administrator",
+			fmt.Sprintf(
+				"Can't find user '%s' for cluster '%s': %v",
+				username, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	if user == nil {
+		response.Diagnostics.AddError(
+			"Can't find cluster administrator",
+			fmt.Sprintf(
+				"User '%s' doesn't exist in identity provider '%s' of cluster '%s'",
+				username, r.identityProvider(state), state.Cluster.Value,
+			),
+		)
+		return
+	}
+
+	// Generate and set the new password:
+	password, err := generatePassword()
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't generate password",
+			err.Error(),
+		)
+		return
+	}
+	patch, err := cmv1.NewHTPasswdUser().
+		Password(password).
+		Build()
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't build user",
+			fmt.Sprintf(
+				"Can't build user '%s': %v",
+				username, err,
+			),
+			err,
+		)
+		return
+	}
+	_, err = usersResource.HtpasswdUser(user.ID()).Update().Body(patch).SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't change password",
+			fmt.Sprintf(
+				"Can't change password of user '%s' for cluster '%s': %v",
+				username, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Save the state:
+	state.ID = types.String{
+		Value: user.ID(),
+	}
+	state.Password = types.String{
+		Value: password,
+	}
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterAdminPasswordResource) Read(ctx context.Context, request tfsdk.ReadResourceRequest,
+	response *tfsdk.ReadResourceResponse) {
+	// Get the current state:
+	state := &ClusterAdminPasswordState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Check that the user still exists, otherwise remove the resource so that a new password is
+	// generated:
+	_, user, err := r.findUser(ctx, state)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster administrator",
+			fmt.Sprintf(
+				"Can't find user '%s' for cluster '%s': %v",
+				r.username(state), state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	if user == nil || user.ID() != state.ID.Value {
+		r.logger.Warn(ctx, "User '%s' of cluster '%s' not found, removing from state",
+			r.username(state), state.Cluster.Value)
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterAdminPasswordResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
+	response *tfsdk.UpdateResourceResponse) {
+	// All the attributes that can be configured require replacement, so there is nothing to
+	// update in the server:
+	state := &ClusterAdminPasswordState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterAdminPasswordResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
+	response *tfsdk.DeleteResourceResponse) {
+	// The password stays in place, so only the state is removed:
+	response.State.RemoveResource(ctx)
+}
+
+func (r *ClusterAdminPasswordResource) ImportState(ctx context.Context,
+	request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
+	// The API doesn't return the password, so there is nothing to import:
+	tfsdk.ResourceImportStateNotImplemented(
+		ctx,
+		"The password of the cluster administrator can't be read from the API",
+		response,
+	)
+}
+
+// identityProvider returns the name of the identity provider, applying the default.
+func (r *ClusterAdminPasswordResource) identityProvider(state *ClusterAdminPasswordState) string {
+	if common.IsStringAttributeEmpty(state.IdentityProvider) {
+		return defaultClusterAdmin
+	}
+	return state.IdentityProvider.Value
+}
+
+// username returns the name of the user, applying the default.
+func (r *ClusterAdminPasswordResource) username(state *ClusterAdminPasswordState) string {
+	if common.IsStringAttributeEmpty(state.Username) {
+		return defaultClusterAdmin
+	}
+	return state.Username.Value
+}
+
+// findUser returns the client of the users of the identity provider and the user. The returned
+// user is nil if the identity provider exists but doesn't contain the user.
+func (r *ClusterAdminPasswordResource) findUser(ctx context.Context,
+	state *ClusterAdminPasswordState) (*cmv1.HTPasswdUsersClient, *cmv1.HTPasswdUser, error) {
+	idpsResource := r.collection.Cluster(state.Cluster.Value).IdentityProviders()
+	list, err := idpsResource.List().SendContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := r.identityProvider(state)
+	var idp *cmv1.IdentityProvider
+	list.Items().Each(func(item *cmv1.IdentityProvider) bool {
+		if item.Name() == name {
+			idp = item
+			return false
+		}
+		return true
+	})
+	if idp == nil {
+		return nil, nil, fmt.Errorf("identity provider '%s' doesn't exist", name)
+	}
+	if idp.Type() != cmv1.IdentityProviderTypeHtpasswd {
+		return nil, nil, fmt.Errorf(
+			"identity provider '%s' is of type '%s' instead of 'htpasswd'",
+			name, idp.Type(),
+		)
+	}
+	usersResource := idpsResource.IdentityProvider(idp.ID()).HtpasswdUsers()
+	users, err := usersResource.List().SendContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	username := r.username(state)
+	var user *cmv1.HTPasswdUser
+	users.Items().Each(func(item *cmv1.HTPasswdUser) bool {
+		if item.Username() == username {
+			user = item
+			return false
+		}
+		return true
+	})
+	return usersResource, user, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+type ClusterCredentialsDataSourceType struct {
+}
+
+type ClusterCredentialsDataSource struct {
+	logger     logging.Logger
+	collection *cmv1.ClustersClient
+	client     *http.Client
+}
+
+func (t *ClusterCredentialsDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Credentials of a cluster, in the shape used by the Kubernetes and " +
+			"Helm providers, so that they can be configured in the same apply that creates " +
+			"the cluster. The cluster must be ready, use the 'ocm_cluster_wait' resource to " +
+			"wait for it. The administrator credentials are the ones stored by OCM, which " +
+			"only stores them for some kinds of clusters, for the rest they are null.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"username": {
+				Description: "Name of a user of the cluster, for example the one created " +
+					"by the 'ocm_cluster_admin_password' resource. When it is given, " +
+					"together with 'password', the provider logs in to the OAuth server " +
+					"of the cluster and returns the token in the 'token' attribute.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"password": {
+				Description: "Password of the user given in 'username'.",
+				Type:        types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"host": {
+				Description: "URL of the API server of the cluster, for the 'host' " +
+					"attribute of the Kubernetes provider.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"cluster_ca_certificate": {
+				Description: "PEM encoded CA certificates of the API server, for the " +
+					"'cluster_ca_certificate' attribute of the Kubernetes provider. Null " +
+					"if OCM doesn't have them, in that case the certificate of the API " +
+					"server must be trusted by the system.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"token": {
+				Description: "Token of the user given in 'username', for the 'token' " +
+					"attribute of the Kubernetes provider. Null if 'username' isn't given. " +
+					"Tokens expire, so the data source should be read in every apply.",
+				Type:      types.StringType,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"kubeconfig": {
+				Description: "Administrator kubeconfig of the cluster, null if OCM " +
+					"doesn't have it.",
+				Type:      types.StringType,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"admin_username": {
+				Description: "Name of the administrator user of the cluster, null if OCM " +
+					"doesn't have it.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"admin_password": {
+				Description: "Password of the administrator user of the cluster, null if " +
+					"OCM doesn't have it.",
+				Type:      types.StringType,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *ClusterCredentialsDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &ClusterCredentialsDataSource{
+		logger:     parent.logger,
+		collection: parent.connection.ClustersMgmt().V1().Clusters(),
+		client:     newEndpointClient(parent.endpointTransport),
+	}
+	return
+}
+
+func (s *ClusterCredentialsDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &ClusterCredentialsState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	username := ""
+	if !state.Username.Unknown && !state.Username.Null {
+		username = state.Username.Value
+	}
+	password := ""
+	if !state.Password.Unknown && !state.Password.Null {
+		password = state.Password.Value
+	}
+	if (username == "") != (password == "") {
+		response.Diagnostics.AddError(
+			"Incomplete user credentials",
+			"Attributes 'username' and 'password' must be given together",
+		)
+		return
+	}
+
+	// The API server and its OAuth server only answer when the cluster is ready:
+	cluster, err := s.collection.Cluster(state.Cluster.Value).Get().SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster",
+			fmt.Sprintf(
+				"Can't find cluster with identifier '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	if cluster.Body().State() != cmv1.ClusterStateReady || cluster.Body().API().URL() == "" {
+		response.Diagnostics.AddError(
+			"Cluster isn't ready",
+			fmt.Sprintf(
+				"Cluster '%s' is in state '%s', the credentials can only be retrieved "+
+					"when it is ready. Add a dependency on an 'ocm_cluster_wait' "+
+					"resource for the cluster, so that they are retrieved after it "+
+					"is ready.",
+				state.Cluster.Value, cluster.Body().State(),
+			),
+		)
+		return
+	}
+	state.Host = types.String{
+		Value: cluster.Body().API().URL(),
+	}
+
+	// Fetch the credentials, the server answers with a not found error when it doesn't have
+	// them:
+	state.Kubeconfig = types.String{
+		Null: true,
+	}
+	state.AdminUsername = types.String{
+		Null: true,
+	}
+	state.AdminPassword = types.String{
+		Null: true,
+	}
+	get, err := s.collection.Cluster(state.Cluster.Value).Credentials().Get().SendContext(ctx)
+	if err != nil {
+		sdkErr, ok := err.(*errors.Error)
+		if !ok || sdkErr.Status() != http.StatusNotFound {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't get cluster credentials",
+				fmt.Sprintf(
+					"Can't get credentials of cluster '%s': %v",
+					state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+	} else {
+		credentials := get.Body()
+		state.Kubeconfig = common.OptionalString(credentials.Kubeconfig())
+		state.AdminUsername = common.OptionalString(credentials.Admin().User())
+		state.AdminPassword = common.OptionalString(credentials.Admin().Password())
+	}
+	state.ClusterCACertificate = common.OptionalString(kubeconfigCA(state.Kubeconfig.Value))
+
+	// Log in with the user, if given:
+	state.Token = types.String{
+		Null: true,
+	}
+	if username != "" {
+		token, err := requestOAuthToken(ctx, s.client, state.Host.Value, username, password)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Can't get token",
+				fmt.Sprintf(
+					"Can't get token for user '%s' of cluster '%s': %v",
+					username, state.Cluster.Value, err,
+				),
+			)
+			return
+		}
+		state.Token = types.String{
+			Value: token,
+		}
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
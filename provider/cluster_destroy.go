@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// clusterUninstallError is returned when OCM reports that the uninstallation of a cluster failed.
+// The cluster still exists, so it has to stay in the Terraform state.
+type clusterUninstallError struct {
+	id     string
+	reason string
+}
+
+func (e *clusterUninstallError) Error() string {
+	return fmt.Sprintf("uninstallation of cluster '%s' failed: %s", e.id, e.reason)
+}
+
+// uninstallErrorReason returns the reason of the failure of the uninstallation of the given
+// cluster, as reported by OCM.
+func uninstallErrorReason(object *cmv1.Cluster) string {
+	reason := object.Status().ProvisionErrorMessage()
+	if reason == "" {
+		reason = object.Status().Description()
+	}
+	if reason == "" {
+		reason = "no error message was reported"
+	}
+	return reason
+}
+
+// deleteCluster sends the request to delete the given cluster. It returns true if the cluster
+// doesn't exist anymore, and it isn't an error if the cluster is already being uninstalled, for
+// example because a previous destroy was interrupted.
+func deleteCluster(ctx context.Context, resource *cmv1.ClusterClient) (gone bool, err error) {
+	_, err = resource.Delete().SendContext(ctx)
+	if err == nil {
+		return
+	}
+	sdkErr, ok := err.(*ocmerrors.Error)
+	if !ok {
+		return
+	}
+	if sdkErr.Status() == http.StatusNotFound {
+		return true, nil
+	}
+	if sdkErr.Status() >= http.StatusInternalServerError {
+		return
+	}
+	get, getErr := resource.Get().SendContext(ctx)
+	if getErr != nil {
+		getSDKErr, ok := getErr.(*ocmerrors.Error)
+		if ok && getSDKErr.Status() == http.StatusNotFound {
+			return true, nil
+		}
+		return
+	}
+	if get.Body().State() == cmv1.ClusterStateUninstalling {
+		return false, nil
+	}
+	return
+}
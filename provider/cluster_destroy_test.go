@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	. "github.com/onsi/gomega/ghttp"       // nolint
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster destroy", func() {
+	var server *Server
+	var connection *sdk.Connection
+	var resource *cmv1.ClusterClient
+
+	const clusterPath = "/api/clusters_mgmt/v1/clusters/123"
+
+	BeforeEach(func() {
+		var err error
+		server = NewServer()
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(MakeTokenString("Bearer", 10*time.Minute)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		resource = connection.ClustersMgmt().V1().Clusters().Cluster("123")
+	})
+
+	AfterEach(func() {
+		connection.Close()
+		server.Close()
+	})
+
+	It("Considers a cluster that doesn't exist as deleted", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, clusterPath),
+				RespondWithJSON(http.StatusNotFound, `{
+				  "kind": "Error",
+				  "id": "404",
+				  "reason": "Cluster not found"
+				}`),
+			),
+		)
+		gone, err := deleteCluster(context.Background(), resource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gone).To(BeTrue())
+	})
+
+	It("Accepts a cluster that is already uninstalling", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, clusterPath),
+				RespondWithJSON(http.StatusBadRequest, `{
+				  "kind": "Error",
+				  "id": "400",
+				  "reason": "Cluster is already being deleted"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, clusterPath),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "state": "uninstalling"
+				}`),
+			),
+		)
+		gone, err := deleteCluster(context.Background(), resource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gone).To(BeFalse())
+	})
+
+	It("Fails if the cluster can't be deleted", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, clusterPath),
+				RespondWithJSON(http.StatusBadRequest, `{
+				  "kind": "Error",
+				  "id": "400",
+				  "reason": "Cluster can't be deleted"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, clusterPath),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "state": "ready"
+				}`),
+			),
+		)
+		_, err := deleteCluster(context.Background(), resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Cluster can't be deleted"))
+	})
+
+	It("Reports the reason of a failed uninstallation", func() {
+		server.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{
+			  "id": "123",
+			  "state": "uninstalling"
+			}`),
+			RespondWithJSON(http.StatusOK, `{
+			  "id": "123",
+			  "state": "error",
+			  "status": {
+			    "provision_error_message": "Can't delete the VPC"
+			  }
+			}`),
+		)
+		err := pollClusterTillNotFound(context.Background(), resource, time.Millisecond)
+		Expect(err).To(HaveOccurred())
+		uninstallErr, ok := err.(*clusterUninstallError)
+		Expect(ok).To(BeTrue())
+		Expect(uninstallErr.reason).To(Equal("Can't delete the VPC"))
+	})
+
+	It("Stops waiting when the cluster disappears while uninstalling", func() {
+		server.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{
+			  "id": "123",
+			  "state": "uninstalling"
+			}`),
+			RespondWithJSON(http.StatusNotFound, `{
+			  "kind": "Error",
+			  "id": "404",
+			  "reason": "Cluster not found"
+			}`),
+		)
+		err := pollClusterTillNotFound(context.Background(), resource, time.Millisecond)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// dryRunSummary is the summary of the diagnostics of the dry run of the creation of a cluster.
+const dryRunSummary = "Dry run"
+
+// dryRunCluster sends the given cluster to the server with the 'dryRun' parameter, so that the
+// server runs all its validations without creating it. The server answers with 204 No Content
+// when the cluster would be accepted.
+func dryRunCluster(ctx context.Context, collection *cmv1.ClustersClient, object *cmv1.Cluster) error {
+	response, err := collection.Add().
+		Parameter("dryRun", true).
+		Body(object).
+		SendContext(ctx)
+	if response != nil && response.Status() == http.StatusNoContent {
+		// The body of the response is empty, so the SDK may report that it couldn't read it:
+		return nil
+	}
+	return err
+}
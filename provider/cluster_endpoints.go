@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// oidcDiscoveryPath is the path of the OIDC discovery document, relative to the OIDC endpoint of
+// the cluster.
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+// endpointTimeout is the maximum time that a single check of an endpoint can take.
+const endpointTimeout = 30 * time.Second
+
+// newEndpointTransport creates the transport used to send requests to the endpoints of clusters,
+// with the same proxy and TLS settings as the connection to the OCM API. The given trusted
+// certificate authorities, in PEM format, are added to the ones of the system, as the endpoints of
+// the clusters usually have certificates issued by public authorities.
+func newEndpointTransport(proxy *httpproxy.Config, trustedCAs [][]byte,
+	insecure bool) *http.Transport {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	for _, data := range trustedCAs {
+		roots.AppendCertsFromPEM(data)
+	}
+	proxyFunc := proxy.ProxyFunc()
+	result := http.DefaultTransport.(*http.Transport).Clone()
+	result.Proxy = func(request *http.Request) (*url.URL, error) {
+		return proxyFunc(request.URL)
+	}
+	result.TLSClientConfig = &tls.Config{
+		RootCAs:            roots,
+		InsecureSkipVerify: insecure, // nolint:gosec
+	}
+	return result
+}
+
+// newEndpointClient creates the HTTP client used to check the endpoints of clusters, using the
+// given transport, or the default one if it is nil. Redirects aren't followed, as the console
+// redirects to the OAuth server, and receiving the redirect is enough to know that the ingress
+// works.
+func newEndpointClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   endpointTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkEndpoints checks that the OIDC discovery document of the cluster can be retrieved, and that
+// the console answers, which means that the ingress of the cluster is working. Clusters without
+// an OIDC endpoint only check the console.
+func checkEndpoints(ctx context.Context, client *http.Client, cluster *cmv1.Cluster) error {
+	oidcURL := cluster.AWS().STS().OIDCEndpointURL()
+	if oidcURL != "" {
+		if !strings.Contains(oidcURL, "://") {
+			oidcURL = "https://" + oidcURL
+		}
+		oidcURL = strings.TrimSuffix(oidcURL, "/") + oidcDiscoveryPath
+		err := checkEndpoint(ctx, client, oidcURL, func(status int) bool {
+			return status == http.StatusOK
+		})
+		if err != nil {
+			return err
+		}
+	}
+	consoleURL := cluster.Console().URL()
+	if consoleURL == "" {
+		return fmt.Errorf("cluster '%s' doesn't have a console URL yet", cluster.ID())
+	}
+	return checkEndpoint(ctx, client, consoleURL, func(status int) bool {
+		return status < http.StatusInternalServerError
+	})
+}
+
+// checkEndpoint sends a GET request to the given URL and checks the response status with the
+// given function.
+func checkEndpoint(ctx context.Context, client *http.Client, url string,
+	accept func(status int) bool) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("can't create request for '%s': %v", url, err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("can't reach '%s': %v", url, err)
+	}
+	defer response.Body.Close()
+	if !accept(response.StatusCode) {
+		return fmt.Errorf("unexpected status code %d from '%s'", response.StatusCode, url)
+	}
+	return nil
+}
+
+// computeNodesReady checks if the number of compute nodes reported by the cluster has reached
+// the requested number of replicas, or the minimum number of replicas when autoscaling.
+func computeNodesReady(cluster *cmv1.Cluster) bool {
+	desired := cluster.Nodes().Compute()
+	autoscaling, ok := cluster.Nodes().GetAutoscaleCompute()
+	if ok {
+		desired = autoscaling.MinReplicas()
+	}
+	return cluster.Status().CurrentCompute() >= desired
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Cluster endpoints", func() {
+	var oidcStatus int
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		oidcStatus = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/oidc" + oidcDiscoveryPath:
+				w.WriteHeader(oidcStatus)
+			case "/console":
+				http.Redirect(w, r, "/oauth", http.StatusFound)
+			default:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	buildEndpointsCluster := func(oidcPath, consolePath string) *cmv1.Cluster {
+		builder := cmv1.NewCluster().ID("123").Console(cmv1.NewClusterConsole().URL(server.URL + consolePath))
+		if oidcPath != "" {
+			builder.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().OIDCEndpointURL(server.URL + oidcPath)))
+		}
+		cluster, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	It("Accepts reachable OIDC and console endpoints", func() {
+		err := checkEndpoints(context.Background(), newEndpointClient(nil), buildEndpointsCluster("/oidc/", "/console"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Checks only the console of clusters without OIDC endpoint", func() {
+		err := checkEndpoints(context.Background(), newEndpointClient(nil), buildEndpointsCluster("", "/console"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Rejects a missing OIDC discovery document", func() {
+		oidcStatus = http.StatusNotFound
+		err := checkEndpoints(context.Background(), newEndpointClient(nil), buildEndpointsCluster("/oidc", "/console"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unexpected status code 404"))
+	})
+
+	It("Rejects a console that isn't served yet", func() {
+		err := checkEndpoints(context.Background(), newEndpointClient(nil), buildEndpointsCluster("", "/other"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unexpected status code 503"))
+	})
+
+	It("Trusts the certificate authorities of the provider", func() {
+		tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer tlsServer.Close()
+		accept := func(status int) bool {
+			return status == http.StatusOK
+		}
+
+		// Without the certificate authority of the server the check fails:
+		client := newEndpointClient(newEndpointTransport(proxyConfig(nil, ""), nil, false))
+		err := checkEndpoint(context.Background(), client, tlsServer.URL, accept)
+		Expect(err).To(HaveOccurred())
+
+		ca := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: tlsServer.Certificate().Raw,
+		})
+		client = newEndpointClient(newEndpointTransport(proxyConfig(nil, ""), [][]byte{ca}, false))
+		err = checkEndpoint(context.Background(), client, tlsServer.URL, accept)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Sends the requests through the proxy of the provider", func() {
+		var hosts []string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hosts = append(hosts, r.Host)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer proxy.Close()
+		proxyURL, err := parseProxyURL(proxy.URL)
+		Expect(err).ToNot(HaveOccurred())
+
+		client := newEndpointClient(newEndpointTransport(proxyConfig(proxyURL, ""), nil, false))
+		err = checkEndpoint(context.Background(), client, "http://console.example.com/console",
+			func(status int) bool {
+				return status == http.StatusOK
+			})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hosts).To(Equal([]string{"console.example.com"}))
+	})
+
+	It("Waits for the requested compute nodes", func() {
+		cluster, err := cmv1.NewCluster().
+			Nodes(cmv1.NewClusterNodes().Compute(3)).
+			Status(cmv1.NewClusterStatus().CurrentCompute(2)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(computeNodesReady(cluster)).To(BeFalse())
+
+		cluster, err = cmv1.NewCluster().
+			Nodes(cmv1.NewClusterNodes().AutoscaleCompute(
+				cmv1.NewMachinePoolAutoscaling().MinReplicas(2).MaxReplicas(5),
+			)).
+			Status(cmv1.NewClusterStatus().CurrentCompute(2)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(computeNodesReady(cluster)).To(BeTrue())
+	})
+})
@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// expirationTimestampDescription is the description of the attribute that sets the time when OCM
+// deletes the cluster automatically. It is shared by the cluster resources.
+const expirationTimestampDescription = "Date and time when the cluster will be deleted " +
+	"automatically by OCM, in RFC3339 format, for example '2023-08-01T12:00:00Z'. Useful for " +
+	"temporary clusters, as they are deleted even if 'terraform destroy' is never executed. " +
+	"It can be changed to extend the life of the cluster, but it can't be removed."
+
+// parseExpirationTimestamp parses the value of the expiration timestamp attribute.
+func parseExpirationTimestamp(text string) (time.Time, error) {
+	result, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"expected a date and time in RFC3339 format, for example "+
+				"'2023-08-01T12:00:00Z', but got '%s'",
+			text,
+		)
+	}
+	return result, nil
+}
+
+// expirationTimestampState returns the value of the expiration timestamp attribute for the given
+// cluster. The current value is preserved when it represents the same time, so that using a
+// different time zone than the server doesn't generate a difference.
+func expirationTimestampState(current types.String, object *cmv1.Cluster) types.String {
+	expiration, ok := object.GetExpirationTimestamp()
+	if !ok || expiration.IsZero() {
+		return types.String{
+			Null: true,
+		}
+	}
+	if !common.IsStringAttributeEmpty(current) {
+		parsed, err := parseExpirationTimestamp(current.Value)
+		if err == nil && parsed.Equal(expiration) {
+			return current
+		}
+	}
+	return types.String{
+		Value: expiration.UTC().Format(time.RFC3339),
+	}
+}
+
+func expirationTimestampValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate expiration timestamp",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				timestamp := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, timestamp)
+				if diag.HasError() || common.IsStringAttributeEmpty(*timestamp) {
+					// No attribute to validate
+					return
+				}
+				_, err := parseExpirationTimestamp(timestamp.Value)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid expiration timestamp",
+						err.Error(),
+					)
+				}
+			},
+		},
+	}
+}
+
+// updateExpirationTimestamp adds the expiration timestamp to the cluster patch if it has changed.
+// It returns an error if the timestamp was removed, as OCM doesn't support removing it.
+func updateExpirationTimestamp(state, plan types.String,
+	builder *cmv1.ClusterBuilder) (bool, error) {
+	if common.IsStringAttributeEmpty(plan) && !plan.Unknown && !common.IsStringAttributeEmpty(state) {
+		return false, fmt.Errorf(
+			"the expiration timestamp can't be removed, set a later date instead",
+		)
+	}
+	value, ok := common.ShouldPatchString(state, plan)
+	if !ok {
+		return false, nil
+	}
+	expiration, err := parseExpirationTimestamp(value)
+	if err != nil {
+		return false, err
+	}
+	builder.ExpirationTimestamp(expiration)
+	return true, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// oauthDiscoveryPath is the path of the document that describes the OAuth server of a cluster,
+// relative to the URL of the API server.
+const oauthDiscoveryPath = "/.well-known/oauth-authorization-server"
+
+// oauthChallengingClient is the OAuth client that returns tokens in exchange of basic
+// authentication credentials, the same that the 'oc login' command uses.
+const oauthChallengingClient = "openshift-challenging-client"
+
+// kubeconfigCARegexp extracts the CA of the API server from the kubeconfig generated by OCM,
+// which always uses the 'certificate-authority-data' field.
+var kubeconfigCARegexp = regexp.MustCompile(`certificate-authority-data"?\s*:\s*"?([A-Za-z0-9+/=]+)`)
+
+// kubeconfigCA returns the PEM encoded CA certificates contained in the given kubeconfig, or an
+// empty string if it doesn't contain them.
+func kubeconfigCA(kubeconfig string) string {
+	match := kubeconfigCARegexp.FindStringSubmatch(kubeconfig)
+	if match == nil {
+		return ""
+	}
+	data, err := base64.StdEncoding.DecodeString(match[1])
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// requestOAuthToken obtains a token for the given user from the OAuth server of the cluster,
+// using the challenging client. The client must not follow redirects, as the token is returned
+// in the location of the redirect.
+func requestOAuthToken(ctx context.Context, client *http.Client, apiURL, username,
+	password string) (token string, err error) {
+	// Find the OAuth server:
+	request, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, strings.TrimSuffix(apiURL, "/")+oauthDiscoveryPath, nil,
+	)
+	if err != nil {
+		return
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		err = fmt.Errorf("can't find the OAuth server of the cluster: %v", err)
+		return
+	}
+	var discovery struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&discovery)
+	response.Body.Close()
+	if err != nil || discovery.AuthorizationEndpoint == "" {
+		err = fmt.Errorf(
+			"can't find the OAuth server of the cluster, unexpected response with "+
+				"status code %d from '%s'",
+			response.StatusCode, request.URL,
+		)
+		return
+	}
+
+	// Request the token:
+	authorizeURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return
+	}
+	query := authorizeURL.Query()
+	query.Set("response_type", "token")
+	query.Set("client_id", oauthChallengingClient)
+	authorizeURL.RawQuery = query.Encode()
+	request, err = http.NewRequestWithContext(ctx, http.MethodGet, authorizeURL.String(), nil)
+	if err != nil {
+		return
+	}
+	request.SetBasicAuth(username, password)
+	request.Header.Set("X-CSRF-Token", "1")
+	response, err = client.Do(request)
+	if err != nil {
+		err = fmt.Errorf("can't request token from the OAuth server: %v", err)
+		return
+	}
+	response.Body.Close()
+	if response.StatusCode == http.StatusUnauthorized {
+		err = fmt.Errorf("the OAuth server rejected the credentials of user '%s'", username)
+		return
+	}
+	location, err := response.Location()
+	if err != nil {
+		err = fmt.Errorf(
+			"unexpected response with status code %d from the OAuth server",
+			response.StatusCode,
+		)
+		return
+	}
+	values, err := url.ParseQuery(location.Fragment)
+	if err != nil {
+		return
+	}
+	token = values.Get("access_token")
+	if token == "" {
+		err = fmt.Errorf("the OAuth server didn't return a token: %s", values.Get("error_description"))
+	}
+	return
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Cluster Kubernetes authentication", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case oauthDiscoveryPath:
+				_, _ = w.Write([]byte(`{"authorization_endpoint": "` + server.URL + `/oauth/authorize"}`))
+			case "/oauth/authorize":
+				user, password, _ := r.BasicAuth()
+				if r.URL.Query().Get("client_id") != oauthChallengingClient ||
+					r.Header.Get("X-CSRF-Token") == "" || user != "my-admin" || password != "my-password" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				http.Redirect(w, r, server.URL+"/oauth/token/implicit#access_token=my-token&token_type=Bearer", http.StatusFound)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Gets a token for the user", func() {
+		token, err := requestOAuthToken(context.Background(), newEndpointClient(nil), server.URL, "my-admin", "my-password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token).To(Equal("my-token"))
+	})
+
+	It("Reports rejected credentials", func() {
+		_, err := requestOAuthToken(context.Background(), newEndpointClient(nil), server.URL, "my-admin", "junk")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("rejected the credentials of user 'my-admin'"))
+	})
+
+	It("Extracts the CA from the kubeconfig", func() {
+		Expect(kubeconfigCA("clusters:\n- cluster:\n    certificate-authority-data: bXktY2E=\n")).To(Equal("my-ca"))
+		Expect(kubeconfigCA(`{"clusters": [{"cluster": {"certificate-authority-data": "bXktY2E="}}]}`)).To(Equal("my-ca"))
+		Expect(kubeconfigCA("clusters: []")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// Types of the logs that OCM keeps for clusters:
+const (
+	installLogType   = "install"
+	uninstallLogType = "uninstall"
+)
+
+// installLogDetail returns the given number of lines from the end of the install log of the
+// cluster, formatted to be appended to the detail of a diagnostic. It returns an empty string if
+// the number of lines is zero, and a note instead of the lines if the log can't be retrieved, so
+// that it never hides the original error.
+func installLogDetail(ctx context.Context, resource *cmv1.ClusterClient, lines int) string {
+	if lines <= 0 {
+		return ""
+	}
+	content, ok, err := fetchClusterLog(ctx, resource, installLogType, lines)
+	if err != nil {
+		return fmt.Sprintf("\n\nThe install log can't be retrieved: %v", err)
+	}
+	content = strings.TrimRight(content, "\n")
+	if !ok || content == "" {
+		return "\n\nThe install log isn't available."
+	}
+	return fmt.Sprintf("\n\nLast %d lines of the install log:\n%s", lines, content)
+}
+
+// fetchClusterLog retrieves the given type of log of a cluster. When tail isn't zero only that
+// number of lines from the end of the log are returned. The result is false if the log doesn't
+// exist yet, for example because the installation hasn't started.
+func fetchClusterLog(ctx context.Context, resource *cmv1.ClusterClient, logType string,
+	tail int) (content string, ok bool, err error) {
+	var client *cmv1.LogClient
+	switch logType {
+	case installLogType:
+		client = resource.Logs().Install()
+	case uninstallLogType:
+		client = resource.Logs().Uninstall()
+	default:
+		err = fmt.Errorf("unknown log type '%s'", logType)
+		return
+	}
+	request := client.Get()
+	if tail > 0 {
+		request.Tail(tail)
+	}
+	get, err := request.SendContext(ctx)
+	if err != nil {
+		sdkErr, isSDKErr := err.(*errors.Error)
+		if isSDKErr && sdkErr.Status() == http.StatusNotFound {
+			err = nil
+		}
+		return
+	}
+	content = get.Body().Content()
+	ok = true
+	return
+}
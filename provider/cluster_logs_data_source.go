@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+type ClusterLogsDataSourceType struct {
+}
+
+type ClusterLogsDataSource struct {
+	logger     logging.Logger
+	collection *cmv1.ClustersClient
+}
+
+func (t *ClusterLogsDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Installation or uninstallation log of a cluster, useful to find " +
+			"out why the provisioning of a cluster failed.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"type": {
+				Description: "Type of the log, one of '" + installLogType + "' or '" +
+					uninstallLogType + "'. The default is '" + installLogType + "'.",
+				Type:     types.StringType,
+				Optional: true,
+				Validators: EnumValueValidator([]string{
+					installLogType,
+					uninstallLogType,
+				}),
+			},
+			"tail": {
+				Description: "Number of lines from the end of the log to return. " +
+					"The default is to return the complete log.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"available": {
+				Description: "Indicates if the log is available. It isn't till the " +
+					"installation or uninstallation of the cluster starts.",
+				Type:     types.BoolType,
+				Computed: true,
+			},
+			"content": {
+				Description: "Content of the log, empty when it isn't available.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+	return
+}
+
+func (t *ClusterLogsDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &ClusterLogsDataSource{
+		logger:     parent.logger,
+		collection: parent.connection.ClustersMgmt().V1().Clusters(),
+	}
+	return
+}
+
+func (s *ClusterLogsDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &ClusterLogsState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	logType := installLogType
+	if !state.Type.Unknown && !state.Type.Null {
+		logType = state.Type.Value
+	}
+	tail := 0
+	if !state.Tail.Unknown && !state.Tail.Null {
+		if state.Tail.Value < 0 {
+			response.Diagnostics.AddError(
+				"Invalid tail",
+				fmt.Sprintf(
+					"The number of lines must be zero or positive but got %d",
+					state.Tail.Value,
+				),
+			)
+			return
+		}
+		tail = int(state.Tail.Value)
+	}
+
+	// Fetch the log:
+	content, ok, err := fetchClusterLog(ctx, s.collection.Cluster(state.Cluster.Value), logType, tail)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't get cluster log",
+			fmt.Sprintf(
+				"Can't get %s log of cluster '%s': %v",
+				logType, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	state.Available = types.Bool{
+		Value: ok,
+	}
+	state.Content = types.String{
+		Value: content,
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
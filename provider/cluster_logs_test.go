@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	. "github.com/onsi/gomega/ghttp"       // nolint
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Install log detail", func() {
+	var server *Server
+	var connection *sdk.Connection
+	var resource *cmv1.ClusterClient
+
+	BeforeEach(func() {
+		var err error
+		server = NewServer()
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(MakeTokenString("Bearer", 10*time.Minute)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		resource = connection.ClustersMgmt().V1().Clusters().Cluster("123")
+	})
+
+	AfterEach(func() {
+		connection.Close()
+		server.Close()
+	})
+
+	It("Is empty when disabled", func() {
+		Expect(installLogDetail(context.Background(), resource, 0)).To(BeEmpty())
+	})
+
+	It("Contains the last lines of the log", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/logs/install"),
+				VerifyFormKV("tail", "2"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "Log",
+				  "content": "first\nsecond\n"
+				}`),
+			),
+		)
+		detail := installLogDetail(context.Background(), resource, 2)
+		Expect(detail).To(Equal("\n\nLast 2 lines of the install log:\nfirst\nsecond"))
+	})
+
+	It("Explains that the log isn't available", func() {
+		server.AppendHandlers(
+			RespondWithJSON(http.StatusNotFound, `{
+			  "kind": "Error",
+			  "id": "404",
+			  "reason": "Log not found"
+			}`),
+		)
+		detail := installLogDetail(context.Background(), resource, 10)
+		Expect(detail).To(ContainSubstring("isn't available"))
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+// osdProduct is the product of the clusters created by the 'ocm_cluster_osd_aws' resource. It is
+// also used by the OSD clusters on GCP.
+const osdProduct = "osd"
+
+type ClusterOsdAwsResourceType struct {
+	logger logging.Logger
+}
+
+var _ tfsdk.ResourceWithModifyPlan = &ClusterOsdAwsResource{}
+
+// ClusterOsdAwsResource manages OSD clusters running in an AWS account of the customer, using
+// access keys instead of STS. It is the generic cluster resource with the product, the cloud
+// provider and the customer cloud subscription fixed, and the AWS credentials required.
+type ClusterOsdAwsResource struct {
+	*ClusterResource
+}
+
+func (t *ClusterOsdAwsResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result, diags = (&ClusterResourceType{logger: t.logger}).GetSchema(ctx)
+	result.Description = "OpenShift Dedicated cluster running in an AWS account of the " +
+		"customer (CCS), using the access keys of the 'osdCcsAdmin' IAM user instead of STS."
+	attributes := result.Attributes
+
+	// These are always the same for this kind of cluster, or don't apply to it:
+	for key, description := range map[string]string{
+		"product":        "Product of the cluster, always `" + osdProduct + "`.",
+		"cloud_provider": "Cloud provider of the cluster, always `" + awsCloudProvider + "`.",
+		"ccs_enabled":    "Indicates if the cluster uses a customer cloud subscription, always `true`.",
+		"load_balancer_quota": "Number of additional load balancers, not used by clusters " +
+			"that use a customer cloud subscription.",
+		"storage_quota": "Persistent storage quota in GiB, not used by clusters that use a " +
+			"customer cloud subscription.",
+	} {
+		attribute := attributes[key]
+		attribute.Description = description
+		attribute.Required = false
+		attribute.Optional = false
+		attribute.Computed = true
+		attribute.Validators = nil
+		attribute.PlanModifiers = nil
+		attributes[key] = attribute
+	}
+
+	cloudRegion := attributes["cloud_region"]
+	cloudRegion.Description = "AWS region identifier, for example 'us-east-1'."
+	attributes["cloud_region"] = cloudRegion
+
+	accountID := attributes["aws_account_id"]
+	accountID.Description = "Identifier of the AWS account where the cluster will be created."
+	accountID.Required = true
+	accountID.Optional = false
+	accountID.Validators = awsAccountIDValidators()
+	accountID.PlanModifiers = []tfsdk.AttributePlanModifier{
+		tfsdk.RequiresReplace(),
+	}
+	attributes["aws_account_id"] = accountID
+
+	accessKeyID := attributes["aws_access_key_id"]
+	accessKeyID.Description = "Identifier of the access key of the 'osdCcsAdmin' IAM user."
+	accessKeyID.Required = true
+	accessKeyID.Optional = false
+	attributes["aws_access_key_id"] = accessKeyID
+
+	secretAccessKey := attributes["aws_secret_access_key"]
+	secretAccessKey.Description = "Secret of the access key of the 'osdCcsAdmin' IAM user."
+	secretAccessKey.Required = true
+	secretAccessKey.Optional = false
+	attributes["aws_secret_access_key"] = secretAccessKey
+
+	return
+}
+
+func (t *ClusterOsdAwsResourceType) NewResource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.Resource, diags diag.Diagnostics) {
+	resource, diags := (&ClusterResourceType{logger: t.logger}).NewResource(ctx, p)
+	if diags.HasError() {
+		return
+	}
+	result = &ClusterOsdAwsResource{
+		ClusterResource: resource.(*ClusterResource),
+	}
+	return
+}
+
+// ModifyPlan sets the attributes that are always the same for this kind of cluster, so that the
+// generic cluster resource sends them when creating the cluster.
+func (r *ClusterOsdAwsResource) ModifyPlan(ctx context.Context,
+	request tfsdk.ModifyResourcePlanRequest, response *tfsdk.ModifyResourcePlanResponse) {
+	r.ClusterResource.ModifyPlan(ctx, request, response)
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	for name, value := range map[string]string{
+		"product":        osdProduct,
+		"cloud_provider": awsCloudProvider,
+	} {
+		response.Diagnostics.Append(response.Plan.SetAttribute(
+			ctx,
+			tftypes.NewAttributePath().WithAttributeName(name),
+			types.String{Value: value},
+		)...)
+	}
+	response.Diagnostics.Append(response.Plan.SetAttribute(
+		ctx,
+		tftypes.NewAttributePath().WithAttributeName("ccs_enabled"),
+		types.Bool{Value: true},
+	)...)
+}
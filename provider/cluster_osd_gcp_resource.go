@@ -0,0 +1,899 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+const (
+	// gcpCloudProvider is the cloud provider of the clusters created by the
+	// 'ocm_cluster_osd_gcp' resource.
+	gcpCloudProvider = "gcp"
+
+	// gcpMarketplaceBillingModel is the billing model of the clusters billed through the Google
+	// Cloud Marketplace.
+	gcpMarketplaceBillingModel = "marketplace-gcp"
+)
+
+// gcpBillingModels are the billing models that can be requested when creating OSD clusters on
+// GCP.
+var gcpBillingModels = []string{
+	string(cmv1.BillingModelStandard),
+	gcpMarketplaceBillingModel,
+}
+
+type ClusterOsdGcpResourceType struct {
+	logger logging.Logger
+}
+
+var _ tfsdk.ResourceWithModifyPlan = &ClusterOsdGcpResource{}
+
+type ClusterOsdGcpResource struct {
+	logger                logging.Logger
+	collection            *cmv1.ClustersClient
+	machineTypeCollection *cmv1.MachineTypesClient
+	cache                 *lookupCache
+	refresh               *refreshCache
+	pollInterval          time.Duration
+	installLogLines       int
+
+	// defaultProperties are the properties from the provider configuration that are added to
+	// the cluster.
+	defaultProperties map[string]string
+}
+
+func (t *ClusterOsdGcpResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "OpenShift Dedicated cluster running in a Google Cloud project of the " +
+			"customer (CCS).",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Description: "Unique identifier of the cluster.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"name": {
+				Description: "Name of the cluster. Must be a maximum of 15 lowercase alphanumeric characters or '-', " +
+					"start with a letter, and end with an alphanumeric character.",
+				Type:       types.StringType,
+				Required:   true,
+				Validators: clusterNameValidators(),
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"billing_model": {
+				Description: "Billing model of the cluster, one of 'standard' or " +
+					"'marketplace-gcp'. Clusters billed through the Google Cloud " +
+					"Marketplace use 'marketplace-gcp'. Default value is 'standard'.",
+				Type:       types.StringType,
+				Optional:   true,
+				Computed:   true,
+				Validators: EnumValueValidator(gcpBillingModels),
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
+			"cloud_region": {
+				Description: "GCP region identifier, for example 'us-east1'.",
+				Type:        types.StringType,
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"multi_az": {
+				Description: "Indicates if the cluster should be deployed to " +
+					"multiple availability zones. Default value is 'false'.",
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"availability_zones": {
+				Description: "GCP zones of the cluster, for example 'us-east1-b'. Multi " +
+					"zone clusters need three zones.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"gcp_service_account": {
+				Description: "Content of the JSON key of the 'osd-ccs-admin' service " +
+					"account of the GCP project where the cluster will be created. It " +
+					"is only sent when the cluster is created, the API doesn't return it.",
+				Type:       types.StringType,
+				Required:   true,
+				Sensitive:  true,
+				Validators: gcpServiceAccountValidators(),
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"gcp_project_id": {
+				Description: "Identifier of the GCP project of the cluster, taken from " +
+					"the service account.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"gcp_network": {
+				Description: "Existing VPC and subnets where the cluster will be " +
+					"created. When not set the installer creates the network.",
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"vpc_name": {
+						Description: "Name of the VPC.",
+						Type:        types.StringType,
+						Required:    true,
+					},
+					"control_plane_subnet": {
+						Description: "Name of the subnet of the control plane nodes.",
+						Type:        types.StringType,
+						Required:    true,
+					},
+					"compute_subnet": {
+						Description: "Name of the subnet of the compute nodes.",
+						Type:        types.StringType,
+						Required:    true,
+					},
+				}),
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"properties": {
+				Description: "User defined properties.",
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"compute_nodes": {
+				Description: "Number of compute nodes of the cluster.",
+				Type:        types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+			},
+			"compute_machine_type": {
+				Description: "Identifier of the machine type used by the compute nodes, " +
+					"for example `custom-4-16384`. Use the `ocm_machine_types` data " +
+					"source to find the possible values. The machine type is " +
+					"checked when planning.",
+				Type:     types.StringType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"machine_cidr": {
+				Description: "Block of IP addresses for nodes.",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"service_cidr": {
+				Description: "Block of IP addresses for services.",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"pod_cidr": {
+				Description: "Block of IP addresses for pods.",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"host_prefix": {
+				Description: "Length of the prefix of the subnet assigned to each node.",
+				Type:        types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"version": {
+				Description: "Identifier of the version of OpenShift, for example 'openshift-v4.12.3'.",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
+			"api_url": {
+				Description: "URL of the API server.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"console_url": {
+				Description: "URL of the console.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"domain": {
+				Description: "DNS domain of the cluster.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"infra_id": {
+				Description: "Infrastructure identifier of the cluster, used as prefix " +
+					"of the names and in the labels of the cloud resources of the cluster.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"state": {
+				Description: "State of the cluster.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"current_version": {
+				Description: "OpenShift version that the cluster runs, for example " +
+					"'4.12.3'. It changes when the cluster is upgraded.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"expiration_timestamp": {
+				Description: expirationTimestampDescription,
+				Type:        types.StringType,
+				Optional:    true,
+				Validators:  expirationTimestampValidators(),
+			},
+			"wait": {
+				Description: "Wait till the cluster is ready. Default value is 'true'.",
+				Type:        types.BoolType,
+				Optional:    true,
+			},
+		},
+	}
+	return
+}
+
+func (t *ClusterOsdGcpResourceType) NewResource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.Resource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the resource:
+	result = &ClusterOsdGcpResource{
+		logger:                parent.logger,
+		collection:            parent.connection.ClustersMgmt().V1().Clusters(),
+		machineTypeCollection: parent.connection.ClustersMgmt().V1().MachineTypes(),
+		cache:                 parent.cache,
+		refresh:               parent.refresh,
+		pollInterval:          parent.pollInterval,
+		installLogLines:       parent.installLogLines,
+		defaultProperties:     parent.defaultClusterProperties,
+	}
+	return
+}
+
+// gcpServiceAccountKey contains the fields of the JSON key of a GCP service account that are
+// sent to OCM.
+type gcpServiceAccountKey struct {
+	Type                    string `json:"type"`
+	ProjectID               string `json:"project_id"`
+	PrivateKeyID            string `json:"private_key_id"`
+	PrivateKey              string `json:"private_key"`
+	ClientEmail             string `json:"client_email"`
+	ClientID                string `json:"client_id"`
+	AuthURI                 string `json:"auth_uri"`
+	TokenURI                string `json:"token_uri"`
+	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
+	ClientX509CertURL       string `json:"client_x509_cert_url"`
+}
+
+// parseGCPServiceAccount parses the JSON key of a GCP service account and checks that it
+// contains the fields required to create a cluster.
+func parseGCPServiceAccount(text string) (result *gcpServiceAccountKey, err error) {
+	result = &gcpServiceAccountKey{}
+	err = json.Unmarshal([]byte(text), result)
+	if err != nil {
+		err = fmt.Errorf("the key isn't valid JSON: %v", err)
+		return
+	}
+	if result.Type != "service_account" {
+		err = fmt.Errorf("expected type 'service_account' but got '%s'", result.Type)
+		return
+	}
+	for name, value := range map[string]string{
+		"project_id":     result.ProjectID,
+		"private_key_id": result.PrivateKeyID,
+		"private_key":    result.PrivateKey,
+		"client_email":   result.ClientEmail,
+	} {
+		if value == "" {
+			err = fmt.Errorf("the key doesn't contain the '%s' field", name)
+			return
+		}
+	}
+	return
+}
+
+func gcpServiceAccountValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate GCP service account key",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				key := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, key)
+				if diag.HasError() || common.IsStringAttributeEmpty(*key) {
+					// No attribute to validate
+					return
+				}
+				_, err := parseGCPServiceAccount(key.Value)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid GCP service account",
+						fmt.Sprintf("Expected the JSON key of a GCP service account: %v", err),
+					)
+				}
+			},
+		},
+	}
+}
+
+func createOsdGcpClusterObject(state *ClusterOsdGcpState,
+	defaultProperties map[string]string) (*cmv1.Cluster, error) {
+	key, err := parseGCPServiceAccount(state.GCPServiceAccount.Value)
+	if err != nil {
+		return nil, err
+	}
+	builder := cmv1.NewCluster()
+	builder.Name(state.Name.Value)
+	builder.Product(cmv1.NewProduct().ID(osdProduct))
+	builder.CloudProvider(cmv1.NewCloudProvider().ID(gcpCloudProvider))
+	builder.Region(cmv1.NewCloudRegion().ID(state.CloudRegion.Value))
+	builder.CCS(cmv1.NewCCS().Enabled(true))
+	if !common.IsStringAttributeEmpty(state.BillingModel) {
+		builder.BillingModel(cmv1.BillingModel(state.BillingModel.Value))
+	}
+	if !state.MultiAZ.Unknown && !state.MultiAZ.Null {
+		builder.MultiAZ(state.MultiAZ.Value)
+	}
+	properties := mergeClusterProperties(defaultProperties, state.Properties)
+	if properties != nil {
+		builder.Properties(properties)
+	}
+	builder.GCP(cmv1.NewGCP().
+		Type(key.Type).
+		ProjectID(key.ProjectID).
+		PrivateKeyID(key.PrivateKeyID).
+		PrivateKey(key.PrivateKey).
+		ClientEmail(key.ClientEmail).
+		ClientID(key.ClientID).
+		AuthURI(key.AuthURI).
+		TokenURI(key.TokenURI).
+		AuthProviderX509CertURL(key.AuthProviderX509CertURL).
+		ClientX509CertURL(key.ClientX509CertURL),
+	)
+	if state.GCPNetwork != nil {
+		builder.GCPNetwork(cmv1.NewGCPNetwork().
+			VPCName(state.GCPNetwork.VPCName.Value).
+			ControlPlaneSubnet(state.GCPNetwork.ControlPlaneSubnet.Value).
+			ComputeSubnet(state.GCPNetwork.ComputeSubnet.Value),
+		)
+	}
+	nodes := cmv1.NewClusterNodes()
+	if !state.ComputeNodes.Unknown && !state.ComputeNodes.Null {
+		nodes.Compute(int(state.ComputeNodes.Value))
+	}
+	if !common.IsStringAttributeEmpty(state.ComputeMachineType) {
+		nodes.ComputeMachineType(cmv1.NewMachineType().ID(state.ComputeMachineType.Value))
+	}
+	if !state.AvailabilityZones.Unknown && !state.AvailabilityZones.Null {
+		azs := make([]string, 0)
+		for _, e := range state.AvailabilityZones.Elems {
+			azs = append(azs, e.(types.String).Value)
+		}
+		nodes.AvailabilityZones(azs...)
+	}
+	if !nodes.Empty() {
+		builder.Nodes(nodes)
+	}
+	network := cmv1.NewNetwork()
+	if !common.IsStringAttributeEmpty(state.MachineCIDR) {
+		network.MachineCIDR(state.MachineCIDR.Value)
+	}
+	if !common.IsStringAttributeEmpty(state.ServiceCIDR) {
+		network.ServiceCIDR(state.ServiceCIDR.Value)
+	}
+	if !common.IsStringAttributeEmpty(state.PodCIDR) {
+		network.PodCIDR(state.PodCIDR.Value)
+	}
+	if !state.HostPrefix.Unknown && !state.HostPrefix.Null {
+		network.HostPrefix(int(state.HostPrefix.Value))
+	}
+	if !network.Empty() {
+		builder.Network(network)
+	}
+	if !common.IsStringAttributeEmpty(state.Version) {
+		builder.Version(cmv1.NewVersion().ID(state.Version.Value))
+	}
+	if !common.IsStringAttributeEmpty(state.ExpirationTimestamp) {
+		expiration, err := parseExpirationTimestamp(state.ExpirationTimestamp.Value)
+		if err != nil {
+			return nil, err
+		}
+		builder.ExpirationTimestamp(expiration)
+	}
+	return builder.Build()
+}
+
+func (r *ClusterOsdGcpResource) Create(ctx context.Context,
+	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	// Get the plan:
+	state := &ClusterOsdGcpState{}
+	diags := request.Plan.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	object, err := createOsdGcpClusterObject(state, r.defaultProperties)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't build cluster",
+			fmt.Sprintf(
+				"Can't build cluster with name '%s': %v",
+				state.Name.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	add, err := r.collection.Add().Body(object).SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't create cluster",
+			fmt.Sprintf(
+				"Can't create cluster with name '%s': %v",
+				state.Name.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	object = add.Body()
+
+	// Wait till the cluster is ready, unless explicitly disabled, and save the state:
+	r.waitAndSaveState(ctx, object, state, &response.State, &response.Diagnostics)
+}
+
+// waitAndSaveState waits till the given cluster is ready, unless waiting is disabled, and then
+// saves it to the Terraform state. If the wait is interrupted the state is saved anyhow with a
+// warning, so that the next apply doesn't create a duplicate cluster.
+func (r *ClusterOsdGcpResource) waitAndSaveState(ctx context.Context, object *cmv1.Cluster,
+	state *ClusterOsdGcpState, tfState *tfsdk.State, diags *diag.Diagnostics) {
+	wait := state.Wait.Unknown || state.Wait.Null || state.Wait.Value
+	if wait && object.State() != cmv1.ClusterStateReady {
+		pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+		defer cancel()
+		id := object.ID()
+		last := object
+		var operationID string
+		var err error
+		object, operationID, err = pollCluster(pollCtx, r.collection.Cluster(id), r.pollInterval,
+			func(object *cmv1.Cluster) bool {
+				switch object.State() {
+				case cmv1.ClusterStateReady,
+					cmv1.ClusterStateError:
+					return true
+				}
+				return false
+			},
+		)
+		if err != nil && ctx.Err() != nil {
+			if object == nil {
+				object = last
+			}
+			r.populateState(object, state)
+			diags.Append(tfState.Set(ctx, state)...)
+			diags.AddWarning(
+				"Cluster isn't ready yet",
+				fmt.Sprintf(
+					"Waiting for cluster with identifier '%s' was interrupted while it "+
+						"was in the '%s' state",
+					id, object.State(),
+				),
+			)
+			return
+		}
+		if err != nil {
+			addClassifiedError(
+				diags,
+				"Can't poll cluster state",
+				fmt.Sprintf(
+					"Can't poll state of cluster with identifier '%s': %v",
+					id, err,
+				),
+				err,
+			)
+			return
+		}
+		if object.State() == cmv1.ClusterStateError {
+			// Save the state anyhow, so that the cluster is marked as tainted and replaced
+			// instead of being left behind:
+			r.populateState(object, state)
+			diags.Append(tfState.Set(ctx, state)...)
+			diags.AddError(
+				provisionErrorSummary,
+				provisionErrorDetail(object, operationID)+
+					installLogDetail(ctx, r.collection.Cluster(id), r.installLogLines),
+			)
+			return
+		}
+	}
+
+	// Save the state:
+	r.populateState(object, state)
+	diags.Append(tfState.Set(ctx, state)...)
+}
+
+// ModifyPlan checks that the compute machine type exists for GCP.
+func (r *ClusterOsdGcpResource) ModifyPlan(ctx context.Context,
+	request tfsdk.ModifyResourcePlanRequest, response *tfsdk.ModifyResourcePlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	checkComputeMachineType(ctx, request, response, r.cache, r.machineTypeCollection, gcpCloudProvider)
+}
+
+func (r *ClusterOsdGcpResource) Read(ctx context.Context, request tfsdk.ReadResourceRequest,
+	response *tfsdk.ReadResourceResponse) {
+	// Get the current state:
+	state := &ClusterOsdGcpState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the cluster, the result is shared with the other resources of the cluster that are
+	// read during the same refresh:
+	object, err := r.refresh.getCluster(ctx, r.collection, state.ID.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster",
+			fmt.Sprintf(
+				"Can't find cluster with identifier '%s': %v",
+				state.ID.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Save the state:
+	r.populateState(object, state)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterOsdGcpResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
+	response *tfsdk.UpdateResourceResponse) {
+	// Get the state:
+	state := &ClusterOsdGcpState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	defer r.refresh.invalidate(state.ID.Value)
+
+	// Get the plan:
+	plan := &ClusterOsdGcpState{}
+	diags = request.Plan.Get(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Send request to update the cluster:
+	builder := cmv1.NewCluster()
+	nodes := cmv1.NewClusterNodes()
+	compute, ok := common.ShouldPatchInt(state.ComputeNodes, plan.ComputeNodes)
+	if ok {
+		nodes.Compute(int(compute))
+	}
+	if !nodes.Empty() {
+		builder.Nodes(nodes)
+	}
+	if !plan.Properties.Unknown && !plan.Properties.Null {
+		builder.Properties(mergeClusterProperties(r.defaultProperties, plan.Properties))
+	}
+	_, err := updateExpirationTimestamp(state.ExpirationTimestamp, plan.ExpirationTimestamp, builder)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("expiration_timestamp"),
+			"Can't update cluster",
+			fmt.Sprintf(
+				"Can't update expiration timestamp for cluster with identifier '%s': %v",
+				state.ID.Value, err,
+			),
+		)
+		return
+	}
+	patch, err := builder.Build()
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't build cluster patch",
+			fmt.Sprintf(
+				"Can't build patch for cluster with identifier '%s': %v",
+				state.ID.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	update, err := r.collection.Cluster(state.ID.Value).Update().
+		Body(patch).
+		SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't update cluster",
+			fmt.Sprintf(
+				"Can't update cluster with identifier '%s': %v",
+				state.ID.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Update the state:
+	state.Wait = plan.Wait
+	state.Properties = plan.Properties
+	state.ExpirationTimestamp = plan.ExpirationTimestamp
+	r.populateState(update.Body(), state)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterOsdGcpResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
+	response *tfsdk.DeleteResourceResponse) {
+	// Get the state:
+	state := &ClusterOsdGcpState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	defer r.refresh.invalidate(state.ID.Value)
+
+	// Send the request to delete the cluster:
+	resource := r.collection.Cluster(state.ID.Value)
+	gone, err := deleteCluster(ctx, resource)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't delete cluster",
+			fmt.Sprintf(
+				"Can't delete cluster with identifier '%s': %v",
+				state.ID.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Wait till the cluster has been effectively deleted:
+	if !gone && (state.Wait.Unknown || state.Wait.Null || state.Wait.Value) {
+		pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+		defer cancel()
+		err = pollClusterTillNotFound(pollCtx, resource, r.pollInterval)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't poll cluster deletion",
+				fmt.Sprintf(
+					"Can't poll deletion of cluster with identifier '%s': %v",
+					state.ID.Value, err,
+				),
+				err,
+			)
+			return
+		}
+	}
+
+	// Remove the state:
+	response.State.RemoveResource(ctx)
+}
+
+func (r *ClusterOsdGcpResource) ImportState(ctx context.Context,
+	request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
+	// Try to retrieve the object:
+	get, err := r.collection.Cluster(request.ID).Get().SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster",
+			fmt.Sprintf(
+				"Can't find cluster with identifier '%s': %v",
+				request.ID, err,
+			),
+			err,
+		)
+		return
+	}
+	object := get.Body()
+	if object.CloudProvider().ID() != gcpCloudProvider {
+		response.Diagnostics.AddError(
+			"Can't import cluster",
+			fmt.Sprintf(
+				"Can't import cluster with identifier '%s' because it runs in "+
+					"cloud provider '%s' instead of '%s'",
+				request.ID, object.CloudProvider().ID(), gcpCloudProvider,
+			),
+		)
+		return
+	}
+
+	// Save the state:
+	state := &ClusterOsdGcpState{}
+	r.populateState(object, state)
+	diags := response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// populateState copies the data from the API object to the Terraform state, leaving out of the
+// user defined properties the ones that come from the provider configuration.
+func (r *ClusterOsdGcpResource) populateState(object *cmv1.Cluster, state *ClusterOsdGcpState) {
+	previous := state.Properties
+	populateOsdGcpClusterState(object, state)
+	state.Properties, _ = splitClusterProperties(r.defaultProperties, previous, state.Properties)
+}
+
+// populateOsdGcpClusterState copies the data from the API object to the Terraform state. The
+// service account key isn't returned by the API, so it is preserved.
+func populateOsdGcpClusterState(object *cmv1.Cluster, state *ClusterOsdGcpState) {
+	state.ID = types.String{
+		Value: object.ID(),
+	}
+	state.Name = types.String{
+		Value: object.Name(),
+	}
+	state.BillingModel = types.String{
+		Value: string(object.BillingModel()),
+	}
+	state.CloudRegion = types.String{
+		Value: object.Region().ID(),
+	}
+	state.MultiAZ = types.Bool{
+		Value: object.MultiAZ(),
+	}
+	state.AvailabilityZones = types.List{
+		ElemType: types.StringType,
+		Elems:    []attr.Value{},
+	}
+	for _, az := range object.Nodes().AvailabilityZones() {
+		state.AvailabilityZones.Elems = append(state.AvailabilityZones.Elems, types.String{
+			Value: az,
+		})
+	}
+	state.Properties = types.Map{
+		ElemType: types.StringType,
+		Elems:    map[string]attr.Value{},
+	}
+	for k, v := range object.Properties() {
+		state.Properties.Elems[k] = types.String{
+			Value: v,
+		}
+	}
+	state.GCPProjectID = types.String{
+		Value: object.GCP().ProjectID(),
+	}
+	state.ExpirationTimestamp = expirationTimestampState(state.ExpirationTimestamp, object)
+	if state.GCPServiceAccount.Unknown {
+		state.GCPServiceAccount = types.String{
+			Null: true,
+		}
+	}
+	gcpNetwork, ok := object.GetGCPNetwork()
+	if ok {
+		state.GCPNetwork = &GCPNetwork{
+			VPCName: types.String{
+				Value: gcpNetwork.VPCName(),
+			},
+			ControlPlaneSubnet: types.String{
+				Value: gcpNetwork.ControlPlaneSubnet(),
+			},
+			ComputeSubnet: types.String{
+				Value: gcpNetwork.ComputeSubnet(),
+			},
+		}
+	} else {
+		state.GCPNetwork = nil
+	}
+	state.ComputeNodes = types.Int64{
+		Value: int64(object.Nodes().Compute()),
+	}
+	state.ComputeMachineType = types.String{
+		Value: object.Nodes().ComputeMachineType().ID(),
+	}
+	state.MachineCIDR = types.String{
+		Value: object.Network().MachineCIDR(),
+	}
+	state.ServiceCIDR = types.String{
+		Value: object.Network().ServiceCIDR(),
+	}
+	state.PodCIDR = types.String{
+		Value: object.Network().PodCIDR(),
+	}
+	state.HostPrefix = types.Int64{
+		Value: int64(object.Network().HostPrefix()),
+	}
+	state.Version = types.String{
+		Value: object.Version().ID(),
+	}
+	state.APIURL = types.String{
+		Value: object.API().URL(),
+	}
+	state.ConsoleURL = types.String{
+		Value: object.Console().URL(),
+	}
+	state.Domain = types.String{
+		Value: fmt.Sprintf("%s.%s", object.Name(), object.DNS().BaseDomain()),
+	}
+	state.InfraID = types.String{
+		Value: object.InfraID(),
+	}
+	state.State = types.String{
+		Value: string(object.State()),
+	}
+	state.CurrentVersion = types.String{
+		Value: clusterCurrentVersion(object),
+	}
+}
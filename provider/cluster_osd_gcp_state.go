@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ClusterOsdGcpState struct {
+	APIURL              types.String `tfsdk:"api_url"`
+	AvailabilityZones   types.List   `tfsdk:"availability_zones"`
+	BillingModel        types.String `tfsdk:"billing_model"`
+	CloudRegion         types.String `tfsdk:"cloud_region"`
+	ComputeMachineType  types.String `tfsdk:"compute_machine_type"`
+	ComputeNodes        types.Int64  `tfsdk:"compute_nodes"`
+	ConsoleURL          types.String `tfsdk:"console_url"`
+	CurrentVersion      types.String `tfsdk:"current_version"`
+	Domain              types.String `tfsdk:"domain"`
+	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	GCPNetwork          *GCPNetwork  `tfsdk:"gcp_network"`
+	GCPProjectID        types.String `tfsdk:"gcp_project_id"`
+	GCPServiceAccount   types.String `tfsdk:"gcp_service_account"`
+	HostPrefix          types.Int64  `tfsdk:"host_prefix"`
+	ID                  types.String `tfsdk:"id"`
+	InfraID             types.String `tfsdk:"infra_id"`
+	MachineCIDR         types.String `tfsdk:"machine_cidr"`
+	MultiAZ             types.Bool   `tfsdk:"multi_az"`
+	Name                types.String `tfsdk:"name"`
+	PodCIDR             types.String `tfsdk:"pod_cidr"`
+	Properties          types.Map    `tfsdk:"properties"`
+	ServiceCIDR         types.String `tfsdk:"service_cidr"`
+	State               types.String `tfsdk:"state"`
+	Version             types.String `tfsdk:"version"`
+	Wait                types.Bool   `tfsdk:"wait"`
+}
+
+type GCPNetwork struct {
+	VPCName            types.String `tfsdk:"vpc_name"`
+	ControlPlaneSubnet types.String `tfsdk:"control_plane_subnet"`
+	ComputeSubnet      types.String `tfsdk:"compute_subnet"`
+}
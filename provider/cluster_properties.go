@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mergeClusterProperties returns the default cluster properties from the provider configuration
+// overridden by the properties given in the resource. The result is nil when there are no
+// properties at all.
+func mergeClusterProperties(defaults map[string]string, properties types.Map) map[string]string {
+	var result map[string]string
+	if len(defaults) > 0 {
+		result = map[string]string{}
+		for k, v := range defaults {
+			result[k] = v
+		}
+	}
+	if !properties.Unknown && !properties.Null {
+		if result == nil {
+			result = map[string]string{}
+		}
+		for k, v := range properties.Elems {
+			result[k] = v.(types.String).Value
+		}
+	}
+	return result
+}
+
+// splitClusterProperties separates the properties of a cluster that come from the defaults of the
+// provider configuration from the ones defined in the resource, so that the defaults don't show as
+// differences with the configuration. A property that was already in the previous value is kept
+// as user defined, as that means that it is overridden by the resource.
+func splitClusterProperties(defaults map[string]string, previous types.Map,
+	properties types.Map) (user types.Map, defaulted types.Map) {
+	user = types.Map{
+		ElemType: types.StringType,
+		Elems:    map[string]attr.Value{},
+	}
+	defaulted = types.Map{
+		ElemType: types.StringType,
+		Elems:    map[string]attr.Value{},
+	}
+	for k, v := range properties.Elems {
+		_, configured := previous.Elems[k]
+		value, isDefault := defaults[k]
+		if isDefault && !configured && v.(types.String).Value == value {
+			defaulted.Elems[k] = v
+		} else {
+			user.Elems[k] = v
+		}
+	}
+	return
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Default cluster properties", func() {
+	buildProperties := func(values map[string]string) types.Map {
+		result := types.Map{
+			ElemType: types.StringType,
+			Elems:    map[string]attr.Value{},
+		}
+		for k, v := range values {
+			result.Elems[k] = types.String{
+				Value: v,
+			}
+		}
+		return result
+	}
+
+	defaults := map[string]string{
+		"team":        "payments",
+		"environment": "staging",
+	}
+
+	It("Is nil without properties", func() {
+		Expect(mergeClusterProperties(nil, types.Map{Null: true})).To(BeNil())
+	})
+
+	It("Lets the resource override the defaults", func() {
+		properties := buildProperties(map[string]string{
+			"environment": "production",
+			"owner":       "jdoe",
+		})
+		Expect(mergeClusterProperties(defaults, properties)).To(Equal(map[string]string{
+			"team":        "payments",
+			"environment": "production",
+			"owner":       "jdoe",
+		}))
+	})
+
+	It("Uses the defaults when the resource has no properties", func() {
+		Expect(mergeClusterProperties(defaults, types.Map{Unknown: true})).To(Equal(defaults))
+	})
+
+	It("Separates the defaults from the user defined properties", func() {
+		previous := buildProperties(map[string]string{
+			"environment": "production",
+		})
+		current := buildProperties(map[string]string{
+			"team":        "payments",
+			"environment": "production",
+			"owner":       "jdoe",
+		})
+		user, defaulted := splitClusterProperties(defaults, previous, current)
+		Expect(user).To(Equal(buildProperties(map[string]string{
+			"environment": "production",
+			"owner":       "jdoe",
+		})))
+		Expect(defaulted).To(Equal(buildProperties(map[string]string{
+			"team": "payments",
+		})))
+	})
+
+	It("Keeps a default that was explicitly configured", func() {
+		previous := buildProperties(map[string]string{
+			"team": "payments",
+		})
+		current := buildProperties(map[string]string{
+			"team": "payments",
+		})
+		user, defaulted := splitClusterProperties(defaults, previous, current)
+		Expect(user.Elems).To(HaveKey("team"))
+		Expect(defaulted.Elems).To(BeEmpty())
+	})
+})
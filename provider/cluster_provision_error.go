@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// operationIDHeader is the response header that contains the identifier that the OCM API
+	// assigns to each request. Support needs it to find the logs of a failed provisioning.
+	operationIDHeader = "X-Operation-ID"
+
+	provisionErrorSummary = "Cluster provisioning failed"
+)
+
+// provisionErrorDetail returns a description of the reason why the given cluster entered the
+// error state, including the provision error code and message reported by OCM and the
+// identifier of the operation that returned the cluster.
+func provisionErrorDetail(object *cmv1.Cluster, operationID string) string {
+	message := object.Status().ProvisionErrorMessage()
+	if message == "" {
+		message = object.Status().Description()
+	}
+	if message == "" {
+		message = "no error message was reported"
+	}
+	var extra []string
+	if code := object.Status().ProvisionErrorCode(); code != "" {
+		extra = append(extra, fmt.Sprintf("provision error code '%s'", code))
+	}
+	if operationID != "" {
+		extra = append(extra, fmt.Sprintf("operation identifier '%s'", operationID))
+	}
+	detail := fmt.Sprintf(
+		"Cluster '%s' with identifier '%s' entered the '%s' state: %s",
+		object.Name(), object.ID(), object.State(), message,
+	)
+	if len(extra) > 0 {
+		detail += fmt.Sprintf(" (%s)", strings.Join(extra, ", "))
+	}
+	return detail
+}
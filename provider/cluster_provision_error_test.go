@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Provision error details", func() {
+	It("Includes the code, message and operation identifier", func() {
+		object, err := cmv1.NewCluster().
+			ID("123").
+			Name("my-cluster").
+			State(cmv1.ClusterStateError).
+			Status(cmv1.NewClusterStatus().
+				ProvisionErrorCode("OCM3055").
+				ProvisionErrorMessage("Insufficient AWS quota"),
+			).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		detail := provisionErrorDetail(object, "456")
+		Expect(detail).To(Equal(
+			"Cluster 'my-cluster' with identifier '123' entered the 'error' state: " +
+				"Insufficient AWS quota (provision error code 'OCM3055', operation identifier '456')",
+		))
+	})
+
+	It("Falls back to the status description", func() {
+		object, err := cmv1.NewCluster().
+			ID("123").
+			Name("my-cluster").
+			State(cmv1.ClusterStateError).
+			Status(cmv1.NewClusterStatus().
+				Description("Install failed"),
+			).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		detail := provisionErrorDetail(object, "")
+		Expect(detail).To(HaveSuffix("entered the 'error' state: Install failed"))
+	})
+})
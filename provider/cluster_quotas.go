@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// gibibyte is the number of bytes in a GiB. The storage quota is configured in GiB, but OCM
+// expects it in bytes.
+const gibibyte = 1 << 30
+
+// storageQuotaGiB returns the storage quota of the cluster in GiB.
+func storageQuotaGiB(object *cmv1.Cluster) (result int64, ok bool) {
+	quota, ok := object.GetStorageQuota()
+	if !ok {
+		return
+	}
+	switch quota.Unit() {
+	case "GiB":
+		result = int64(quota.Value())
+	default:
+		result = int64(quota.Value() / gibibyte)
+	}
+	return
+}
+
+// storageQuotaValue converts the given storage quota in GiB to the value expected by OCM.
+func storageQuotaValue(gib int64) *cmv1.ValueBuilder {
+	return cmv1.NewValue().Unit("B").Value(float64(gib * gibibyte))
+}
+
+// buildQuotas adds to the given builder the load balancer and storage quotas, if they are set.
+func buildQuotas(builder *cmv1.ClusterBuilder, loadBalancers, storage types.Int64) {
+	if !loadBalancers.Unknown && !loadBalancers.Null {
+		builder.LoadBalancerQuota(int(loadBalancers.Value))
+	}
+	if !storage.Unknown && !storage.Null {
+		builder.StorageQuota(storageQuotaValue(storage.Value))
+	}
+}
+
+func quotaValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate quota",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				quota := &types.Int64{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, quota)
+				if diag.HasError() || quota.Unknown || quota.Null {
+					// No attribute to validate
+					return
+				}
+				if quota.Value < 0 {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid quota",
+						fmt.Sprintf("Expected a non negative value, but got %d", quota.Value),
+					)
+				}
+			},
+		},
+	}
+}
+
+// checkQuotasNotCCS checks that the load balancer and storage quotas aren't configured for
+// clusters that use a customer cloud subscription, as those clusters use the load balancers and
+// the storage of the customer account.
+func checkQuotasNotCCS(ctx context.Context, request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse) {
+	ccsEnabled := types.Bool{}
+	diags := request.Plan.GetAttribute(
+		ctx,
+		tftypes.NewAttributePath().WithAttributeName("ccs_enabled"),
+		&ccsEnabled,
+	)
+	if diags.HasError() || ccsEnabled.Unknown || ccsEnabled.Null || !ccsEnabled.Value {
+		return
+	}
+	for _, name := range []string{"load_balancer_quota", "storage_quota"} {
+		path := tftypes.NewAttributePath().WithAttributeName(name)
+		quota := types.Int64{}
+		diags = request.Config.GetAttribute(ctx, path, &quota)
+		if diags.HasError() || quota.Null {
+			continue
+		}
+		response.Diagnostics.AddAttributeError(
+			path,
+			"Quota not supported",
+			fmt.Sprintf(
+				"Attribute '%s' can only be used with clusters that don't use a "+
+					"customer cloud subscription, as those use the quotas of the "+
+					"cloud account",
+				name,
+			),
+		)
+	}
+}
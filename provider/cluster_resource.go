@@ -19,25 +19,44 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
-	"github.com/openshift-online/ocm-sdk-go/errors"
 	"github.com/openshift-online/ocm-sdk-go/logging"
 	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
 )
 
 type ClusterResourceType struct {
+	logger logging.Logger
 }
 
+// billingModels are the billing models that can be requested when creating clusters.
+var billingModels = []string{
+	string(cmv1.BillingModelStandard),
+	string(cmv1.BillingModelMarketplace),
+	string(cmv1.BillingModelMarketplaceAWS),
+}
+
+var _ tfsdk.ResourceWithModifyPlan = &ClusterResource{}
+
 type ClusterResource struct {
-	logger     logging.Logger
-	collection *cmv1.ClustersClient
+	logger                logging.Logger
+	collection            *cmv1.ClustersClient
+	machineTypeCollection *cmv1.MachineTypesClient
+	cache                 *lookupCache
+	refresh               *refreshCache
+	pollInterval          time.Duration
+	installLogLines       int
+
+	// defaultProperties are the properties from the provider configuration that are added to
+	// the cluster.
+	defaultProperties map[string]string
 }
 
 func (t *ClusterResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
@@ -55,20 +74,41 @@ func (t *ClusterResourceType) GetSchema(ctx context.Context) (result tfsdk.Schem
 				Type:        types.StringType,
 				Required:    true,
 			},
+			"billing_model": {
+				Description: "Billing model of the cluster, one of 'standard', 'marketplace' " +
+					"or 'marketplace-aws'. Clusters billed through the AWS Marketplace, " +
+					"including private offers, use 'marketplace-aws'. Default value is " +
+					"'standard'.",
+				Type:       types.StringType,
+				Optional:   true,
+				Computed:   true,
+				Validators: EnumValueValidator(billingModels),
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
 			"name": {
-				Description: "Name of the cluster.",
-				Type:        types.StringType,
-				Required:    true,
+				Description: "Name of the cluster. Must be a maximum of 15 lowercase alphanumeric characters or '-', " +
+					"start with a letter, and end with an alphanumeric character.",
+				Type:       types.StringType,
+				Required:   true,
+				Validators: clusterNameValidators(),
 			},
 			"cloud_provider": {
 				Description: "Cloud provider identifier, for example 'aws'.",
 				Type:        types.StringType,
 				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
 			},
 			"cloud_region": {
 				Description: "Cloud region identifier, for example 'us-east-1'.",
 				Type:        types.StringType,
 				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
 			},
 			"multi_az": {
 				Description: "Indicates if the cluster should be deployed to " +
@@ -98,6 +138,17 @@ func (t *ClusterResourceType) GetSchema(ctx context.Context) (result tfsdk.Schem
 				Type:        types.StringType,
 				Computed:    true,
 			},
+			"domain": {
+				Description: "DNS Domain of Cluster",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"infra_id": {
+				Description: "Infrastructure identifier of the cluster, used as prefix " +
+					"of the names and in the tags of the cloud resources of the cluster.",
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"compute_nodes": {
 				Description: "Number of compute nodes of the cluster.",
 				Type:        types.Int64Type,
@@ -107,7 +158,8 @@ func (t *ClusterResourceType) GetSchema(ctx context.Context) (result tfsdk.Schem
 			"compute_machine_type": {
 				Description: "Identifier of the machine type used by the compute nodes, " +
 					"for example `r5.xlarge`. Use the `ocm_machine_types` data " +
-					"source to find the possible values.",
+					"source to find the possible values. The machine type is " +
+					"checked when planning.",
 				Type:     types.StringType,
 				Optional: true,
 				Computed: true,
@@ -217,11 +269,48 @@ func (t *ClusterResourceType) GetSchema(ctx context.Context) (result tfsdk.Schem
 				Type:        types.StringType,
 				Computed:    true,
 			},
+			"current_version": {
+				Description: "OpenShift version that the cluster runs, for example " +
+					"'4.12.3'. It changes when the cluster is upgraded.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"load_balancer_quota": {
+				Description: "Number of load balancers of the cluster, in addition to the " +
+					"default ones. Only for clusters that don't use a customer cloud " +
+					"subscription. Can be changed after the cluster is created.",
+				Type:       types.Int64Type,
+				Optional:   true,
+				Computed:   true,
+				Validators: quotaValidators(),
+			},
+			"storage_quota": {
+				Description: "Persistent storage quota of the cluster, in GiB. Only for " +
+					"clusters that don't use a customer cloud subscription. Can be changed " +
+					"after the cluster is created.",
+				Type:       types.Int64Type,
+				Optional:   true,
+				Computed:   true,
+				Validators: quotaValidators(),
+			},
+			"expiration_timestamp": {
+				Description: expirationTimestampDescription,
+				Type:        types.StringType,
+				Optional:    true,
+				Validators:  expirationTimestampValidators(),
+			},
 			"wait": {
 				Description: "Wait till the cluster is ready.",
 				Type:        types.BoolType,
 				Optional:    true,
 			},
+			"cleanup_on_failure": {
+				Description: "Delete the cluster automatically if it enters the 'error' " +
+					"state while waiting for it to be ready, so that the next apply can " +
+					"create it again. Only used when 'wait' is enabled. Default value is 'false'.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
 		},
 	}
 	return
@@ -237,29 +326,35 @@ func (t *ClusterResourceType) NewResource(ctx context.Context,
 
 	// Create the resource:
 	result = &ClusterResource{
-		logger:     parent.logger,
-		collection: collection,
+		logger:                parent.logger,
+		collection:            collection,
+		machineTypeCollection: parent.connection.ClustersMgmt().V1().MachineTypes(),
+		cache:                 parent.cache,
+		refresh:               parent.refresh,
+		pollInterval:          parent.pollInterval,
+		installLogLines:       parent.installLogLines,
+		defaultProperties:     parent.defaultClusterProperties,
 	}
 
 	return
 }
 
-func createClusterObject(ctx context.Context,
-	state *ClusterState, diags diag.Diagnostics) (*cmv1.Cluster, error) {
+func createClusterObject(ctx context.Context, state *ClusterState,
+	defaultProperties map[string]string, diags diag.Diagnostics) (*cmv1.Cluster, error) {
 	// Create the cluster:
 	builder := cmv1.NewCluster()
 	builder.Name(state.Name.Value)
 	builder.CloudProvider(cmv1.NewCloudProvider().ID(state.CloudProvider.Value))
 	builder.Product(cmv1.NewProduct().ID(state.Product.Value))
+	if !common.IsStringAttributeEmpty(state.BillingModel) {
+		builder.BillingModel(cmv1.BillingModel(state.BillingModel.Value))
+	}
 	builder.Region(cmv1.NewCloudRegion().ID(state.CloudRegion.Value))
 	if !state.MultiAZ.Unknown && !state.MultiAZ.Null {
 		builder.MultiAZ(state.MultiAZ.Value)
 	}
-	if !state.Properties.Unknown && !state.Properties.Null {
-		properties := map[string]string{}
-		for k, v := range state.Properties.Elems {
-			properties[k] = v.(types.String).Value
-		}
+	properties := mergeClusterProperties(defaultProperties, state.Properties)
+	if properties != nil {
 		builder.Properties(properties)
 	}
 	nodes := cmv1.NewClusterNodes()
@@ -339,6 +434,14 @@ func createClusterObject(ctx context.Context,
 	if !state.Version.Unknown && !state.Version.Null {
 		builder.Version(cmv1.NewVersion().ID(state.Version.Value))
 	}
+	buildQuotas(builder, state.LoadBalancerQuota, state.StorageQuota)
+	if !common.IsStringAttributeEmpty(state.ExpirationTimestamp) {
+		expiration, err := parseExpirationTimestamp(state.ExpirationTimestamp.Value)
+		if err != nil {
+			return nil, err
+		}
+		builder.ExpirationTimestamp(expiration)
+	}
 
 	proxy := cmv1.NewProxy()
 	if state.Proxy != nil {
@@ -362,60 +465,180 @@ func (r *ClusterResource) Create(ctx context.Context,
 		return
 	}
 
-	object, err := createClusterObject(ctx, state, diags)
+	object, err := createClusterObject(ctx, state, r.defaultProperties, diags)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't build cluster",
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
 		return
 	}
 
 	add, err := r.collection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't create cluster",
 			fmt.Sprintf(
 				"Can't create cluster with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
 		return
 	}
 	object = add.Body()
 
-	// Wait till the cluster is ready unless explicitly disabled:
-	wait := state.Wait.Unknown || state.Wait.Null || state.Wait.Value
-	ready := object.State() == cmv1.ClusterStateReady
-	if wait && !ready {
+	// Wait till the cluster is ready, unless explicitly disabled, and save the state:
+	r.waitAndSaveState(ctx, object, state, &response.State, &response.Diagnostics)
+}
+
+// waitAndSaveState waits till the given cluster is ready, unless waiting is disabled, and then
+// saves it to the Terraform state. If the wait is interrupted the state is saved anyhow with a
+// warning, so that the next apply resumes waiting instead of creating a duplicate cluster.
+func (r *ClusterResource) waitAndSaveState(ctx context.Context, object *cmv1.Cluster,
+	state *ClusterState, tfState *tfsdk.State, diags *diag.Diagnostics) {
+	if r.shouldWait(state) && object.State() != cmv1.ClusterStateReady {
 		pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
 		defer cancel()
-		_, err := r.collection.Cluster(object.ID()).Poll().
-			Interval(30 * time.Second).
-			Predicate(func(get *cmv1.ClusterGetResponse) bool {
-				object = get.Body()
-				return object.State() == cmv1.ClusterStateReady
-			}).
-			StartContext(pollCtx)
+		id := object.ID()
+		last := object
+		var operationID string
+		var err error
+		object, operationID, err = pollCluster(pollCtx, r.collection.Cluster(id), r.pollInterval,
+			func(object *cmv1.Cluster) bool {
+				switch object.State() {
+				case cmv1.ClusterStateReady,
+					cmv1.ClusterStateError:
+					return true
+				}
+				return false
+			},
+		)
+		if err != nil && ctx.Err() != nil {
+			if object == nil {
+				object = last
+			}
+			r.populateState(object, state)
+			diags.Append(tfState.Set(ctx, state)...)
+			diags.AddWarning(
+				"Cluster isn't ready yet",
+				fmt.Sprintf(
+					"Waiting for cluster with identifier '%s' was interrupted while it "+
+						"was in the '%s' state, the next apply will resume waiting",
+					id, object.State(),
+				),
+			)
+			return
+		}
 		if err != nil {
-			response.Diagnostics.AddError(
+			addClassifiedError(
+				diags,
 				"Can't poll cluster state",
 				fmt.Sprintf(
 					"Can't poll state of cluster with identifier '%s': %v",
-					object.ID(), err,
+					id, err,
 				),
+				err,
 			)
 			return
 		}
+		if object.State() == cmv1.ClusterStateError {
+			detail := provisionErrorDetail(object, operationID) +
+				installLogDetail(ctx, r.collection.Cluster(id), r.installLogLines)
+			cleanup := !state.CleanupOnFailure.Unknown && !state.CleanupOnFailure.Null &&
+				state.CleanupOnFailure.Value
+			if cleanup {
+				err = r.cleanupFailedCluster(ctx, object.ID())
+				if err == nil {
+					// Remove the state, the next apply will create the cluster again:
+					tfState.RemoveResource(ctx)
+					diags.AddError(
+						provisionErrorSummary,
+						detail+". The cluster has been deleted, it will be created again "+
+							"by the next apply",
+					)
+					return
+				}
+				detail = fmt.Sprintf(
+					"%s. The cluster couldn't be deleted automatically: %v",
+					detail, err,
+				)
+			}
+
+			// Save the state anyhow, so that the cluster is marked as tainted and replaced
+			// instead of being left behind:
+			r.populateState(object, state)
+			diags.Append(tfState.Set(ctx, state)...)
+			diags.AddError(provisionErrorSummary, detail)
+			return
+		}
 	}
 
 	// Save the state:
-	populateClusterState(object, state)
-	diags = response.State.Set(ctx, state)
-	response.Diagnostics.Append(diags...)
+	r.populateState(object, state)
+	diags.Append(tfState.Set(ctx, state)...)
+}
+
+// shouldWait checks if the resource should wait for the cluster to be ready, which is the
+// default.
+func (r *ClusterResource) shouldWait(state *ClusterState) bool {
+	return state.Wait.Unknown || state.Wait.Null || state.Wait.Value
+}
+
+// ModifyPlan checks the compute machine type, and detects clusters that aren't ready yet because
+// a previous apply was interrupted while waiting for them, and plans an update so that the apply
+// resumes waiting.
+func (r *ClusterResource) ModifyPlan(ctx context.Context, request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	cloudProvider := types.String{}
+	request.Plan.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("cloud_provider"), &cloudProvider)
+	checkComputeMachineType(ctx, request, response, r.cache, r.machineTypeCollection, cloudProvider.Value)
+	checkQuotasNotCCS(ctx, request, response)
+	if request.State.Raw.IsNull() {
+		return
+	}
+	state := &ClusterState{}
+	diags := request.State.Get(ctx, state)
+	if diags.HasError() {
+		return
+	}
+	plan := &ClusterState{}
+	diags = request.Plan.Get(ctx, plan)
+	if diags.HasError() {
+		return
+	}
+	if !r.shouldWait(plan) || !isClusterInstalling(state.State.Value) {
+		return
+	}
+	for _, name := range []string{"state", "api_url", "console_url"} {
+		response.Diagnostics.Append(response.Plan.SetAttribute(
+			ctx,
+			tftypes.NewAttributePath().WithAttributeName(name),
+			types.String{Unknown: true},
+		)...)
+	}
+}
+
+// isClusterInstalling checks if the given cluster state is one of the states that a cluster goes
+// through before it is ready.
+func isClusterInstalling(state string) bool {
+	switch cmv1.ClusterState(state) {
+	case cmv1.ClusterStatePending,
+		cmv1.ClusterStateValidating,
+		cmv1.ClusterStateWaiting,
+		cmv1.ClusterStateInstalling:
+		return true
+	}
+	return false
 }
 
 func (r *ClusterResource) Read(ctx context.Context, request tfsdk.ReadResourceRequest,
@@ -428,22 +651,24 @@ func (r *ClusterResource) Read(ctx context.Context, request tfsdk.ReadResourceRe
 		return
 	}
 
-	// Find the cluster:
-	get, err := r.collection.Cluster(state.ID.Value).Get().SendContext(ctx)
+	// Find the cluster, the result is shared with the other resources of the cluster that are
+	// read during the same refresh:
+	object, err := r.refresh.getCluster(ctx, r.collection, state.ID.Value)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
-	object := get.Body()
 
 	// Save the state:
-	populateClusterState(object, state)
+	r.populateState(object, state)
 	diags = response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
@@ -459,6 +684,7 @@ func (r *ClusterResource) Update(ctx context.Context, request tfsdk.UpdateResour
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.ID.Value)
 
 	// Get the plan:
 	plan := &ClusterState{}
@@ -478,14 +704,36 @@ func (r *ClusterResource) Update(ctx context.Context, request tfsdk.UpdateResour
 	if !nodes.Empty() {
 		builder.Nodes(nodes)
 	}
+	loadBalancers, ok := common.ShouldPatchInt(state.LoadBalancerQuota, plan.LoadBalancerQuota)
+	if ok {
+		builder.LoadBalancerQuota(int(loadBalancers))
+	}
+	storage, ok := common.ShouldPatchInt(state.StorageQuota, plan.StorageQuota)
+	if ok {
+		builder.StorageQuota(storageQuotaValue(storage))
+	}
+	_, err := updateExpirationTimestamp(state.ExpirationTimestamp, plan.ExpirationTimestamp, builder)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("expiration_timestamp"),
+			"Can't update cluster",
+			fmt.Sprintf(
+				"Can't update expiration timestamp for cluster with identifier '%s': %v",
+				state.ID.Value, err,
+			),
+		)
+		return
+	}
 	patch, err := builder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't build cluster patch",
 			fmt.Sprintf(
 				"Can't build patch for cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -493,21 +741,25 @@ func (r *ClusterResource) Update(ctx context.Context, request tfsdk.UpdateResour
 		Body(patch).
 		SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
 	object := update.Body()
 
-	// Update the state:
-	populateClusterState(object, state)
-	diags = response.State.Set(ctx, state)
-	response.Diagnostics.Append(diags...)
+	// Update the state, resuming the wait if a previous apply was interrupted before the
+	// cluster was ready:
+	state.Wait = plan.Wait
+	state.CleanupOnFailure = plan.CleanupOnFailure
+	state.ExpirationTimestamp = plan.ExpirationTimestamp
+	r.waitAndSaveState(ctx, object, state, &response.State, &response.Diagnostics)
 }
 
 func (r *ClusterResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
@@ -519,40 +771,36 @@ func (r *ClusterResource) Delete(ctx context.Context, request tfsdk.DeleteResour
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.ID.Value)
 
 	// Send the request to delete the cluster:
 	resource := r.collection.Cluster(state.ID.Value)
-	_, err := resource.Delete().SendContext(ctx)
+	gone, err := deleteCluster(ctx, resource)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't delete cluster",
 			fmt.Sprintf(
 				"Can't delete cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
 
 	// Wait till the cluster has been effectively deleted:
-	if state.Wait.Unknown || state.Wait.Null || state.Wait.Value {
-		pollCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-		defer cancel()
-		_, err := resource.Poll().
-			Interval(30 * time.Second).
-			Status(http.StatusNotFound).
-			StartContext(pollCtx)
-		sdkErr, ok := err.(*errors.Error)
-		if ok && sdkErr.Status() == http.StatusNotFound {
-			err = nil
-		}
+	if !gone && (state.Wait.Unknown || state.Wait.Null || state.Wait.Value) {
+		err := r.waitTillClusterIsDeleted(ctx, resource)
 		if err != nil {
-			response.Diagnostics.AddError(
+			addClassifiedError(
+				&response.Diagnostics,
 				"Can't poll cluster deletion",
 				fmt.Sprintf(
 					"Can't poll deletion of cluster with identifier '%s': %v",
 					state.ID.Value, err,
 				),
+				err,
 			)
 			return
 		}
@@ -562,17 +810,38 @@ func (r *ClusterResource) Delete(ctx context.Context, request tfsdk.DeleteResour
 	response.State.RemoveResource(ctx)
 }
 
+// cleanupFailedCluster deletes a cluster that failed to install and waits till it has been
+// effectively deleted.
+func (r *ClusterResource) cleanupFailedCluster(ctx context.Context, id string) error {
+	resource := r.collection.Cluster(id)
+	gone, err := deleteCluster(ctx, resource)
+	if err != nil || gone {
+		return err
+	}
+	return r.waitTillClusterIsDeleted(ctx, resource)
+}
+
+// waitTillClusterIsDeleted polls the given cluster till the server reports that it doesn't
+// exist.
+func (r *ClusterResource) waitTillClusterIsDeleted(ctx context.Context, resource *cmv1.ClusterClient) error {
+	pollCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	return pollClusterTillNotFound(pollCtx, resource, r.pollInterval)
+}
+
 func (r *ClusterResource) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest,
 	response *tfsdk.ImportResourceStateResponse) {
 	// Try to retrieve the object:
 	get, err := r.collection.Cluster(request.ID).Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				request.ID, err,
 			),
+			err,
 		)
 		return
 	}
@@ -580,11 +849,19 @@ func (r *ClusterResource) ImportState(ctx context.Context, request tfsdk.ImportR
 
 	// Save the state:
 	state := &ClusterState{}
-	populateClusterState(object, state)
+	r.populateState(object, state)
 	diags := response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
 
+// populateState copies the data from the API object to the Terraform state, leaving out of the
+// user defined properties the ones that come from the provider configuration.
+func (r *ClusterResource) populateState(object *cmv1.Cluster, state *ClusterState) {
+	previous := state.Properties
+	populateClusterState(object, state)
+	state.Properties, _ = splitClusterProperties(r.defaultProperties, previous, state.Properties)
+}
+
 // populateClusterState copies the data from the API object to the Terraform state.
 func populateClusterState(object *cmv1.Cluster, state *ClusterState) {
 	state.ID = types.String{
@@ -595,6 +872,9 @@ func populateClusterState(object *cmv1.Cluster, state *ClusterState) {
 	state.Product = types.String{
 		Value: object.Product().ID(),
 	}
+	state.BillingModel = types.String{
+		Value: string(object.BillingModel()),
+	}
 	state.Name = types.String{
 		Value: object.Name(),
 	}
@@ -622,12 +902,39 @@ func populateClusterState(object *cmv1.Cluster, state *ClusterState) {
 	state.ConsoleURL = types.String{
 		Value: object.Console().URL(),
 	}
+	state.Domain = types.String{
+		Value: fmt.Sprintf("%s.%s", object.Name(), object.DNS().BaseDomain()),
+	}
+	state.InfraID = types.String{
+		Value: object.InfraID(),
+	}
 	state.ComputeNodes = types.Int64{
 		Value: int64(object.Nodes().Compute()),
 	}
 	state.ComputeMachineType = types.String{
 		Value: object.Nodes().ComputeMachineType().ID(),
 	}
+	state.ExpirationTimestamp = expirationTimestampState(state.ExpirationTimestamp, object)
+	loadBalancers, ok := object.GetLoadBalancerQuota()
+	if ok {
+		state.LoadBalancerQuota = types.Int64{
+			Value: int64(loadBalancers),
+		}
+	} else {
+		state.LoadBalancerQuota = types.Int64{
+			Null: true,
+		}
+	}
+	storage, ok := storageQuotaGiB(object)
+	if ok {
+		state.StorageQuota = types.Int64{
+			Value: storage,
+		}
+	} else {
+		state.StorageQuota = types.Int64{
+			Null: true,
+		}
+	}
 
 	azs, ok := object.Nodes().GetAvailabilityZones()
 	if ok {
@@ -649,12 +956,13 @@ func populateClusterState(object *cmv1.Cluster, state *ClusterState) {
 			Value: awsAccountID,
 		}
 	}
+	// The API doesn't usually return the access key either, so keep the one in the state:
 	awsAccessKeyID, ok := object.AWS().GetAccessKeyID()
 	if ok {
 		state.AWSAccessKeyID = types.String{
 			Value: awsAccessKeyID,
 		}
-	} else {
+	} else if state.AWSAccessKeyID.Unknown {
 		state.AWSAccessKeyID = types.String{
 			Null: true,
 		}
@@ -664,7 +972,7 @@ func populateClusterState(object *cmv1.Cluster, state *ClusterState) {
 		state.AWSSecretAccessKey = types.String{
 			Value: awsSecretAccessKey,
 		}
-	} else {
+	} else if state.AWSSecretAccessKey.Unknown {
 		state.AWSSecretAccessKey = types.String{
 			Null: true,
 		}
@@ -752,5 +1060,30 @@ func populateClusterState(object *cmv1.Cluster, state *ClusterState) {
 	state.State = types.String{
 		Value: string(object.State()),
 	}
+	state.CurrentVersion = types.String{
+		Value: clusterCurrentVersion(object),
+	}
+
+}
 
+// clusterCurrentVersion returns the OpenShift version that the cluster runs, for example
+// '4.12.3'. The raw identifier of the version isn't always returned, so it is also calculated
+// removing the prefix and the channel group from the identifier.
+func clusterCurrentVersion(object *cmv1.Cluster) string {
+	version, ok := object.Version().GetRawID()
+	if ok && version != "" {
+		return version
+	}
+	version = object.Version().ID()
+	for _, prefix := range []string{"openshift-v", "openshift-"} {
+		if strings.HasPrefix(version, prefix) {
+			version = strings.TrimPrefix(version, prefix)
+			break
+		}
+	}
+	channelGroup := object.Version().ChannelGroup()
+	if channelGroup != "" {
+		version = strings.TrimSuffix(version, "-"+channelGroup)
+	}
+	return version
 }
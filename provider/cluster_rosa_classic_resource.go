@@ -29,10 +29,12 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/terraform-redhat/terraform-provider-ocm/build"
@@ -47,8 +49,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
-	ocm_errors "github.com/openshift-online/ocm-sdk-go/errors"
 	"github.com/openshift-online/ocm-sdk-go/logging"
 )
 
@@ -59,16 +61,46 @@ const (
 	maxClusterNameLength  = 15
 	tagsPrefix            = "rosa_"
 	tagsOpenShiftVersion  = tagsPrefix + "openshift_version"
+	tagsManagedPolicies   = tagsPrefix + "managed_policies"
+	validationOnlySummary = "Validation only"
 	lowestHttpTokensVer   = "4.11.0"
 	propertyRosaTfVersion = tagsPrefix + "tf_version"
 	propertyRosaTfCommit  = tagsPrefix + "tf_commit"
 )
 
+const (
+	// networkTypeOVN and networkTypeSDN are the network plugins that can be selected when
+	// creating a cluster.
+	networkTypeOVN = "OVNKubernetes"
+	networkTypeSDN = "OpenShiftSDN"
+
+	// minOVNVersion is the first version of ROSA where clusters can use OVN-Kubernetes, which is
+	// also the default network plugin since then. See:
+	//
+	//	https://docs.openshift.com/rosa/networking/ovn_kubernetes_network_provider/about-ovn-kubernetes.html
+	minOVNVersion = "4.11.0"
+
+	// minVersionWithoutSDN is the first version where new clusters can no longer use OpenShift
+	// SDN, as it was deprecated in 4.14. See the "Networking" section of the release notes:
+	//
+	//	https://docs.openshift.com/container-platform/4.15/release_notes/ocp-4-15-release-notes.html
+	minVersionWithoutSDN = "4.15.0"
+)
+
 var OCMProperties = map[string]string{
 	propertyRosaTfVersion: build.Version,
 	propertyRosaTfCommit:  build.Commit,
 }
 
+// clusterNameRE is the RFC 1035 label syntax required by OCM for cluster names, as they are used
+// as part of the DNS names of the cluster.
+var clusterNameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// propertyKeyRE is the syntax of the keys of the user defined properties.
+var propertyKeyRE = regexp.MustCompile(`^[A-Za-z]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+const maxPropertyKeyLength = 63
+
 var kmsArnRE = regexp.MustCompile(
 	`^arn:aws[\w-]*:kms:[\w-]+:\d{12}:key\/mrk-[0-9a-f]{32}$|[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
 )
@@ -83,11 +115,29 @@ type ClusterRosaClassicResourceType struct {
 }
 
 type ClusterRosaClassicResource struct {
-	logger            logging.Logger
-	clusterCollection *cmv1.ClustersClient
-	versionCollection *cmv1.VersionsClient
+	logger                logging.Logger
+	clusterCollection     *cmv1.ClustersClient
+	versionCollection     *cmv1.VersionsClient
+	machineTypeCollection *cmv1.MachineTypesClient
+	oidcConfigCollection  *cmv1.OidcConfigsClient
+	awsInquiries          *cmv1.AWSInquiriesClient
+	awsSettings           awsSettings
+	cache                 *lookupCache
+	refresh               *refreshCache
+	pollInterval          time.Duration
+	iamPropagationTimeout time.Duration
+	versionEOLWarning     time.Duration
+
+	// defaultProperties are the properties from the provider configuration that are added to
+	// the cluster.
+	defaultProperties map[string]string
+
+	// fedRAMP indicates if the provider is connected to the FedRAMP environment of OCM.
+	fedRAMP bool
 }
 
+var _ tfsdk.ResourceWithModifyPlan = &ClusterRosaClassicResource{}
+
 func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
 	diags diag.Diagnostics) {
 	result = tfsdk.Schema{
@@ -108,9 +158,11 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				},
 			},
 			"name": {
-				Description: "Name of the cluster. Must be a maximum of 15 characters in length.",
-				Type:        types.StringType,
-				Required:    true,
+				Description: "Name of the cluster. Must be a maximum of 15 lowercase alphanumeric characters or '-', " +
+					"start with a letter, and end with an alphanumeric character.",
+				Type:       types.StringType,
+				Required:   true,
+				Validators: clusterNameValidators(),
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					ValueCannotBeChangedModifier(t.logger),
 				},
@@ -119,10 +171,14 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Description: "Cloud region identifier, for example 'us-east-1'.",
 				Type:        types.StringType,
 				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					RequiresReplaceModifier(t.logger,
+						"the cluster infrastructure lives in this region"),
+				},
 			},
 			"sts": {
 				Description: "STS Configuration",
-				Attributes:  stsResource(),
+				Attributes:  stsResource(t.logger),
 				Optional:    true,
 			},
 			"multi_az": {
@@ -132,7 +188,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional: true,
 				Computed: true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the availability zones of the cluster are selected at install time"),
 				},
 			},
 			"disable_workload_monitoring": {
@@ -141,6 +198,12 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Type:     types.BoolType,
 				Optional: true,
 			},
+			"expiration_timestamp": {
+				Description: expirationTimestampDescription,
+				Type:        types.StringType,
+				Optional:    true,
+				Validators:  expirationTimestampValidators(),
+			},
 			"disable_scp_checks": {
 				Description: "Enables you to monitor your own projects in isolation from Red Hat " +
 					"Site Reliability Engineer (SRE) platform metrics.",
@@ -187,7 +250,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"etcd encryption can only be configured at install time"),
 				},
 			},
 			"autoscaling_enabled": {
@@ -220,6 +284,12 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Type:        types.StringType,
 				Computed:    true,
 			},
+			"infra_id": {
+				Description: "Infrastructure identifier of the cluster, used as prefix " +
+					"of the names and in the tags of the cloud resources of the cluster.",
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"replicas": {
 				Description: "Number of worker nodes to provision. Single zone clusters need at least 2 nodes, " +
 					"multizone clusters need at least 3 nodes.",
@@ -230,7 +300,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 			"compute_machine_type": {
 				Description: "Identifier of the machine type used by the compute nodes, " +
 					"for example `r5.xlarge`. Use the `ocm_machine_types` data " +
-					"source to find the possible values.",
+					"source to find the possible values. The machine type is " +
+					"checked when planning.",
 				Type:     types.StringType,
 				Optional: true,
 				Computed: true,
@@ -244,14 +315,16 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Type: types.MapType{
 					ElemType: types.StringType,
 				},
-				Optional: true,
+				Optional:   true,
+				Validators: labelsValidators(),
 			},
 			"aws_account_id": {
 				Description: "Identifier of the AWS account.",
 				Type:        types.StringType,
 				Required:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster infrastructure lives in this AWS account"),
 				},
 			},
 			"aws_subnet_ids": {
@@ -261,16 +334,19 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				},
 				Optional: true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster nodes are installed in these subnets"),
 				},
 			},
 			"kms_key_arn": {
 				Description: "The key ARN is the Amazon Resource Name (ARN) of a AWS KMS (Key Management Service) Key. It is a unique, " +
 					"fully qualified identifier for the AWS KMS Key. A key ARN includes the AWS account, Region, and the key ID.",
-				Type:     types.StringType,
-				Optional: true,
+				Type:       types.StringType,
+				Validators: ARNValidator(kmsService, keyResourceType),
+				Optional:   true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the node volumes are encrypted with this key at install time"),
 				},
 			},
 			"fips": {
@@ -278,7 +354,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Type:        types.BoolType,
 				Optional:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"FIPS mode can only be configured at install time"),
 				},
 			},
 			"aws_private_link": {
@@ -287,9 +364,19 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster endpoints are created at install time"),
 				},
 			},
+			"aws_private_link_principals": {
+				Description: "ARNs of the AWS principals allowed to connect to the VPC " +
+					"endpoint service of the API of a PrivateLink cluster. Null for " +
+					"clusters that don't use PrivateLink.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Computed: true,
+			},
 			"availability_zones": {
 				Description: "availability zones",
 				Type: types.ListType{
@@ -297,7 +384,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				},
 				Optional: true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the availability zones of the cluster are selected at install time"),
 				},
 			},
 			"machine_cidr": {
@@ -306,7 +394,23 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster network is configured at install time"),
+				},
+			},
+			"network_type": {
+				Description: "Network plugin of the cluster, one of '" + networkTypeOVN +
+					"' or '" + networkTypeSDN + "'. '" + networkTypeSDN + "' isn't " +
+					"supported by OpenShift 4.15 and later, and '" + networkTypeOVN +
+					"' requires OpenShift 4.11 or later. When not set OCM selects the " +
+					"default of the version.",
+				Type:       types.StringType,
+				Optional:   true,
+				Computed:   true,
+				Validators: EnumValueValidator([]string{networkTypeOVN, networkTypeSDN}),
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					RequiresReplaceModifier(t.logger,
+						"the network plugin of the cluster is selected at install time"),
 				},
 			},
 			"proxy": {
@@ -328,9 +432,11 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 						Optional:    true,
 					},
 					"additional_trust_bundle": {
-						Description: "a string contains contains a PEM-encoded X.509 certificate bundle that will be added to the nodes' trusted certificate store.",
-						Type:        types.StringType,
-						Optional:    true,
+						Description: "a string contains contains a PEM-encoded X.509 certificate bundle that will be added to the nodes' trusted certificate store. " +
+							"Differences in line endings, indentation and blank lines are ignored.",
+						Type:       types.StringType,
+						Optional:   true,
+						Validators: trustBundleValidators(),
 					},
 				}),
 				Optional:   true,
@@ -342,7 +448,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster network is configured at install time"),
 				},
 			},
 			"pod_cidr": {
@@ -351,7 +458,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster network is configured at install time"),
 				},
 			},
 			"host_prefix": {
@@ -360,7 +468,8 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					ValueCannotBeChangedModifier(t.logger),
+					RequiresReplaceModifier(t.logger,
+						"the cluster network is configured at install time"),
 				},
 			},
 			"channel_group": {
@@ -397,6 +506,62 @@ func (t *ClusterRosaClassicResourceType) GetSchema(ctx context.Context) (result
 				Type:        types.StringType,
 				Computed:    true,
 			},
+			"current_version": {
+				Description: "OpenShift version that the cluster runs, for example " +
+					"'4.12.3'. It changes when the cluster is upgraded.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"preflight_checks": {
+				Description: "When set to 'true' the provider verifies at plan time, using " +
+					"AWS credentials, that the account roles given in the 'sts' attribute " +
+					"exist, belong to 'aws_account_id' and have the expected trust policy, " +
+					"that the AWS service quotas of the region are enough for the " +
+					"requested cluster, and that the subnets given in 'aws_subnet_ids' " +
+					"exist, belong to one VPC inside 'machine_cidr' and have the " +
+					"'kubernetes.io/role/elb' or 'kubernetes.io/role/internal-elb' tag. " +
+					"When 'kms_key_arn' is given it verifies that the key policy allows " +
+					"the installer role, and the EBS CSI driver operator role, to use " +
+					"the key. When the cluster uses an existing OIDC " +
+					"configuration it also verifies, right before creating the cluster, " +
+					"that the OIDC provider is registered in IAM and that the operator " +
+					"roles exist and trust the service accounts of the operators. " +
+					"Default value is 'false'.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"validation_only": {
+				Description: "When set to 'true' the cluster isn't created. Instead the " +
+					"plan runs all the validations and preflight checks, including the " +
+					"version, the account roles, the network configuration and the AWS " +
+					"service quotas, and reports the problems found. Applying fails " +
+					"without creating anything. Default value is 'false'.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"dry_run": {
+				Description: "When set to 'true' the plan of a new cluster sends it to " +
+					"OCM in dry run mode, so that all the server side validations run " +
+					"without creating anything and problems like name conflicts, " +
+					"missing quota or invalid combinations of attributes are reported " +
+					"before apply. The dry run is skipped when some attributes of the " +
+					"cluster aren't known yet. Default value is 'false'.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"billing_model": {
+				Description: "Billing model of the cluster, one of 'standard', 'marketplace' " +
+					"or 'marketplace-aws'. Clusters billed through the AWS Marketplace, " +
+					"including private offers, use 'marketplace-aws'. Default value is " +
+					"'standard'.",
+				Type:       types.StringType,
+				Optional:   true,
+				Computed:   true,
+				Validators: EnumValueValidator(billingModels),
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
 			"ec2_metadata_http_tokens": {
 				Description: "Which ec2 metadata mode to use for metadata service interaction options for EC2 instances" +
 					"can be optional or required, available only from 4.11.0",
@@ -424,11 +589,25 @@ func (t *ClusterRosaClassicResourceType) NewResource(ctx context.Context,
 	// Get the version collection
 	versionCollection := parent.connection.ClustersMgmt().V1().Versions()
 
+	// Get the machine type collection
+	machineTypeCollection := parent.connection.ClustersMgmt().V1().MachineTypes()
+
 	// Create the resource:
 	result = &ClusterRosaClassicResource{
-		logger:            parent.logger,
-		clusterCollection: clusterCollection,
-		versionCollection: versionCollection,
+		logger:                parent.logger,
+		clusterCollection:     clusterCollection,
+		versionCollection:     versionCollection,
+		machineTypeCollection: machineTypeCollection,
+		oidcConfigCollection:  parent.connection.ClustersMgmt().V1().OidcConfigs(),
+		awsInquiries:          parent.connection.ClustersMgmt().V1().AWSInquiries(),
+		awsSettings:           parent.awsSettings,
+		cache:                 parent.cache,
+		refresh:               parent.refresh,
+		pollInterval:          parent.pollInterval,
+		iamPropagationTimeout: parent.iamPropagationTimeout,
+		versionEOLWarning:     parent.versionEOLWarning,
+		defaultProperties:     parent.defaultClusterProperties,
+		fedRAMP:               parent.fedRAMP,
 	}
 
 	return
@@ -439,7 +618,8 @@ const (
 )
 
 func createClassicClusterObject(ctx context.Context,
-	state *ClusterRosaClassicState, logger logging.Logger, diags diag.Diagnostics) (*cmv1.Cluster, error) {
+	state *ClusterRosaClassicState, defaultProperties map[string]string, logger logging.Logger,
+	diags diag.Diagnostics) (*cmv1.Cluster, error) {
 
 	builder := cmv1.NewCluster()
 	clusterName := state.Name.Value
@@ -459,6 +639,9 @@ func createClassicClusterObject(ctx context.Context,
 	builder.Name(state.Name.Value)
 	builder.CloudProvider(cmv1.NewCloudProvider().ID(awsCloudProvider))
 	builder.Product(cmv1.NewProduct().ID(rosaProduct))
+	if !common.IsStringAttributeEmpty(state.BillingModel) {
+		builder.BillingModel(cmv1.BillingModel(state.BillingModel.Value))
+	}
 	builder.Region(cmv1.NewCloudRegion().ID(state.CloudRegion.Value))
 	if !state.MultiAZ.Unknown && !state.MultiAZ.Null {
 		builder.MultiAZ(state.MultiAZ.Value)
@@ -468,10 +651,8 @@ func createClassicClusterObject(ctx context.Context,
 	for k, v := range OCMProperties {
 		properties[k] = v
 	}
-	if !state.Properties.Unknown && !state.Properties.Null {
-		for k, v := range state.Properties.Elems {
-			properties[k] = v.(types.String).Value
-		}
+	for k, v := range mergeClusterProperties(defaultProperties, state.Properties) {
+		properties[k] = v
 	}
 	builder.Properties(properties)
 
@@ -487,6 +668,14 @@ func createClassicClusterObject(ctx context.Context,
 		builder.DisableUserWorkloadMonitoring(state.DisableWorkloadMonitoring.Value)
 	}
 
+	if !common.IsStringAttributeEmpty(state.ExpirationTimestamp) {
+		expiration, err := parseExpirationTimestamp(state.ExpirationTimestamp.Value)
+		if err != nil {
+			return nil, err
+		}
+		builder.ExpirationTimestamp(expiration)
+	}
+
 	nodes := cmv1.NewClusterNodes()
 	if !state.Replicas.Unknown && !state.Replicas.Null {
 		nodes.Compute(int(state.Replicas.Value))
@@ -640,6 +829,9 @@ func createClassicClusterObject(ctx context.Context,
 	if !state.HostPrefix.Unknown && !state.HostPrefix.Null {
 		network.HostPrefix(int(state.HostPrefix.Value))
 	}
+	if !common.IsStringAttributeEmpty(state.NetworkType) {
+		network.Type(state.NetworkType.Value)
+	}
 	if !network.Empty() {
 		builder.Network(network)
 	}
@@ -719,7 +911,7 @@ func buildProxy(state *ClusterRosaClassicState, builder *cmv1.ClusterBuilder) (*
 		}
 
 		if !common.IsStringAttributeEmpty(state.Proxy.AdditionalTrustBundle) {
-			additionalTrustBundle = state.Proxy.AdditionalTrustBundle.Value
+			additionalTrustBundle = normalizeTrustBundle(state.Proxy.AdditionalTrustBundle.Value)
 			builder.AdditionalTrustBundle(additionalTrustBundle)
 		}
 
@@ -775,41 +967,138 @@ func validateHttpTokensVersion(ctx context.Context, logger logging.Logger, state
 	return nil
 }
 
+// validateNetworkTypeVersion checks that the network plugin requested for the cluster is supported
+// by the given version.
+func validateNetworkTypeVersion(state *ClusterRosaClassicState, version string) error {
+	if common.IsStringAttributeEmpty(state.NetworkType) {
+		return nil
+	}
+	networkType := state.NetworkType.Value
+	switch networkType {
+	case networkTypeOVN:
+		supported, err := common.IsGreaterThanOrEqual(version, minOVNVersion)
+		if err != nil {
+			return fmt.Errorf("version '%s' is not supported: %v", version, err)
+		}
+		if !supported {
+			return fmt.Errorf(
+				"network type '%s' requires version %s or later, but version is '%s'",
+				networkType, minOVNVersion, version,
+			)
+		}
+	case networkTypeSDN:
+		unsupported, err := common.IsGreaterThanOrEqual(version, minVersionWithoutSDN)
+		if err != nil {
+			return fmt.Errorf("version '%s' is not supported: %v", version, err)
+		}
+		if unsupported {
+			return fmt.Errorf(
+				"network type '%s' isn't supported by version %s and later, but "+
+					"version is '%s', use '%s' instead",
+				networkType, minVersionWithoutSDN, version, networkTypeOVN,
+			)
+		}
+	}
+	return nil
+}
+
 func (r *ClusterRosaClassicResource) validateAccountRoles(ctx context.Context, state *ClusterRosaClassicState, version string) error {
 	r.logger.Debug(ctx, "Validating if cluster version is compatible to account roles' version")
 	region := state.CloudRegion.Value
 
 	r.logger.Debug(ctx, "Cluster version is %s", version)
-	roleARNs := []string{
-		state.Sts.RoleARN.Value,
-		state.Sts.SupportRoleArn.Value,
-		state.Sts.InstanceIAMRoles.MasterRoleARN.Value,
-		state.Sts.InstanceIAMRoles.WorkerRoleARN.Value,
-	}
-
-	for _, ARN := range roleARNs {
-		if ARN == "" {
+	roles := []struct {
+		kind string
+		arn  string
+	}{
+		{"Installer", state.Sts.RoleARN.Value},
+		{"Support", state.Sts.SupportRoleArn.Value},
+		{"Control plane", state.Sts.InstanceIAMRoles.MasterRoleARN.Value},
+		{"Worker", state.Sts.InstanceIAMRoles.WorkerRoleARN.Value},
+	}
+
+	minorVersion := getOcmVersionMinor(version)
+	var incompatible []incompatibleAccountRole
+	for _, candidate := range roles {
+		if candidate.arn == "" {
 			continue
 		}
 		// get role from arn
-		role, err := getRoleByARN(ARN, region)
+		role, err := getRoleByARN(candidate.arn, region, r.awsSettings)
 		if err != nil {
-			return fmt.Errorf("Could not get Role '%s' : %v", ARN, err)
+			return fmt.Errorf("Could not get Role '%s' : %v", candidate.arn, err)
 		}
 
-		validVersion, err := r.hasCompatibleVersionTags(ctx, role.Tags, getOcmVersionMinor(version))
+		// Roles that use managed policies are updated by Red Hat and are compatible with all
+		// the versions:
+		if hasManagedPoliciesTag(role.Tags) {
+			r.logger.Debug(ctx, "Role '%s' uses managed policies", candidate.arn)
+			continue
+		}
+
+		validVersion, err := r.hasCompatibleVersionTags(ctx, role.Tags, minorVersion)
 		if err != nil {
-			return fmt.Errorf("Could not validate Role '%s' : %v", ARN, err)
+			return fmt.Errorf("Could not validate Role '%s' : %v", candidate.arn, err)
 		}
 		if !validVersion {
-			return fmt.Errorf("account role '%s' is not compatible with version %s. "+
-				"Run 'rosa create account-roles' to create compatible roles and try again",
-				ARN, version)
+			incompatible = append(incompatible, incompatibleAccountRole{
+				kind:    candidate.kind,
+				arn:     candidate.arn,
+				version: getTagValue(role.Tags, tagsOpenShiftVersion),
+			})
 		}
 	}
+	if len(incompatible) > 0 {
+		return fmt.Errorf("the following account roles are not compatible with version %s:\n\n%s\n"+
+			"Run 'rosa create account-roles' to create compatible roles and try again",
+			version, formatIncompatibleAccountRoles(incompatible, minorVersion))
+	}
 
 	return nil
 }
+
+// incompatibleAccountRole describes an account role whose version is lower than the version of
+// the cluster.
+type incompatibleAccountRole struct {
+	kind    string
+	arn     string
+	version string
+}
+
+// formatIncompatibleAccountRoles returns a table with the given roles, their version and the
+// version required by the cluster.
+func formatIncompatibleAccountRoles(roles []incompatibleAccountRole, requiredVersion string) string {
+	buffer := &bytes.Buffer{}
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ROLE\tARN\tROLE VERSION\tREQUIRED VERSION\n")
+	for _, role := range roles {
+		version := role.version
+		if version == "" {
+			version = "none"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", role.kind, role.arn, version, requiredVersion)
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+// hasManagedPoliciesTag checks if the given role tags indicate that the role uses the policies
+// managed by Red Hat instead of policies created for a specific version.
+func hasManagedPoliciesTag(iamTags []*iam.Tag) bool {
+	return strings.EqualFold(getTagValue(iamTags, tagsManagedPolicies), "true")
+}
+
+// getTagValue returns the value of the tag with the given key, or an empty string if there is no
+// such tag.
+func getTagValue(iamTags []*iam.Tag, key string) string {
+	for _, tag := range iamTags {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
+
 func (r *ClusterRosaClassicResource) hasCompatibleVersionTags(ctx context.Context, iamTags []*iam.Tag, version string) (bool, error) {
 	if len(iamTags) == 0 {
 		return false, nil
@@ -834,7 +1123,7 @@ func (r *ClusterRosaClassicResource) hasCompatibleVersionTags(ctx context.Contex
 	return false, nil
 }
 
-func getRoleByARN(roleARN, region string) (*iam.Role, error) {
+func getRoleByARN(roleARN, region string, settings awsSettings) (*iam.Role, error) {
 	// validate arn
 	parsedARN, err := arn.Parse(roleARN)
 	if err != nil {
@@ -851,7 +1140,7 @@ func getRoleByARN(roleARN, region string) (*iam.Role, error) {
 	m := strings.LastIndex(resource, "/")
 	roleName := resource[m+1:]
 
-	sess, err := buildSession(region)
+	sess, err := buildSession(region, settings)
 	if err != nil {
 		return nil, err
 	}
@@ -866,18 +1155,24 @@ func getRoleByARN(roleARN, region string) (*iam.Role, error) {
 	return roleOutput.Role, nil
 }
 
-func buildSession(region string) (*session.Session, error) {
+func buildSession(region string, settings awsSettings) (*session.Session, error) {
+	config := aws.Config{
+		CredentialsChainVerboseErrors: aws.Bool(true),
+		Region:                        &region,
+		Retryer:                       buildCustomRetryer(),
+		HTTPClient: &http.Client{
+			Transport: http.DefaultTransport,
+		},
+	}
+	if settings.accessKeyID != "" && settings.secretAccessKey != "" {
+		config.Credentials = credentials.NewStaticCredentials(
+			settings.accessKeyID, settings.secretAccessKey, "",
+		)
+	}
 	sess, err := session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
-		Profile:           "",
-		Config: aws.Config{
-			CredentialsChainVerboseErrors: aws.Bool(true),
-			Region:                        &region,
-			Retryer:                       buildCustomRetryer(),
-			HTTPClient: &http.Client{
-				Transport: http.DefaultTransport,
-			},
-		},
+		Profile:           settings.profile,
+		Config:            config,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create session. Check your AWS configuration and try again")
@@ -934,6 +1229,14 @@ func (r *ClusterRosaClassicResource) getVersionList(logger logging.Logger, ctx c
 	return
 }
 func (r *ClusterRosaClassicResource) getVersions(logger logging.Logger, ctx context.Context, channelGroup string) (versions []*cmv1.Version, err error) {
+	return r.cache.getVersions("rosa\n"+channelGroup, func() ([]*cmv1.Version, error) {
+		return r.listVersions(logger, ctx, channelGroup)
+	})
+}
+
+// listVersions retrieves from the server all the pages of ROSA enabled versions of the given
+// channel group, sorted by descending semver.
+func (r *ClusterRosaClassicResource) listVersions(logger logging.Logger, ctx context.Context, channelGroup string) (versions []*cmv1.Version, err error) {
 	page := 1
 	size := 100
 	filter := strings.Join([]string{
@@ -948,13 +1251,16 @@ func (r *ClusterRosaClassicResource) getVersions(logger logging.Logger, ctx cont
 			Order("default desc, id desc").
 			Page(page).
 			Size(size).
-			Send()
+			SendContext(ctx)
 		if err != nil {
 			logger.Debug(ctx, err.Error())
 			return nil, err
 		}
 		versions = append(versions, response.Items().Slice()...)
-		if response.Size() < size {
+
+		// The server may return pages smaller than requested, so stop only when all the items
+		// reported by the total have been retrieved:
+		if response.Items().Len() == 0 || len(versions) >= response.Total() {
 			break
 		}
 		page++
@@ -973,85 +1279,275 @@ func (r *ClusterRosaClassicResource) getVersions(logger logging.Logger, ctx cont
 	return
 }
 
-func (r *ClusterRosaClassicResource) Create(ctx context.Context,
-	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
-	// Get the plan:
-	state := &ClusterRosaClassicState{}
-	diags := request.Plan.Get(ctx, state)
-	response.Diagnostics.Append(diags...)
+func (r *ClusterRosaClassicResource) ModifyPlan(ctx context.Context, request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	checkComputeMachineType(ctx, request, response, r.cache, r.machineTypeCollection, awsCloudProvider)
+	r.checkPlannedVersionEOL(ctx, request, response)
+
+	// The preflight checks are only relevant when the cluster is going to be created:
+	if !request.State.Raw.IsNull() {
+		return
+	}
+
+	plan := &ClusterRosaClassicState{}
+	diags := request.Plan.Get(ctx, plan)
+	if diags.HasError() {
+		// The plan may still contain unknown values that can't be checked yet, the checks
+		// will be skipped in that case.
+		return
+	}
+
+	// Clusters in the wrong partition for the environment would be rejected when created, and
+	// checking it is cheap, so it is always done:
+	for _, err := range checkClusterPartition(plan, r.fedRAMP) {
+		response.Diagnostics.AddError("Cluster can't be created in this environment", err.Error())
+	}
 	if response.Diagnostics.HasError() {
 		return
 	}
+
+	validationOnly := isValidationOnly(plan)
+	preflightChecks := !plan.PreflightChecks.Unknown && !plan.PreflightChecks.Null &&
+		plan.PreflightChecks.Value
+	dryRun := !plan.DryRun.Unknown && !plan.DryRun.Null && plan.DryRun.Value
+	if !validationOnly && !preflightChecks && !dryRun {
+		return
+	}
+
+	// In validation only mode run also the checks that are otherwise done when the cluster is
+	// created, so that the plan reports all the problems. The dry run needs the same checks,
+	// as it sends the resulting object to the server:
+	if validationOnly || dryRun {
+		if !request.Plan.Raw.IsFullyKnown() {
+			summary := validationOnlySummary
+			if !validationOnly {
+				summary = dryRunSummary
+			}
+			response.Diagnostics.AddWarning(
+				summary,
+				"Some attributes of the cluster aren't known yet, only the preflight checks "+
+					"of the known attributes were done",
+			)
+		} else {
+			object := r.validateAndBuildCluster(ctx, plan, &response.Diagnostics)
+			if dryRun && object != nil {
+				err := dryRunCluster(ctx, r.clusterCollection, object)
+				if err != nil {
+					addClassifiedError(
+						&response.Diagnostics,
+						dryRunSummary,
+						fmt.Sprintf(
+							"Cluster with name '%s' was rejected by the server: %v",
+							plan.Name.Value, err,
+						),
+						err,
+					)
+				}
+			}
+		}
+	}
+	if !preflightChecks && !validationOnly {
+		return
+	}
+	r.checkAccountRoles(ctx, plan, &response.Diagnostics)
+	r.checkQuotas(ctx, plan, &response.Diagnostics)
+	r.checkSubnets(ctx, plan, &response.Diagnostics)
+	r.checkKMSKey(ctx, plan, &response.Diagnostics)
+	if validationOnly && !response.Diagnostics.HasError() {
+		response.Diagnostics.AddWarning(
+			validationOnlySummary,
+			fmt.Sprintf(
+				"Cluster with name '%s' passed all the validations, it will not be "+
+					"created while 'validation_only' is 'true'",
+				plan.Name.Value,
+			),
+		)
+	}
+}
+
+// checkPlannedVersionEOL warns if the version requested for a new cluster, or the version that an
+// existing cluster is going to be upgraded to, is close to its end of life. The current version
+// of existing clusters is checked during the refresh instead.
+func (r *ClusterRosaClassicResource) checkPlannedVersionEOL(ctx context.Context,
+	request tfsdk.ModifyResourcePlanRequest, response *tfsdk.ModifyResourcePlanResponse) {
+	var version, channelGroup types.String
+	diags := request.Plan.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("version"), &version)
+	if diags.HasError() || version.Unknown || version.Null {
+		return
+	}
+	if !request.State.Raw.IsNull() {
+		var current types.String
+		diags = request.State.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("version"), &current)
+		if diags.HasError() || current.Value == version.Value {
+			return
+		}
+	}
+	diags = request.Plan.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("channel_group"), &channelGroup)
+	if diags.HasError() || channelGroup.Unknown {
+		return
+	}
+	r.checkVersionEOL(ctx, version.Value, channelGroup.Value, &response.Diagnostics)
+}
+
+// isValidationOnly checks if the cluster should only be validated, without creating it.
+func isValidationOnly(state *ClusterRosaClassicState) bool {
+	return !state.ValidationOnly.Unknown && !state.ValidationOnly.Null && state.ValidationOnly.Value
+}
+
+// validateAndBuildCluster runs the validations that are done before creating a cluster, adding
+// the problems found to the given diagnostics, and returns the object that would be sent to the
+// server.
+func (r *ClusterRosaClassicResource) validateAndBuildCluster(ctx context.Context,
+	state *ClusterRosaClassicState, diags *diag.Diagnostics) *cmv1.Cluster {
 	summary := "Can't build cluster"
 
 	version, err := r.getAndValidateVersionInChannelGroup(ctx, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			diags,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
-		return
+		return nil
 	}
 
 	err = r.validateAccountRoles(ctx, state, version)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			diags,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s', failed while validating account roles: %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
-		return
+		return nil
 	}
 	err = validateHttpTokensVersion(ctx, r.logger, state, version)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			diags,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
-		return
+		return nil
+	}
+	err = validateNetworkTypeVersion(state, version)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			summary,
+			fmt.Sprintf(
+				"Can't build cluster with name '%s': %v",
+				state.Name.Value, err,
+			),
+			err,
+		)
+		return nil
 	}
 
-	object, err := createClassicClusterObject(ctx, state, r.logger, diags)
+	object, err := createClassicClusterObject(ctx, state, r.defaultProperties, r.logger, *diags)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			diags,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
+		return nil
+	}
+
+	return object
+}
+
+func (r *ClusterRosaClassicResource) Create(ctx context.Context,
+	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	// Get the plan:
+	state := &ClusterRosaClassicState{}
+	diags := request.Plan.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
+	summary := "Can't build cluster"
 
-	add, err := r.clusterCollection.Add().Body(object).SendContext(ctx)
-	if err != nil {
+	object := r.validateAndBuildCluster(ctx, state, &response.Diagnostics)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if isValidationOnly(state) {
 		response.Diagnostics.AddError(
+			validationOnlySummary,
+			fmt.Sprintf(
+				"Cluster with name '%s' passed all the validations but wasn't created "+
+					"because 'validation_only' is 'true'",
+				state.Name.Value,
+			),
+		)
+		return
+	}
+
+	// The operator roles and the OIDC provider are usually created in the same apply, so they
+	// are checked now instead of when planning:
+	if !state.PreflightChecks.Unknown && !state.PreflightChecks.Null && state.PreflightChecks.Value {
+		r.checkOperatorRoles(ctx, state, &response.Diagnostics)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The account and operator roles are often created in the same apply, and OCM may not
+	// see them yet, so retry while it complains about them:
+	var add *cmv1.ClustersAddResponse
+	err := retryOnIAMPropagation(ctx, r.iamPropagationTimeout, iamPropagationRetryInterval,
+		func(ctx context.Context) error {
+			var err error
+			add, err = r.clusterCollection.Add().Body(object).SendContext(ctx)
+			if isIAMPropagationError(err) {
+				r.logger.Info(ctx, "Cluster '%s' was rejected because the IAM roles "+
+					"aren't visible yet, will retry: %v", state.Name.Value, err)
+			}
+			return err
+		})
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't create cluster with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
 		return
 	}
 	object = add.Body()
 
 	// Save the state:
-	err = populateRosaClassicClusterState(ctx, object, state, r.logger, DefaultHttpClient{})
+	err = r.populateState(ctx, object, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -1069,33 +1565,40 @@ func (r *ClusterRosaClassicResource) Read(ctx context.Context, request tfsdk.Rea
 		return
 	}
 
-	// Find the cluster:
-	get, err := r.clusterCollection.Cluster(state.ID.Value).Get().SendContext(ctx)
+	// Find the cluster, the result is shared with the other resources of the cluster that are
+	// read during the same refresh:
+	object, err := r.refresh.getCluster(ctx, r.clusterCollection, state.ID.Value)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
-	object := get.Body()
 
 	// Save the state:
-	err = populateRosaClassicClusterState(ctx, object, state, r.logger, DefaultHttpClient{})
+	err = r.populateState(ctx, object, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
 	diags = response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
+
+	// Warn if the version that the cluster is running is close to its end of life:
+	r.checkVersionEOL(ctx, state.CurrentVersion.Value, state.ChannelGroup.Value, &response.Diagnostics)
 }
 
 func (r *ClusterRosaClassicResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
@@ -1109,6 +1612,7 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request tfsdk.U
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.ID.Value)
 
 	// Get the plan:
 	plan := &ClusterRosaClassicState{}
@@ -1122,24 +1626,28 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request tfsdk.U
 
 	clusterBuilder, shouldUpdateNodes, err := updateNodes(state, plan, clusterBuilder)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update cluster nodes for cluster with identifier: `%s`, %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
 
 	clusterBuilder, shouldUpdateProxy, err := updateProxy(state, plan, clusterBuilder)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update proxy's configuration for cluster with identifier: `%s`, %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -1149,17 +1657,34 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request tfsdk.U
 		clusterBuilder.DisableUserWorkloadMonitoring(plan.DisableWorkloadMonitoring.Value)
 	}
 
-	if !shouldUpdateProxy && !shouldUpdateNodes && !shouldPatchDisableWorkloadMonitoring {
+	shouldPatchExpiration, err := updateExpirationTimestamp(state.ExpirationTimestamp,
+		plan.ExpirationTimestamp, clusterBuilder)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("expiration_timestamp"),
+			"Can't update cluster",
+			fmt.Sprintf(
+				"Can't update expiration timestamp for cluster with identifier: `%s`, %v",
+				state.ID.Value, err,
+			),
+		)
+		return
+	}
+
+	if !shouldUpdateProxy && !shouldUpdateNodes && !shouldPatchDisableWorkloadMonitoring &&
+		!shouldPatchExpiration {
 		return
 	}
 	clusterSpec, err := clusterBuilder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't build cluster patch",
 			fmt.Sprintf(
 				"Can't build patch for cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -1168,12 +1693,14 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request tfsdk.U
 		Body(clusterSpec).
 		SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -1186,13 +1713,15 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request tfsdk.U
 	object := update.Body()
 
 	// Update the state:
-	err = populateRosaClassicClusterState(ctx, object, plan, r.logger, DefaultHttpClient{})
+	err = r.populateState(ctx, object, plan)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -1209,6 +1738,10 @@ func updateProxy(state, plan *ClusterRosaClassicState, clusterBuilder *cmv1.Clus
 		_, patchHttpProxy := common.ShouldPatchString(state.Proxy.HttpProxy, plan.Proxy.HttpProxy)
 		_, patchHttpsProxy := common.ShouldPatchString(state.Proxy.HttpsProxy, plan.Proxy.HttpsProxy)
 		_, patchAdditionalTrustBundle := common.ShouldPatchString(state.Proxy.AdditionalTrustBundle, plan.Proxy.AdditionalTrustBundle)
+		if patchAdditionalTrustBundle && !state.Proxy.AdditionalTrustBundle.Null &&
+			sameTrustBundle(state.Proxy.AdditionalTrustBundle.Value, plan.Proxy.AdditionalTrustBundle.Value) {
+			patchAdditionalTrustBundle = false
+		}
 		if patchNoProxy || patchHttpProxy || patchHttpsProxy || patchAdditionalTrustBundle {
 			shouldUpdateProxy = true
 		}
@@ -1270,21 +1803,26 @@ func (r *ClusterRosaClassicResource) Delete(ctx context.Context, request tfsdk.D
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.ID.Value)
 
 	// Send the request to delete the cluster:
 	resource := r.clusterCollection.Cluster(state.ID.Value)
-	_, err := resource.Delete().SendContext(ctx)
+	gone, err := deleteCluster(ctx, resource)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't delete cluster",
 			fmt.Sprintf(
 				"Can't delete cluster with identifier '%s': %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
-	if !state.DisableWaitingInDestroy.Unknown && !state.DisableWaitingInDestroy.Null && state.DisableWaitingInDestroy.Value {
+	if gone {
+		r.logger.Info(ctx, "Cluster '%s' was already deleted", state.ID.Value)
+	} else if !state.DisableWaitingInDestroy.Unknown && !state.DisableWaitingInDestroy.Null && state.DisableWaitingInDestroy.Value {
 		r.logger.Info(ctx, "Waiting for destroy to be completed, is disabled")
 	} else {
 		timeout := defaultTimeoutInMinutes
@@ -1297,12 +1835,14 @@ func (r *ClusterRosaClassicResource) Delete(ctx context.Context, request tfsdk.D
 		}
 		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, ctx, timeout, resource)
 		if err != nil {
-			response.Diagnostics.AddError(
+			addClassifiedError(
+				&response.Diagnostics,
 				"Can't poll cluster state",
 				fmt.Sprintf(
 					"Can't poll state of cluster with identifier '%s': %v",
 					state.ID.Value, err,
 				),
+				err,
 			)
 			return
 		}
@@ -1324,12 +1864,14 @@ func (r *ClusterRosaClassicResource) ImportState(ctx context.Context, request tf
 	// Try to retrieve the object:
 	get, err := r.clusterCollection.Cluster(request.ID).Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				request.ID, err,
 			),
+			err,
 		)
 		return
 	}
@@ -1337,21 +1879,60 @@ func (r *ClusterRosaClassicResource) ImportState(ctx context.Context, request tf
 
 	// Save the state:
 	state := &ClusterRosaClassicState{}
-	err = populateRosaClassicClusterState(ctx, object, state, r.logger, DefaultHttpClient{})
+	err = r.populateState(ctx, object, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
+	populateImportedRosaClassicClusterState(object, state)
 
 	diags := response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
 
+// populateImportedRosaClassicClusterState copies to the state of an imported cluster the
+// attributes that can't be changed and that aren't refreshed when reading, so that the
+// configuration generated from the imported state describes the cluster completely.
+func populateImportedRosaClassicClusterState(object *cmv1.Cluster, state *ClusterRosaClassicState) {
+	tags, ok := object.AWS().GetTags()
+	if ok && len(tags) > 0 {
+		state.Tags = types.Map{
+			ElemType: types.StringType,
+			Elems:    map[string]attr.Value{},
+		}
+		for k, v := range tags {
+			state.Tags.Elems[k] = types.String{
+				Value: v,
+			}
+		}
+	}
+}
+
+// populateState copies the data from the API object to the Terraform state. The properties that
+// come from the provider configuration are moved to 'ocm_properties', so that they don't show as
+// differences with the user defined properties.
+func (r *ClusterRosaClassicResource) populateState(ctx context.Context, object *cmv1.Cluster,
+	state *ClusterRosaClassicState) error {
+	previous := state.Properties
+	err := populateRosaClassicClusterState(ctx, object, state, r.logger, DefaultHttpClient{})
+	if err != nil {
+		return err
+	}
+	var defaulted types.Map
+	state.Properties, defaulted = splitClusterProperties(r.defaultProperties, previous, state.Properties)
+	for k, v := range defaulted.Elems {
+		state.OCMProperties.Elems[k] = v
+	}
+	return nil
+}
+
 // populateRosaClassicClusterState copies the data from the API object to the Terraform state.
 func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster, state *ClusterRosaClassicState, logger logging.Logger, httpClient HttpClient) error {
 	state.ID = types.String{
@@ -1402,6 +1983,9 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 	state.Domain = types.String{
 		Value: fmt.Sprintf("%s.%s", object.Name(), object.DNS().BaseDomain()),
 	}
+	state.InfraID = types.String{
+		Value: object.InfraID(),
+	}
 	state.Replicas = types.Int64{
 		Value: int64(object.Nodes().Compute()),
 	}
@@ -1410,7 +1994,7 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 	}
 
 	labels, ok := object.Nodes().GetComputeLabels()
-	if ok {
+	if ok && len(labels) > 0 {
 		state.DefaultMPLabels = types.Map{
 			ElemType: types.StringType,
 			Elems:    map[string]attr.Value{},
@@ -1420,21 +2004,21 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 				Value: v,
 			}
 		}
-	}
-
-	disableUserWorkload, ok := object.GetDisableUserWorkloadMonitoring()
-	if ok && disableUserWorkload {
-		state.DisableWorkloadMonitoring = types.Bool{
-			Value: true,
+	} else if len(state.DefaultMPLabels.Elems) > 0 {
+		// The labels were removed outside of Terraform:
+		state.DefaultMPLabels = types.Map{
+			ElemType: types.StringType,
+			Null:     true,
 		}
 	}
 
-	isFips, ok := object.GetFIPS()
-	if ok && isFips {
-		state.FIPS = types.Bool{
-			Value: true,
-		}
-	}
+	state.DisableWorkloadMonitoring = common.OptionalBool(
+		state.DisableWorkloadMonitoring,
+		object.DisableUserWorkloadMonitoring(),
+	)
+	state.FIPS = common.OptionalBool(state.FIPS, object.FIPS())
+	state.ExpirationTimestamp = expirationTimestampState(state.ExpirationTimestamp, object)
+
 	autoScaleCompute, ok := object.Nodes().GetAutoscaleCompute()
 	if ok {
 		var maxReplicas, minReplicas int
@@ -1457,6 +2041,7 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 		}
 	} else {
 		// autoscaling not enabled - initialize the MaxReplica and MinReplica
+		state.AutoScalingEnabled = common.OptionalBool(state.AutoScalingEnabled, false)
 		state.MaxReplicas.Null = true
 		state.MinReplicas.Null = true
 	}
@@ -1475,20 +2060,19 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 		Value: object.CCS().Enabled(),
 	}
 
-	disableSCPChecks, ok := object.CCS().GetDisableSCPChecks()
-	if ok && disableSCPChecks {
-		state.DisableSCPChecks = types.Bool{
-			Value: true,
-		}
-	}
+	state.DisableSCPChecks = common.OptionalBool(state.DisableSCPChecks, object.CCS().DisableSCPChecks())
 
 	state.EtcdEncryption = types.Bool{
 		Value: object.EtcdEncryption(),
 	}
 
-	//The API does not return account id
+	// The API doesn't always return the account identifier, in that case it is taken from the
+	// installer role, which is always in the account of the cluster:
 	awsAccountID, ok := object.AWS().GetAccountID()
-	if ok {
+	if !ok || awsAccountID == "" {
+		awsAccountID = arnAccountID(object.AWS().STS().RoleARN())
+	}
+	if awsAccountID != "" {
 		state.AWSAccountID = types.String{
 			Value: awsAccountID,
 		}
@@ -1504,6 +2088,22 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 			Null: true,
 		}
 	}
+	state.AWSPrivateLinkPrincipals = types.List{
+		ElemType: types.StringType,
+		Null:     true,
+	}
+	privateLinkConfiguration, ok := object.AWS().GetPrivateLinkConfiguration()
+	if ok && awsPrivateLink {
+		var principals []string
+		for _, principal := range privateLinkConfiguration.Principals() {
+			if principal.Principal() != "" {
+				principals = append(principals, principal.Principal())
+			}
+		}
+		if len(principals) > 0 {
+			state.AWSPrivateLinkPrincipals = common.StringArrayToList(principals)
+		}
+	}
 	kmsKeyArn, ok := object.AWS().GetKMSKeyArn()
 	if ok {
 		state.KMSKeyArn = types.String{
@@ -1511,6 +2111,10 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 		}
 	}
 
+	state.BillingModel = types.String{
+		Value: string(object.BillingModel()),
+	}
+
 	httpTokensState, ok := object.AWS().GetEc2MetadataHttpTokens()
 	if ok && httpTokensState != "" {
 		state.Ec2MetadataHttpTokens = types.String{
@@ -1523,13 +2127,11 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 		if state.Sts == nil {
 			state.Sts = &Sts{}
 		}
-		oidc_endpoint_url := sts.OIDCEndpointURL()
-		if strings.HasPrefix(oidc_endpoint_url, "https://") {
-			oidc_endpoint_url = strings.TrimPrefix(oidc_endpoint_url, "https://")
-		}
-
 		state.Sts.OIDCEndpointURL = types.String{
-			Value: oidc_endpoint_url,
+			Value: oidcEndpointHostPath(sts.OIDCEndpointURL()),
+		}
+		state.Sts.OIDCIssuerURL = types.String{
+			Value: oidcIssuerURL(sts.OIDCEndpointURL()),
 		}
 		state.Sts.RoleARN = types.String{
 			Value: sts.RoleARN(),
@@ -1546,7 +2148,10 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 				Value: instanceIAMRoles.WorkerRoleARN(),
 			}
 		}
+
 		// TODO: fix a bug in uhc-cluster-services
+		// The server may return a prefix different to the configured one, so it is only
+		// taken from the server when it isn't known, for example after an import:
 		if state.Sts.OperatorRolePrefix.Unknown || state.Sts.OperatorRolePrefix.Null {
 			operatorRolePrefix, ok := sts.GetOperatorRolePrefix()
 			if ok {
@@ -1555,22 +2160,44 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 				}
 			}
 		}
+
+		state.Sts.OperatorRoleARNs = types.Map{
+			ElemType: types.StringType,
+			Elems:    map[string]attr.Value{},
+		}
+		for _, operatorRole := range sts.OperatorIAMRoles() {
+			key := operatorRole.Namespace() + "/" + operatorRole.Name()
+			state.Sts.OperatorRoleARNs.Elems[key] = types.String{
+				Value: operatorRole.RoleARN(),
+			}
+		}
+
+		// Failing to get the thumbprint, for example because the OIDC endpoint is
+		// temporarily unreachable, shouldn't remove the value calculated before, as the
+		// OIDC provider that uses it would be replaced:
 		thumbprint, err := getThumbprint(sts.OIDCEndpointURL(), httpClient)
 		if err != nil {
 			logger.Error(ctx, "cannot get thumbprint", err)
-			state.Sts.Thumbprint = types.String{
-				Value: "",
+			if state.Sts.Thumbprint.Unknown || state.Sts.Thumbprint.Null {
+				state.Sts.Thumbprint = types.String{
+					Value: "",
+				}
 			}
 		} else {
 			state.Sts.Thumbprint = types.String{
 				Value: thumbprint,
 			}
 		}
+
 		oidcConfig, ok := sts.GetOidcConfig()
-		if ok && oidcConfig != nil {
+		if ok && oidcConfig != nil && oidcConfig.ID() != "" {
 			state.Sts.OIDCConfigID = types.String{
 				Value: oidcConfig.ID(),
 			}
+		} else {
+			state.Sts.OIDCConfigID = types.String{
+				Null: true,
+			}
 		}
 	}
 
@@ -1584,36 +2211,29 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 		}
 	}
 
-	proxy, ok := object.GetProxy()
-	if ok {
-		httpProxy, ok := proxy.GetHTTPProxy()
-		if ok {
-			state.Proxy.HttpProxy = types.String{
-				Value: httpProxy,
-			}
-		}
-
-		httpsProxy, ok := proxy.GetHTTPSProxy()
-		if ok {
-			state.Proxy.HttpsProxy = types.String{
-				Value: httpsProxy,
-			}
-		}
-
-		noProxy, ok := proxy.GetNoProxy()
-		if ok {
-			state.Proxy.NoProxy = types.String{
-				Value: noProxy,
-			}
-		}
+	// The proxy is rebuilt from what the server returns, so that changes made outside of
+	// Terraform, including the removal of the proxy, are detected:
+	proxyState := &Proxy{
+		HttpProxy:             common.OptionalString(object.Proxy().HTTPProxy()),
+		HttpsProxy:            common.OptionalString(object.Proxy().HTTPSProxy()),
+		NoProxy:               common.OptionalString(object.Proxy().NoProxy()),
+		AdditionalTrustBundle: common.OptionalString(object.AdditionalTrustBundle()),
 	}
-
-	trustBundle, ok := object.GetAdditionalTrustBundle()
-	if ok {
-		state.Proxy.AdditionalTrustBundle = types.String{
-			Value: trustBundle,
+	if state.Proxy != nil && !proxyState.AdditionalTrustBundle.Null {
+		// Keep the value of the configuration if OCM returns it normalized or redacted, so that
+		// there are no spurious differences:
+		current := state.Proxy.AdditionalTrustBundle
+		if !common.IsStringAttributeEmpty(current) &&
+			sameTrustBundle(current.Value, proxyState.AdditionalTrustBundle.Value) {
+			proxyState.AdditionalTrustBundle = current
 		}
 	}
+	if proxyState.HttpProxy.Null && proxyState.HttpsProxy.Null && proxyState.NoProxy.Null &&
+		proxyState.AdditionalTrustBundle.Null {
+		state.Proxy = nil
+	} else {
+		state.Proxy = proxyState
+	}
 
 	machineCIDR, ok := object.Network().GetMachineCIDR()
 	if ok {
@@ -1645,6 +2265,16 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 			Null: true,
 		}
 	}
+	networkType, ok := object.Network().GetType()
+	if ok && networkType != "" {
+		state.NetworkType = types.String{
+			Value: networkType,
+		}
+	} else {
+		state.NetworkType = types.String{
+			Null: true,
+		}
+	}
 	hostPrefix, ok := object.Network().GetHostPrefix()
 	if ok {
 		state.HostPrefix = types.Int64{
@@ -1681,6 +2311,9 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 	state.State = types.String{
 		Value: string(object.State()),
 	}
+	state.CurrentVersion = types.String{
+		Value: clusterCurrentVersion(object),
+	}
 
 	return nil
 }
@@ -1751,6 +2384,10 @@ func (r *ClusterRosaClassicResource) retryClusterNotFoundWithTimeout(attempts in
 	resource *cmv1.ClusterClient) (bool, error) {
 	isNotFound, err := r.waitTillClusterIsNotFoundWithTimeout(ctx, timeout, resource, r.logger)
 	if err != nil {
+		// Retrying doesn't help when OCM reports that the uninstallation failed:
+		if _, ok := err.(*clusterUninstallError); ok {
+			return isNotFound, err
+		}
 		if attempts--; attempts > 0 {
 			time.Sleep(sleep)
 			return r.retryClusterNotFoundWithTimeout(attempts, 2*sleep, ctx, timeout, resource)
@@ -1766,21 +2403,14 @@ func (r *ClusterRosaClassicResource) waitTillClusterIsNotFoundWithTimeout(ctx co
 	timeoutInMinutes := time.Duration(timeout) * time.Minute
 	pollCtx, cancel := context.WithTimeout(ctx, timeoutInMinutes)
 	defer cancel()
-	_, err := resource.Poll().
-		Interval(pollingIntervalInMinutes * time.Minute).
-		Status(http.StatusNotFound).
-		StartContext(pollCtx)
-	sdkErr, ok := err.(*ocm_errors.Error)
-	if ok && sdkErr.Status() == http.StatusNotFound {
-		logger.Info(ctx, "Cluster was removed")
-		return true, nil
-	}
+	err := pollClusterTillNotFound(pollCtx, resource, r.pollInterval)
 	if err != nil {
 		logger.Error(ctx, "Can't poll cluster deletion")
 		return false, err
 	}
 
-	return false, nil
+	logger.Info(ctx, "Cluster was removed")
+	return true, nil
 }
 func proxyValidators() []tfsdk.AttributeValidator {
 	return []tfsdk.AttributeValidator{
@@ -1851,9 +2481,49 @@ func propertiesValidators() []tfsdk.AttributeValidator {
 							resp.Diagnostics.AddError(errHead, errDesc)
 							return
 						}
+						if len(k) > maxPropertyKeyLength || !propertyKeyRE.MatchString(k) {
+							errHead := "Invalid property key."
+							errDesc := fmt.Sprintf("Property key '%s' must consist of no more than %d alphanumeric "+
+								"characters, '-', '_' or '.', start with a letter, and end with an alphanumeric character.",
+								k, maxPropertyKeyLength)
+							resp.Diagnostics.AddError(errHead, errDesc)
+							return
+						}
 					}
 				}
 			},
 		},
 	}
 }
+
+func clusterNameValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate cluster name",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				name := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, name)
+				if diag.HasError() || name.Unknown || name.Null {
+					// No attribute to validate
+					return
+				}
+				err := validateClusterName(name.Value)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid cluster name.", err.Error())
+				}
+			},
+		},
+	}
+}
+
+// validateClusterName checks that the given name satisfies the requirements of OCM.
+func validateClusterName(name string) error {
+	if len(name) > maxClusterNameLength || !clusterNameRE.MatchString(name) {
+		return fmt.Errorf(
+			"Cluster name '%s' must consist of no more than %d lowercase alphanumeric characters or '-', "+
+				"start with a letter, and end with an alphanumeric character.",
+			name, maxClusterNameLength,
+		)
+	}
+	return nil
+}
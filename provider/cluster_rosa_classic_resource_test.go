@@ -23,7 +23,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -173,7 +177,7 @@ var _ = Describe("Rosa Classic Sts cluster", func() {
 	Context("createClassicClusterObject", func() {
 		It("Creates a cluster with correct field values", func() {
 			clusterState := generateBasicRosaClassicClusterState()
-			rosaClusterObject, err := createClassicClusterObject(context.Background(), clusterState, &logging.StdLogger{}, diag.Diagnostics{})
+			rosaClusterObject, err := createClassicClusterObject(context.Background(), clusterState, nil, &logging.StdLogger{}, diag.Diagnostics{})
 			Expect(err).To(BeNil())
 
 			Expect(rosaClusterObject.Name()).To(Equal(clusterName))
@@ -207,21 +211,21 @@ var _ = Describe("Rosa Classic Sts cluster", func() {
 	It("Throws an error when version format is invalid", func() {
 		clusterState := generateBasicRosaClassicClusterState()
 		clusterState.Version.Value = "a.4.1"
-		_, err := createClassicClusterObject(context.Background(), clusterState, &logging.StdLogger{}, diag.Diagnostics{})
+		_, err := createClassicClusterObject(context.Background(), clusterState, nil, &logging.StdLogger{}, diag.Diagnostics{})
 		Expect(err).ToNot(BeNil())
 	})
 
 	It("Throws an error when version is unsupported", func() {
 		clusterState := generateBasicRosaClassicClusterState()
 		clusterState.Version.Value = "4.1.0"
-		_, err := createClassicClusterObject(context.Background(), clusterState, &logging.StdLogger{}, diag.Diagnostics{})
+		_, err := createClassicClusterObject(context.Background(), clusterState, nil, &logging.StdLogger{}, diag.Diagnostics{})
 		Expect(err).ToNot(BeNil())
 	})
 
 	It("appends the non-default channel name to the requested version", func() {
 		clusterState := generateBasicRosaClassicClusterState()
 		clusterState.ChannelGroup.Value = "somechannel"
-		rosaClusterObject, err := createClassicClusterObject(context.Background(), clusterState, &logging.StdLogger{}, diag.Diagnostics{})
+		rosaClusterObject, err := createClassicClusterObject(context.Background(), clusterState, nil, &logging.StdLogger{}, diag.Diagnostics{})
 		Expect(err).To(BeNil())
 
 		version, ok := rosaClusterObject.Version().GetID()
@@ -232,6 +236,36 @@ var _ = Describe("Rosa Classic Sts cluster", func() {
 		Expect(channel).To(Equal("somechannel"))
 	})
 
+	It("Sets the expiration timestamp of the cluster", func() {
+		clusterState := generateBasicRosaClassicClusterState()
+		clusterState.ExpirationTimestamp = types.String{
+			Value: "2023-08-01T14:00:00+02:00",
+		}
+		rosaClusterObject, err := createClassicClusterObject(context.Background(), clusterState, nil, &logging.StdLogger{}, diag.Diagnostics{})
+		Expect(err).To(BeNil())
+
+		expiration, ok := rosaClusterObject.GetExpirationTimestamp()
+		Expect(ok).To(BeTrue())
+		Expect(expiration.UTC()).To(Equal(time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)))
+	})
+
+	It("Checks that the network type is supported by the version", func() {
+		clusterState := generateBasicRosaClassicClusterState()
+		Expect(validateNetworkTypeVersion(clusterState, "4.10.1")).To(Succeed())
+
+		clusterState.NetworkType = types.String{
+			Value: networkTypeOVN,
+		}
+		Expect(validateNetworkTypeVersion(clusterState, "4.11.0")).To(Succeed())
+		Expect(validateNetworkTypeVersion(clusterState, "4.10.1")).ToNot(Succeed())
+
+		clusterState.NetworkType = types.String{
+			Value: networkTypeSDN,
+		}
+		Expect(validateNetworkTypeVersion(clusterState, "4.14.9")).To(Succeed())
+		Expect(validateNetworkTypeVersion(clusterState, "4.15.0")).ToNot(Succeed())
+	})
+
 	Context("populateRosaClassicClusterState", func() {
 		It("Converts correctly a Cluster object into a ClusterRosaClassicState", func() {
 			clusterState := &ClusterRosaClassicState{}
@@ -280,6 +314,43 @@ var _ = Describe("Rosa Classic Sts cluster", func() {
 			err = populateRosaClassicClusterState(context.Background(), clusterObject, clusterState, &logging.StdLogger{}, mockHttpClient)
 			Expect(err).To(BeNil())
 			Expect(clusterState.Sts.OIDCEndpointURL.Value).To(Equal("nonce.com"))
+			Expect(clusterState.Sts.OIDCIssuerURL.Value).To(Equal("https://nonce.com"))
+		})
+
+		It("Keeps the configured operator role prefix", func() {
+			clusterState := generateBasicRosaClassicClusterState()
+			clusterState.Sts.OperatorRolePrefix = types.String{
+				Value: "terraform-operator",
+			}
+			clusterJson := generateBasicRosaClassicClusterJson()
+			clusterJson["aws"].(map[string]interface{})["sts"].(map[string]interface{})["operator_role_prefix"] = "terraform-operator-x1y2"
+			clusterJsonString, err := json.Marshal(clusterJson)
+			Expect(err).To(BeNil())
+
+			clusterObject, err := cmv1.UnmarshalCluster(clusterJsonString)
+			Expect(err).To(BeNil())
+
+			err = populateRosaClassicClusterState(context.Background(), clusterObject, clusterState, &logging.StdLogger{}, mockHttpClient)
+			Expect(err).To(BeNil())
+			Expect(clusterState.Sts.OperatorRolePrefix.Value).To(Equal("terraform-operator"))
+		})
+
+		It("Takes the operator role prefix from the server when it isn't known", func() {
+			clusterState := generateBasicRosaClassicClusterState()
+			clusterState.Sts.OperatorRolePrefix = types.String{
+				Null: true,
+			}
+			clusterJson := generateBasicRosaClassicClusterJson()
+			clusterJson["aws"].(map[string]interface{})["sts"].(map[string]interface{})["operator_role_prefix"] = "terraform-operator-x1y2"
+			clusterJsonString, err := json.Marshal(clusterJson)
+			Expect(err).To(BeNil())
+
+			clusterObject, err := cmv1.UnmarshalCluster(clusterJsonString)
+			Expect(err).To(BeNil())
+
+			err = populateRosaClassicClusterState(context.Background(), clusterObject, clusterState, &logging.StdLogger{}, mockHttpClient)
+			Expect(err).To(BeNil())
+			Expect(clusterState.Sts.OperatorRolePrefix.Value).To(Equal("terraform-operator-x1y2"))
 		})
 
 		It("Throws an error when oidc_endpoint_url is an invalid url", func() {
@@ -314,4 +385,46 @@ var _ = Describe("Rosa Classic Sts cluster", func() {
 		})
 	})
 
+	Context("account role version validation", func() {
+		It("Detects roles that use managed policies", func() {
+			tags := []*iam.Tag{
+				{Key: aws.String(tagsOpenShiftVersion), Value: aws.String("4.10")},
+				{Key: aws.String(tagsManagedPolicies), Value: aws.String("true")},
+			}
+			Expect(hasManagedPoliciesTag(tags)).To(BeTrue())
+			Expect(hasManagedPoliciesTag(tags[:1])).To(BeFalse())
+		})
+		It("Lists all the incompatible roles in a table", func() {
+			table := formatIncompatibleAccountRoles([]incompatibleAccountRole{
+				{kind: "Installer", arn: "arn:aws:iam::123456789012:role/Installer", version: "4.10"},
+				{kind: "Worker", arn: "arn:aws:iam::123456789012:role/Worker"},
+			}, "4.12")
+			lines := strings.Split(strings.TrimSpace(table), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(MatchRegexp(`^ROLE\s+ARN\s+ROLE VERSION\s+REQUIRED VERSION$`))
+			Expect(lines[1]).To(MatchRegexp(`^Installer\s+arn:aws:iam::123456789012:role/Installer\s+4\.10\s+4\.12$`))
+			Expect(lines[2]).To(MatchRegexp(`^Worker\s+arn:aws:iam::123456789012:role/Worker\s+none\s+4\.12$`))
+		})
+	})
+
+	Context("cluster name validation", func() {
+		It("Accepts valid names", func() {
+			Expect(validateClusterName("my-cluster")).To(Succeed())
+			Expect(validateClusterName("a")).To(Succeed())
+			Expect(validateClusterName("abcdefghijklm15")).To(Succeed())
+		})
+		It("Rejects names that are too long", func() {
+			err := validateClusterName("abcdefghijklmn16")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no more than 15"))
+		})
+		It("Rejects names that aren't valid DNS labels", func() {
+			Expect(validateClusterName("My-cluster")).ToNot(Succeed())
+			Expect(validateClusterName("1cluster")).ToNot(Succeed())
+			Expect(validateClusterName("cluster-")).ToNot(Succeed())
+			Expect(validateClusterName("my_cluster")).ToNot(Succeed())
+			Expect(validateClusterName("")).ToNot(Succeed())
+		})
+	})
+
 })
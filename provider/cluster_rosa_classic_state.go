@@ -25,6 +25,7 @@ type ClusterRosaClassicState struct {
 	AWSAccountID              types.String `tfsdk:"aws_account_id"`
 	AWSSubnetIDs              types.List   `tfsdk:"aws_subnet_ids"`
 	AWSPrivateLink            types.Bool   `tfsdk:"aws_private_link"`
+	AWSPrivateLinkPrincipals  types.List   `tfsdk:"aws_private_link_principals"`
 	Sts                       *Sts         `tfsdk:"sts"`
 	CCSEnabled                types.Bool   `tfsdk:"ccs_enabled"`
 	EtcdEncryption            types.Bool   `tfsdk:"etcd_encryption"`
@@ -38,6 +39,7 @@ type ClusterRosaClassicState struct {
 	Replicas                  types.Int64  `tfsdk:"replicas"`
 	ConsoleURL                types.String `tfsdk:"console_url"`
 	Domain                    types.String `tfsdk:"domain"`
+	InfraID                   types.String `tfsdk:"infra_id"`
 	HostPrefix                types.Int64  `tfsdk:"host_prefix"`
 	ID                        types.String `tfsdk:"id"`
 	FIPS                      types.Bool   `tfsdk:"fips"`
@@ -47,8 +49,10 @@ type ClusterRosaClassicState struct {
 	MultiAZ                   types.Bool   `tfsdk:"multi_az"`
 	DisableWorkloadMonitoring types.Bool   `tfsdk:"disable_workload_monitoring"`
 	DisableSCPChecks          types.Bool   `tfsdk:"disable_scp_checks"`
+	ExpirationTimestamp       types.String `tfsdk:"expiration_timestamp"`
 	AvailabilityZones         types.List   `tfsdk:"availability_zones"`
 	Name                      types.String `tfsdk:"name"`
+	NetworkType               types.String `tfsdk:"network_type"`
 	PodCIDR                   types.String `tfsdk:"pod_cidr"`
 	Properties                types.Map    `tfsdk:"properties"`
 	OCMProperties             types.Map    `tfsdk:"ocm_properties"`
@@ -56,20 +60,27 @@ type ClusterRosaClassicState struct {
 	ServiceCIDR               types.String `tfsdk:"service_cidr"`
 	Proxy                     *Proxy       `tfsdk:"proxy"`
 	State                     types.String `tfsdk:"state"`
+	CurrentVersion            types.String `tfsdk:"current_version"`
 	Version                   types.String `tfsdk:"version"`
 	DisableWaitingInDestroy   types.Bool   `tfsdk:"disable_waiting_in_destroy"`
 	DestroyTimeout            types.Int64  `tfsdk:"destroy_timeout"`
 	Ec2MetadataHttpTokens     types.String `tfsdk:"ec2_metadata_http_tokens"`
+	BillingModel              types.String `tfsdk:"billing_model"`
+	PreflightChecks           types.Bool   `tfsdk:"preflight_checks"`
+	ValidationOnly            types.Bool   `tfsdk:"validation_only"`
+	DryRun                    types.Bool   `tfsdk:"dry_run"`
 }
 
 type Sts struct {
 	OIDCEndpointURL    types.String    `tfsdk:"oidc_endpoint_url"`
+	OIDCIssuerURL      types.String    `tfsdk:"oidc_issuer_url"`
 	OIDCConfigID       types.String    `tfsdk:"oidc_config_id"`
 	Thumbprint         types.String    `tfsdk:"thumbprint"`
 	RoleARN            types.String    `tfsdk:"role_arn"`
 	SupportRoleArn     types.String    `tfsdk:"support_role_arn"`
 	InstanceIAMRoles   InstanceIAMRole `tfsdk:"instance_iam_roles"`
 	OperatorRolePrefix types.String    `tfsdk:"operator_role_prefix"`
+	OperatorRoleARNs   types.Map       `tfsdk:"operator_role_arns"`
 }
 
 type InstanceIAMRole struct {
@@ -21,32 +21,40 @@ import (
 )
 
 type ClusterState struct {
-	APIURL             types.String `tfsdk:"api_url"`
-	AWSAccessKeyID     types.String `tfsdk:"aws_access_key_id"`
-	AWSAccountID       types.String `tfsdk:"aws_account_id"`
-	AWSSecretAccessKey types.String `tfsdk:"aws_secret_access_key"`
-	AWSSubnetIDs       types.List   `tfsdk:"aws_subnet_ids"`
-	AWSPrivateLink     types.Bool   `tfsdk:"aws_private_link"`
-	CCSEnabled         types.Bool   `tfsdk:"ccs_enabled"`
-	CloudProvider      types.String `tfsdk:"cloud_provider"`
-	CloudRegion        types.String `tfsdk:"cloud_region"`
-	ComputeMachineType types.String `tfsdk:"compute_machine_type"`
-	ComputeNodes       types.Int64  `tfsdk:"compute_nodes"`
-	ConsoleURL         types.String `tfsdk:"console_url"`
-	HostPrefix         types.Int64  `tfsdk:"host_prefix"`
-	ID                 types.String `tfsdk:"id"`
-	Product            types.String `tfsdk:"product"`
-	MachineCIDR        types.String `tfsdk:"machine_cidr"`
-	MultiAZ            types.Bool   `tfsdk:"multi_az"`
-	AvailabilityZones  types.List   `tfsdk:"availability_zones"`
-	Name               types.String `tfsdk:"name"`
-	PodCIDR            types.String `tfsdk:"pod_cidr"`
-	Properties         types.Map    `tfsdk:"properties"`
-	ServiceCIDR        types.String `tfsdk:"service_cidr"`
-	Proxy              *Proxy       `tfsdk:"proxy"`
-	State              types.String `tfsdk:"state"`
-	Version            types.String `tfsdk:"version"`
-	Wait               types.Bool   `tfsdk:"wait"`
+	APIURL              types.String `tfsdk:"api_url"`
+	AWSAccessKeyID      types.String `tfsdk:"aws_access_key_id"`
+	AWSAccountID        types.String `tfsdk:"aws_account_id"`
+	AWSSecretAccessKey  types.String `tfsdk:"aws_secret_access_key"`
+	AWSSubnetIDs        types.List   `tfsdk:"aws_subnet_ids"`
+	AWSPrivateLink      types.Bool   `tfsdk:"aws_private_link"`
+	BillingModel        types.String `tfsdk:"billing_model"`
+	CCSEnabled          types.Bool   `tfsdk:"ccs_enabled"`
+	CloudProvider       types.String `tfsdk:"cloud_provider"`
+	CloudRegion         types.String `tfsdk:"cloud_region"`
+	ComputeMachineType  types.String `tfsdk:"compute_machine_type"`
+	ComputeNodes        types.Int64  `tfsdk:"compute_nodes"`
+	ConsoleURL          types.String `tfsdk:"console_url"`
+	Domain              types.String `tfsdk:"domain"`
+	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	LoadBalancerQuota   types.Int64  `tfsdk:"load_balancer_quota"`
+	InfraID             types.String `tfsdk:"infra_id"`
+	HostPrefix          types.Int64  `tfsdk:"host_prefix"`
+	ID                  types.String `tfsdk:"id"`
+	Product             types.String `tfsdk:"product"`
+	MachineCIDR         types.String `tfsdk:"machine_cidr"`
+	MultiAZ             types.Bool   `tfsdk:"multi_az"`
+	AvailabilityZones   types.List   `tfsdk:"availability_zones"`
+	Name                types.String `tfsdk:"name"`
+	PodCIDR             types.String `tfsdk:"pod_cidr"`
+	Properties          types.Map    `tfsdk:"properties"`
+	ServiceCIDR         types.String `tfsdk:"service_cidr"`
+	Proxy               *Proxy       `tfsdk:"proxy"`
+	StorageQuota        types.Int64  `tfsdk:"storage_quota"`
+	State               types.String `tfsdk:"state"`
+	CurrentVersion      types.String `tfsdk:"current_version"`
+	Version             types.String `tfsdk:"version"`
+	Wait                types.Bool   `tfsdk:"wait"`
+	CleanupOnFailure    types.Bool   `tfsdk:"cleanup_on_failure"`
 }
 
 type Proxy struct {
@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+type ClusterSubscriptionDataSourceType struct {
+}
+
+type ClusterSubscriptionDataSource struct {
+	logger        logging.Logger
+	clusters      *cmv1.ClustersClient
+	subscriptions *amv1.SubscriptionsClient
+	refresh       *refreshCache
+}
+
+func (t *ClusterSubscriptionDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Subscription of a cluster, which describes how the cluster is " +
+			"supported and billed.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"id": {
+				Description: "Unique identifier of the subscription.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the subscription, for example 'Active' or " +
+					"'Deprovisioned'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"plan": {
+				Description: "Identifier of the plan of the subscription, for " +
+					"example 'MOA' for ROSA clusters.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"support_level": {
+				Description: "Support level of the subscription, for example " +
+					"'Premium', 'Standard', 'Self-Support' or 'Eval'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"service_level": {
+				Description: "Service level of the subscription, 'L1-L3' or 'L3-only'.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"usage": {
+				Description: "Usage of the subscription, for example 'Production' " +
+					"or 'Development/Test'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"system_units": {
+				Description: "Units used to calculate the cost of the subscription, " +
+					"'Cores/vCPU' or 'Sockets'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"managed": {
+				Description: "Indicates if the cluster is a managed cluster.",
+				Type:        types.BoolType,
+				Computed:    true,
+			},
+			"cluster_billing_model": {
+				Description: "Billing model of the cluster, for example 'standard' " +
+					"or 'marketplace-aws'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"billing_marketplace_account": {
+				Description: "Cloud account that is billed through the marketplace, " +
+					"empty when the cluster isn't billed through a marketplace.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"organization_id": {
+				Description: "Identifier of the organization that owns the subscription.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"creator": {
+				Description: "User name of the account that created the cluster.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"creator_email": {
+				Description: "Email address of the account that created the cluster.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"display_name": {
+				Description: "Name of the cluster displayed in the console.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"external_cluster_id": {
+				Description: "External identifier of the cluster, the one reported " +
+					"by the cluster itself.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"created_at": {
+				Description: "Date and time when the subscription was created, in " +
+					"RFC 3339 format.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *ClusterSubscriptionDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &ClusterSubscriptionDataSource{
+		logger:        parent.logger,
+		clusters:      parent.connection.ClustersMgmt().V1().Clusters(),
+		subscriptions: parent.connection.AccountsMgmt().V1().Subscriptions(),
+		refresh:       parent.refresh,
+	}
+	return
+}
+
+func (s *ClusterSubscriptionDataSource) Read(ctx context.Context,
+	request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &ClusterSubscriptionState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the cluster, as it contains the reference to the subscription:
+	cluster, err := s.refresh.getCluster(ctx, s.clusters, state.Cluster.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find cluster",
+			fmt.Sprintf(
+				"Can't find cluster with identifier '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	subscriptionID := cluster.Subscription().ID()
+	if subscriptionID == "" {
+		response.Diagnostics.AddError(
+			"Can't find subscription",
+			fmt.Sprintf(
+				"Cluster '%s' doesn't have a subscription yet",
+				state.Cluster.Value,
+			),
+		)
+		return
+	}
+
+	// Get the subscription, including the details of the account that created it:
+	subscription, err := s.subscriptions.Subscription(subscriptionID).Get().
+		Parameter("fetchAccounts", true).
+		SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find subscription",
+			fmt.Sprintf(
+				"Can't find subscription '%s' of cluster '%s': %v",
+				subscriptionID, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Save the state:
+	populateClusterSubscriptionState(subscription.Body(), state)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// populateClusterSubscriptionState copies the data from the API object to the Terraform state.
+func populateClusterSubscriptionState(object *amv1.Subscription, state *ClusterSubscriptionState) {
+	state.ID = types.String{
+		Value: object.ID(),
+	}
+	state.Status = types.String{
+		Value: object.Status(),
+	}
+	state.Plan = types.String{
+		Value: object.Plan().ID(),
+	}
+	state.SupportLevel = types.String{
+		Value: object.SupportLevel(),
+	}
+	state.ServiceLevel = types.String{
+		Value: object.ServiceLevel(),
+	}
+	state.Usage = types.String{
+		Value: object.Usage(),
+	}
+	state.SystemUnits = types.String{
+		Value: object.SystemUnits(),
+	}
+	state.Managed = types.Bool{
+		Value: object.Managed(),
+	}
+	state.ClusterBillingModel = types.String{
+		Value: string(object.ClusterBillingModel()),
+	}
+	state.BillingMarketplaceAccount = types.String{
+		Value: object.BillingMarketplaceAccount(),
+	}
+	state.OrganizationID = types.String{
+		Value: object.OrganizationID(),
+	}
+	state.Creator = types.String{
+		Value: object.Creator().Username(),
+	}
+	state.CreatorEmail = types.String{
+		Value: object.Creator().Email(),
+	}
+	state.DisplayName = types.String{
+		Value: object.DisplayName(),
+	}
+	state.ExternalClusterID = types.String{
+		Value: object.ExternalClusterID(),
+	}
+	state.CreatedAt = types.String{
+		Null: true,
+	}
+	createdAt, ok := object.GetCreatedAt()
+	if ok {
+		state.CreatedAt = types.String{
+			Value: createdAt.UTC().Format(time.RFC3339),
+		}
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type ClusterSubscriptionState struct {
+	Cluster                   types.String `tfsdk:"cluster"`
+	ID                        types.String `tfsdk:"id"`
+	Status                    types.String `tfsdk:"status"`
+	Plan                      types.String `tfsdk:"plan"`
+	SupportLevel              types.String `tfsdk:"support_level"`
+	ServiceLevel              types.String `tfsdk:"service_level"`
+	Usage                     types.String `tfsdk:"usage"`
+	SystemUnits               types.String `tfsdk:"system_units"`
+	Managed                   types.Bool   `tfsdk:"managed"`
+	ClusterBillingModel       types.String `tfsdk:"cluster_billing_model"`
+	BillingMarketplaceAccount types.String `tfsdk:"billing_marketplace_account"`
+	OrganizationID            types.String `tfsdk:"organization_id"`
+	Creator                   types.String `tfsdk:"creator"`
+	CreatorEmail              types.String `tfsdk:"creator_email"`
+	DisplayName               types.String `tfsdk:"display_name"`
+	ExternalClusterID         types.String `tfsdk:"external_cluster_id"`
+	CreatedAt                 types.String `tfsdk:"created_at"`
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -20,15 +21,17 @@ type ClusterWaiterResourceType struct {
 }
 
 type ClusterWaiterResource struct {
-	logger     logging.Logger
-	collection *cmv1.ClustersClient
+	logger            logging.Logger
+	collection        *cmv1.ClustersClient
+	pollInterval      time.Duration
+	installLogLines   int
+	endpointTransport http.RoundTripper
 }
 
 const (
 	defaultTimeoutInMinutes   = int64(60)
 	nonPositiveTimeoutSummary = "Can't poll cluster state with a non-positive timeout"
 	nonPositiveTimeoutFormat  = "Can't poll state of cluster with identifier '%s', the timeout that was set is not a positive number"
-	pollingIntervalInMinutes  = 2
 )
 
 func (t *ClusterWaiterResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
@@ -47,6 +50,20 @@ func (t *ClusterWaiterResourceType) GetSchema(ctx context.Context) (result tfsdk
 				Type:     types.Int64Type,
 				Optional: true,
 			},
+			"wait_for_compute_nodes": {
+				Description: "Wait also till the compute nodes requested for the cluster " +
+					"have joined it, as the cluster is reported ready before that happens. " +
+					"When autoscaling it waits for the minimum number of replicas.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"wait_for_endpoints": {
+				Description: "Wait also till the OIDC discovery document of the cluster " +
+					"and the console can be retrieved, which requires the ingress of the " +
+					"cluster to be working.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
 			"ready": {
 				Description: "Whether the cluster is ready",
 				Type:        types.BoolType,
@@ -67,8 +84,11 @@ func (t *ClusterWaiterResourceType) NewResource(ctx context.Context,
 
 	// Create the resource:
 	result = &ClusterWaiterResource{
-		logger:     parent.logger,
-		collection: collection,
+		logger:            parent.logger,
+		collection:        collection,
+		pollInterval:      parent.pollInterval,
+		installLogLines:   parent.installLogLines,
+		endpointTransport: parent.endpointTransport,
 	}
 	return
 }
@@ -96,22 +116,36 @@ func (r *ClusterWaiterResource) Create(ctx context.Context,
 		}
 	}
 
+	// The timeout applies to the whole wait, including the additional conditions that are checked
+	// once the cluster is ready:
+	waitCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Minute)
+	defer cancel()
+
 	// Wait till the cluster is ready:
-	object, err := r.retryClusterReadiness(3, 30*time.Second, state.Cluster.Value, ctx, timeout)
+	object, operationID, err := r.retryClusterReadiness(3, 30*time.Second, state.Cluster.Value, waitCtx, timeout)
 	if err != nil {
 
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't poll cluster state",
 			fmt.Sprintf(
 				"Can't poll state of cluster with identifier '%s': %v",
 				state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
 	isClusterReady := false
 	if object.State() == cmv1.ClusterStateReady {
-		isClusterReady = true
+		isClusterReady = r.waitForConditions(waitCtx, state, &response.Diagnostics)
+	}
+	if object.State() == cmv1.ClusterStateError {
+		response.Diagnostics.AddWarning(
+			provisionErrorSummary,
+			provisionErrorDetail(object, operationID)+
+				installLogDetail(ctx, r.collection.Cluster(object.ID()), r.installLogLines),
+		)
 	}
 
 	state.Ready = types.Bool{
@@ -143,41 +177,92 @@ func (r *ClusterWaiterResource) ImportState(ctx context.Context, request tfsdk.I
 	// Do Nothing
 }
 
-func (r *ClusterWaiterResource) isClusterReady(clusterId string, ctx context.Context, timeout int64) (*cmv1.Cluster, error) {
+func (r *ClusterWaiterResource) isClusterReady(clusterId string, ctx context.Context, timeout int64) (*cmv1.Cluster, string, error) {
 	resource := r.collection.Cluster(clusterId)
-	var object *cmv1.Cluster
 	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Minute)
 	defer cancel()
-	_, err := resource.Poll().
-		Interval(pollingIntervalInMinutes * time.Minute).
-		Predicate(func(getClusterResponse *cmv1.ClusterGetResponse) bool {
-			object = getClusterResponse.Body()
-			r.logger.Debug(ctx, "cluster state is %s", object.State())
-			switch object.State() {
-			case cmv1.ClusterStateReady,
-				cmv1.ClusterStateError:
-				return true
-			}
-			return false
-		}).
-		StartContext(pollCtx)
+	object, operationID, err := pollCluster(pollCtx, resource, r.pollInterval, func(object *cmv1.Cluster) bool {
+		r.logger.Debug(ctx, "cluster state is %s", object.State())
+		switch object.State() {
+		case cmv1.ClusterStateReady,
+			cmv1.ClusterStateError:
+			return true
+		}
+		return false
+	})
 	if err != nil {
 		r.logger.Error(ctx, "Can't  poll cluster state")
-		return nil, err
+		return nil, "", err
+	}
+
+	return object, operationID, err
+}
+
+// waitForConditions waits, once the cluster is ready, for the additional conditions requested in
+// the state. It returns false, adding a warning, if they aren't met before the deadline of the
+// given context.
+func (r *ClusterWaiterResource) waitForConditions(waitCtx context.Context, state *ClusterWaiterState,
+	diags *diag.Diagnostics) bool {
+	resource := r.collection.Cluster(state.Cluster.Value)
+
+	if !state.WaitForComputeNodes.Unknown && !state.WaitForComputeNodes.Null &&
+		state.WaitForComputeNodes.Value {
+		_, _, err := pollCluster(waitCtx, resource, r.pollInterval, computeNodesReady)
+		if err != nil {
+			diags.AddWarning(
+				"Compute nodes aren't ready",
+				fmt.Sprintf(
+					"Compute nodes of cluster with identifier '%s' haven't joined it: %v",
+					state.Cluster.Value, err,
+				),
+			)
+			return false
+		}
+	}
+
+	if !state.WaitForEndpoints.Unknown && !state.WaitForEndpoints.Null &&
+		state.WaitForEndpoints.Value {
+		client := newEndpointClient(r.endpointTransport)
+		var checkErr error
+		err := pollWithBackoff(waitCtx, r.pollInterval, func(ctx context.Context) (bool, error) {
+			get, err := resource.Get().SendContext(ctx)
+			if err != nil {
+				return false, err
+			}
+			checkErr = checkEndpoints(ctx, client, get.Body())
+			if checkErr != nil {
+				r.logger.Debug(ctx, "endpoints of cluster '%s' aren't reachable: %v", state.Cluster.Value, checkErr)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			if checkErr != nil {
+				err = checkErr
+			}
+			diags.AddWarning(
+				"Cluster endpoints aren't reachable",
+				fmt.Sprintf(
+					"Endpoints of cluster with identifier '%s' aren't reachable: %v",
+					state.Cluster.Value, err,
+				),
+			)
+			return false
+		}
 	}
 
-	return object, err
+	return true
 }
 
-func (r *ClusterWaiterResource) retryClusterReadiness(attempts int, sleep time.Duration, clusterId string, ctx context.Context, timeout int64) (*cmv1.Cluster, error) {
-	object, err := r.isClusterReady(clusterId, ctx, timeout)
+func (r *ClusterWaiterResource) retryClusterReadiness(attempts int, sleep time.Duration, clusterId string, ctx context.Context, timeout int64) (*cmv1.Cluster, string, error) {
+	object, operationID, err := r.isClusterReady(clusterId, ctx, timeout)
 	if err != nil {
 		if attempts--; attempts > 0 {
 			time.Sleep(sleep)
 			return r.retryClusterReadiness(attempts, 2*sleep, clusterId, ctx, timeout)
 		}
-		return object, err
+		return object, operationID, err
 	}
 
-	return object, nil
+	return object, operationID, nil
 }
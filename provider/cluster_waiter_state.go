@@ -5,7 +5,9 @@ import (
 )
 
 type ClusterWaiterState struct {
-	Cluster types.String `tfsdk:"cluster"`
-	Ready   types.Bool   `tfsdk:"ready"`
-	Timeout types.Int64  `tfsdk:"timeout"`
+	Cluster             types.String `tfsdk:"cluster"`
+	Ready               types.Bool   `tfsdk:"ready"`
+	Timeout             types.Int64  `tfsdk:"timeout"`
+	WaitForComputeNodes types.Bool   `tfsdk:"wait_for_compute_nodes"`
+	WaitForEndpoints    types.Bool   `tfsdk:"wait_for_endpoints"`
 }
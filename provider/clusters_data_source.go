@@ -0,0 +1,330 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+type ClustersDataSourceType struct {
+}
+
+type ClustersDataSource struct {
+	logger     logging.Logger
+	collection *cmv1.ClustersClient
+}
+
+func (t *ClustersDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "List of the clusters that the current account can see. All the " +
+			"criteria are combined, only the clusters that match all of them are " +
+			"returned.",
+		Attributes: map[string]tfsdk.Attribute{
+			"search": {
+				Description: "Search criteria in the syntax of the OCM API, for " +
+					"example `cloud_provider.id = 'aws' and multi_az = 't'`.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"name": {
+				Description: "Pattern that the names of the clusters must match, " +
+					"where `%` matches any sequence of characters, for example " +
+					"`prod-%`.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"product": {
+				Description: "Product of the clusters, for example 'rosa' or 'osd'.",
+				Type:        types.StringType,
+				Optional:    true,
+			},
+			"state": {
+				Description: "State of the clusters, for example 'ready'.",
+				Type:        types.StringType,
+				Optional:    true,
+			},
+			"properties": {
+				Description: "User defined properties that the clusters must have, " +
+					"with the given values.",
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+			},
+			"order": {
+				Description: "Order criteria, for example `name asc`.",
+				Type:        types.StringType,
+				Optional:    true,
+			},
+			"items": {
+				Description: "Clusters that match the criteria.",
+				Attributes: tfsdk.ListNestedAttributes(
+					t.itemAttributes(),
+					tfsdk.ListNestedAttributesOptions{},
+				),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *ClustersDataSourceType) itemAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			Description: "Unique identifier of the cluster.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the cluster.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"external_id": {
+			Description: "External identifier of the cluster, the one reported by " +
+				"the cluster itself.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"product": {
+			Description: "Product of the cluster, for example 'rosa'.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"cloud_provider": {
+			Description: "Cloud provider identifier, for example 'aws'.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"cloud_region": {
+			Description: "Cloud region identifier, for example 'us-east-1'.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"multi_az": {
+			Description: "Indicates if the cluster is deployed to multiple " +
+				"availability zones.",
+			Type:     types.BoolType,
+			Computed: true,
+		},
+		"state": {
+			Description: "State of the cluster.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"current_version": {
+			Description: "OpenShift version that the cluster runs, for example '4.12.3'.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"api_url": {
+			Description: "URL of the API server.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"console_url": {
+			Description: "URL of the console.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"properties": {
+			Description: "User defined properties.",
+			Type: types.MapType{
+				ElemType: types.StringType,
+			},
+			Computed: true,
+		},
+	}
+}
+
+func (t *ClustersDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &ClustersDataSource{
+		logger:     parent.logger,
+		collection: parent.connection.ClustersMgmt().V1().Clusters(),
+	}
+	return
+}
+
+// quoteSearchValue quotes the given text so that it can be used as a literal in a search query,
+// doubling the single quotes that it contains.
+func quoteSearchValue(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+// clustersSearch combines the criteria of the data source into a single search query. The
+// properties are sorted so that the query is always the same for the same configuration.
+func clustersSearch(state *ClustersState) string {
+	var filters []string
+	if !common.IsStringAttributeEmpty(state.Search) {
+		filters = append(filters, "("+state.Search.Value+")")
+	}
+	if !common.IsStringAttributeEmpty(state.Name) {
+		filters = append(filters, "name like "+quoteSearchValue(state.Name.Value))
+	}
+	if !common.IsStringAttributeEmpty(state.Product) {
+		filters = append(filters, "product.id = "+quoteSearchValue(state.Product.Value))
+	}
+	if !common.IsStringAttributeEmpty(state.State) {
+		filters = append(filters, "state = "+quoteSearchValue(state.State.Value))
+	}
+	if !state.Properties.Unknown && !state.Properties.Null {
+		keys := make([]string, 0, len(state.Properties.Elems))
+		for key := range state.Properties.Elems {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := state.Properties.Elems[key].(types.String).Value
+			filters = append(filters, fmt.Sprintf(
+				"properties.%s = %s",
+				key, quoteSearchValue(value),
+			))
+		}
+	}
+	return strings.Join(filters, " and ")
+}
+
+func (s *ClustersDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &ClustersState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the clusters:
+	search := clustersSearch(state)
+	order := ""
+	if !common.IsStringAttributeEmpty(state.Order) {
+		order = state.Order.Value
+	}
+	clusters, err := s.listClusters(ctx, search, order)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't list clusters",
+			fmt.Sprintf(
+				"Can't list clusters with search criteria '%s': %v",
+				search, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state:
+	state.Items = make([]*ClustersItemState, len(clusters))
+	for i, cluster := range clusters {
+		item := &ClustersItemState{
+			ID: types.String{
+				Value: cluster.ID(),
+			},
+			Name: types.String{
+				Value: cluster.Name(),
+			},
+			ExternalID: types.String{
+				Value: cluster.ExternalID(),
+			},
+			Product: types.String{
+				Value: cluster.Product().ID(),
+			},
+			CloudProvider: types.String{
+				Value: cluster.CloudProvider().ID(),
+			},
+			CloudRegion: types.String{
+				Value: cluster.Region().ID(),
+			},
+			MultiAZ: types.Bool{
+				Value: cluster.MultiAZ(),
+			},
+			State: types.String{
+				Value: string(cluster.State()),
+			},
+			CurrentVersion: types.String{
+				Value: clusterCurrentVersion(cluster),
+			},
+			APIURL: types.String{
+				Value: cluster.API().URL(),
+			},
+			ConsoleURL: types.String{
+				Value: cluster.Console().URL(),
+			},
+			Properties: types.Map{
+				ElemType: types.StringType,
+				Elems:    map[string]attr.Value{},
+			},
+		}
+		for k, v := range cluster.Properties() {
+			item.Properties.Elems[k] = types.String{
+				Value: v,
+			}
+		}
+		state.Items[i] = item
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// listClusters retrieves all the pages of clusters that match the given search and order
+// criteria.
+func (s *ClustersDataSource) listClusters(ctx context.Context,
+	search, order string) ([]*cmv1.Cluster, error) {
+	var result []*cmv1.Cluster
+	page := 1
+	size := 100
+	for {
+		request := s.collection.List().Page(page).Size(size)
+		if search != "" {
+			request.Search(search)
+		}
+		if order != "" {
+			request.Order(order)
+		}
+		response, err := request.SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.Items().Slice()...)
+		if response.Items().Len() == 0 || len(result) >= response.Total() {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
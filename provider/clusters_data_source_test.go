@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Clusters search", func() {
+	It("Returns an empty query without criteria", func() {
+		state := &ClustersState{
+			Properties: types.Map{
+				ElemType: types.StringType,
+				Null:     true,
+			},
+		}
+		Expect(clustersSearch(state)).To(BeEmpty())
+	})
+
+	It("Combines all the criteria", func() {
+		state := &ClustersState{
+			Search: types.String{
+				Value: "multi_az = 't' or cloud_provider.id = 'gcp'",
+			},
+			Name: types.String{
+				Value: "prod-%",
+			},
+			Product: types.String{
+				Value: "rosa",
+			},
+			State: types.String{
+				Value: "ready",
+			},
+			Properties: types.Map{
+				ElemType: types.StringType,
+				Elems: map[string]attr.Value{
+					"team":   types.String{Value: "payments"},
+					"region": types.String{Value: "emea"},
+				},
+			},
+		}
+		Expect(clustersSearch(state)).To(Equal(
+			"(multi_az = 't' or cloud_provider.id = 'gcp') and " +
+				"name like 'prod-%' and " +
+				"product.id = 'rosa' and " +
+				"state = 'ready' and " +
+				"properties.region = 'emea' and " +
+				"properties.team = 'payments'",
+		))
+	})
+
+	It("Escapes quotes in the values", func() {
+		state := &ClustersState{
+			Name: types.String{
+				Value: "o'brien",
+			},
+			Properties: types.Map{
+				ElemType: types.StringType,
+				Null:     true,
+			},
+		}
+		Expect(clustersSearch(state)).To(Equal("name like 'o''brien'"))
+	})
+})
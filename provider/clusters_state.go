@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type ClustersState struct {
+	Search     types.String         `tfsdk:"search"`
+	Name       types.String         `tfsdk:"name"`
+	Product    types.String         `tfsdk:"product"`
+	State      types.String         `tfsdk:"state"`
+	Properties types.Map            `tfsdk:"properties"`
+	Order      types.String         `tfsdk:"order"`
+	Items      []*ClustersItemState `tfsdk:"items"`
+}
+
+type ClustersItemState struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	ExternalID     types.String `tfsdk:"external_id"`
+	Product        types.String `tfsdk:"product"`
+	CloudProvider  types.String `tfsdk:"cloud_provider"`
+	CloudRegion    types.String `tfsdk:"cloud_region"`
+	MultiAZ        types.Bool   `tfsdk:"multi_az"`
+	State          types.String `tfsdk:"state"`
+	CurrentVersion types.String `tfsdk:"current_version"`
+	APIURL         types.String `tfsdk:"api_url"`
+	ConsoleURL     types.String `tfsdk:"console_url"`
+	Properties     types.Map    `tfsdk:"properties"`
+}
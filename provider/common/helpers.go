@@ -117,6 +117,38 @@ func IsStringAttributeEmpty(param types.String) bool {
 	return param.Unknown || param.Null || param.Value == ""
 }
 
+// OptionalString returns the state of an optional string attribute, which is null when the value
+// is empty.
+func OptionalString(value string) types.String {
+	if value == "" {
+		return types.String{
+			Null: true,
+		}
+	}
+	return types.String{
+		Value: value,
+	}
+}
+
+// OptionalBool returns the state of an optional boolean attribute whose default is false. The
+// result is true if the value is true, false if the current state already has a value, and null
+// otherwise, so that a flag that isn't in the configuration doesn't produce differences.
+func OptionalBool(current types.Bool, value bool) types.Bool {
+	if value {
+		return types.Bool{
+			Value: true,
+		}
+	}
+	if current.Unknown || current.Null {
+		return types.Bool{
+			Null: true,
+		}
+	}
+	return types.Bool{
+		Value: false,
+	}
+}
+
 func IsGreaterThanOrEqual(version1, version2 string) (bool, error) {
 	v1, err := version.NewVersion(strings.TrimPrefix(version1, versionPrefix))
 	if err != nil {
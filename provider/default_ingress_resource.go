@@ -0,0 +1,333 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+type DefaultIngressResourceType struct {
+}
+
+type DefaultIngressResource struct {
+	logger       logging.Logger
+	collection   *cmv1.ClustersClient
+	pollInterval time.Duration
+}
+
+func (t *DefaultIngressResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Settings of the default ingress of a cluster. The default ingress is " +
+			"created with the cluster, so this resource only updates it, and destroying " +
+			"the resource leaves the ingress unchanged.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				Description: "Unique identifier of the ingress.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"dns_name": {
+				Description: "DNS name of the ingress.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"route_selectors": {
+				Description: "Labels that routes must have to be admitted by the " +
+					"ingress. When not set the current selectors are kept, an empty " +
+					"map removes them.",
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *DefaultIngressResourceType) NewResource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.Resource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation: use it directly when needed.
+	parent := p.(*Provider)
+
+	// Get the collection of clusters:
+	collection := parent.connection.ClustersMgmt().V1().Clusters()
+
+	// Create the resource:
+	result = &DefaultIngressResource{
+		logger:       parent.logger,
+		collection:   collection,
+		pollInterval: parent.pollInterval,
+	}
+
+	return
+}
+
+func (r *DefaultIngressResource) Create(ctx context.Context,
+	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	// Get the plan:
+	state := &DefaultIngressState{}
+	diags := request.Plan.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait till the cluster is ready, as the default ingress is created during the installation:
+	resource := r.collection.Cluster(state.Cluster.Value)
+	pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+	defer cancel()
+	_, _, err := pollCluster(pollCtx, resource, r.pollInterval, func(object *cmv1.Cluster) bool {
+		return object.State() == cmv1.ClusterStateReady
+	})
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't poll cluster state",
+			fmt.Sprintf(
+				"Can't poll state of cluster with identifier '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Find the default ingress and apply the settings:
+	object, err := r.findDefaultIngress(ctx, state.Cluster.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find default ingress",
+			fmt.Sprintf(
+				"Can't find default ingress for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	object, err = r.updateIngress(ctx, state.Cluster.Value, object, state)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't update default ingress",
+			fmt.Sprintf(
+				"Can't update default ingress '%s' for cluster '%s': %v",
+				object.ID(), state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Save the state:
+	r.populateState(object, state)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *DefaultIngressResource) Read(ctx context.Context, request tfsdk.ReadResourceRequest,
+	response *tfsdk.ReadResourceResponse) {
+	// Get the current state:
+	state := &DefaultIngressState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the default ingress:
+	object, err := r.findDefaultIngress(ctx, state.Cluster.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find default ingress",
+			fmt.Sprintf(
+				"Can't find default ingress for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Save the state:
+	r.populateState(object, state)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *DefaultIngressResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
+	response *tfsdk.UpdateResourceResponse) {
+	// Get the state:
+	state := &DefaultIngressState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the plan:
+	plan := &DefaultIngressState{}
+	diags = request.Plan.Get(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the default ingress and apply the changes:
+	object, err := r.findDefaultIngress(ctx, state.Cluster.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find default ingress",
+			fmt.Sprintf(
+				"Can't find default ingress for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	object, err = r.updateIngress(ctx, state.Cluster.Value, object, plan)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't update default ingress",
+			fmt.Sprintf(
+				"Can't update default ingress '%s' for cluster '%s': %v",
+				object.ID(), state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Save the state:
+	r.populateState(object, plan)
+	diags = response.State.Set(ctx, plan)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *DefaultIngressResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
+	response *tfsdk.DeleteResourceResponse) {
+	// The default ingress can't be deleted, so only the state is removed:
+	response.State.RemoveResource(ctx)
+}
+
+func (r *DefaultIngressResource) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest,
+	response *tfsdk.ImportResourceStateResponse) {
+	// The default ingress is imported using the identifier of the cluster:
+	tfsdk.ResourceImportStatePassthroughID(
+		ctx,
+		tftypes.NewAttributePath().WithAttributeName("cluster"),
+		request,
+		response,
+	)
+}
+
+// findDefaultIngress returns the default ingress of the given cluster.
+func (r *DefaultIngressResource) findDefaultIngress(ctx context.Context,
+	clusterID string) (*cmv1.Ingress, error) {
+	list, err := r.collection.Cluster(clusterID).Ingresses().List().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ingress := range list.Items().Slice() {
+		if ingress.Default() {
+			return ingress, nil
+		}
+	}
+	return nil, fmt.Errorf("cluster '%s' doesn't have a default ingress", clusterID)
+}
+
+// updateIngress sends the settings of the plan that differ from the given ingress, and returns
+// the updated ingress. Nothing is sent if all the settings are already applied.
+func (r *DefaultIngressResource) updateIngress(ctx context.Context, clusterID string,
+	object *cmv1.Ingress, plan *DefaultIngressState) (*cmv1.Ingress, error) {
+	builder := cmv1.NewIngress()
+	changed := false
+	if !plan.RouteSelectors.Unknown && !plan.RouteSelectors.Null {
+		selectors := map[string]string{}
+		for k, v := range plan.RouteSelectors.Elems {
+			selectors[k] = v.(types.String).Value
+		}
+		if !reflect.DeepEqual(selectors, object.RouteSelectors()) &&
+			(len(selectors) > 0 || len(object.RouteSelectors()) > 0) {
+			builder.RouteSelectors(selectors)
+			changed = true
+		}
+	}
+	if !changed {
+		return object, nil
+	}
+	patch, err := builder.Build()
+	if err != nil {
+		return object, err
+	}
+	update, err := r.collection.Cluster(clusterID).
+		Ingresses().
+		Ingress(object.ID()).
+		Update().
+		Body(patch).
+		SendContext(ctx)
+	if err != nil {
+		return object, err
+	}
+	return update.Body(), nil
+}
+
+// populateState copies the data from the API object to the Terraform state.
+func (r *DefaultIngressResource) populateState(object *cmv1.Ingress, state *DefaultIngressState) {
+	state.ID = types.String{
+		Value: object.ID(),
+	}
+	state.DNSName = types.String{
+		Value: object.DNSName(),
+	}
+	state.RouteSelectors = types.Map{
+		ElemType: types.StringType,
+		Elems:    map[string]attr.Value{},
+	}
+	for k, v := range object.RouteSelectors() {
+		state.RouteSelectors.Elems[k] = types.String{
+			Value: v,
+		}
+	}
+}
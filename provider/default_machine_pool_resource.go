@@ -0,0 +1,266 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+// defaultMachinePoolName is the name of the machine pool that is created together with the
+// cluster.
+const defaultMachinePoolName = "worker"
+
+type DefaultMachinePoolResourceType struct {
+	logger logging.Logger
+}
+
+var _ tfsdk.ResourceWithModifyPlan = &DefaultMachinePoolResource{}
+
+// DefaultMachinePoolResource manages the machine pool created together with the cluster. It
+// uses the schema of the other machine pools, but creating it adopts the existing pool and
+// destroying it only removes it from the state, as that pool can't be deleted.
+type DefaultMachinePoolResource struct {
+	*MachinePoolResource
+	pollInterval time.Duration
+}
+
+func (t *DefaultMachinePoolResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result, diags = (&MachinePoolResourceType{logger: t.logger}).GetSchema(ctx)
+	result.Description = "Default machine pool of a cluster, the one created together " +
+		"with the cluster. Creating this resource adopts the existing pool and applies " +
+		"the settings, destroying it only removes it from the Terraform state."
+	attributes := result.Attributes
+
+	name := attributes["name"]
+	name.Description = "Name of the default machine pool, `" + defaultMachinePoolName +
+		"` by default."
+	name.Required = false
+	name.Optional = true
+	name.Computed = true
+	name.PlanModifiers = []tfsdk.AttributePlanModifier{
+		ValueCannotBeChangedModifier(t.logger),
+	}
+	attributes["name"] = name
+
+	// The machine type, like the rest of the settings that are only used when creating a
+	// machine pool, is taken from the existing pool:
+	machineType := attributes["machine_type"]
+	machineType.Required = false
+	machineType.Optional = true
+	machineType.Computed = true
+	attributes["machine_type"] = machineType
+	for _, key := range []string{
+		"use_spot_instances",
+		"max_spot_price",
+		"subnet_id",
+		"availability_zone",
+		"infra",
+	} {
+		attribute := attributes[key]
+		attribute.Optional = false
+		attribute.Computed = true
+		attribute.Validators = nil
+		attribute.PlanModifiers = nil
+		attributes[key] = attribute
+	}
+
+	// The labels that aren't configured are the ones of the existing pool:
+	labels := attributes["labels"]
+	labels.Computed = true
+	attributes["labels"] = labels
+
+	force := attributes["force"]
+	force.Description = "Ignored, as destroying the default machine pool never deletes it."
+	attributes["force"] = force
+
+	return
+}
+
+func (t *DefaultMachinePoolResourceType) NewResource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.Resource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation: use it directly when needed.
+	parent := p.(*Provider)
+
+	// Create the resource:
+	resource, diags := (&MachinePoolResourceType{logger: t.logger}).NewResource(ctx, p)
+	if diags.HasError() {
+		return
+	}
+	result = &DefaultMachinePoolResource{
+		MachinePoolResource: resource.(*MachinePoolResource),
+		pollInterval:        parent.pollInterval,
+	}
+
+	return
+}
+
+func (r *DefaultMachinePoolResource) Create(ctx context.Context,
+	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	// Get the plan:
+	plan := &MachinePoolState{}
+	diags := request.Plan.Get(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if plan.Name.Unknown || plan.Name.Null {
+		plan.Name = types.String{
+			Value: defaultMachinePoolName,
+		}
+	}
+	plan.ID = plan.Name
+
+	// Wait till the cluster is ready, as the default machine pool is created during the
+	// installation:
+	resource := r.collection.Cluster(plan.Cluster.Value)
+	pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+	defer cancel()
+	_, _, err := pollCluster(pollCtx, resource, r.pollInterval, func(object *cmv1.Cluster) bool {
+		return object.State() == cmv1.ClusterStateReady
+	})
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't poll cluster state",
+			fmt.Sprintf(
+				"Can't poll state of cluster with identifier '%s': %v",
+				plan.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Find the default machine pool:
+	get, err := resource.MachinePools().MachinePool(plan.ID.Value).Get().SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find default machine pool",
+			fmt.Sprintf(
+				"Can't find machine pool '%s' for cluster '%s': %v",
+				plan.ID.Value, plan.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	state := &MachinePoolState{
+		Cluster: plan.Cluster,
+		Labels: types.Map{
+			ElemType: types.StringType,
+			Null:     true,
+		},
+		ZoneType: types.String{Unknown: true},
+	}
+	r.populateState(get.Body(), state)
+
+	// Apply the settings of the plan:
+	response.Diagnostics.Append(r.update(ctx, state, plan, true)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	machineType, err := findMachineType(ctx, r.cache, r.machineTypeCollection, state.MachineType.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find machine type",
+			fmt.Sprintf(
+				"Can't find machine type of machine pool '%s' for cluster '%s': %v",
+				state.ID.Value, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	populateMachineTypeState(machineType, state)
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *DefaultMachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
+	response *tfsdk.UpdateResourceResponse) {
+	// Get the state:
+	state := &MachinePoolState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the plan:
+	plan := &MachinePoolState{}
+	diags = request.Plan.Get(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Send the update, including the labels and taints:
+	response.Diagnostics.Append(r.update(ctx, state, plan, true)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *DefaultMachinePoolResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
+	response *tfsdk.DeleteResourceResponse) {
+	// The default machine pool can't be deleted, so only remove it from the state:
+	response.State.RemoveResource(ctx)
+}
+
+func (r *DefaultMachinePoolResource) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest,
+	response *tfsdk.ImportResourceStateResponse) {
+	// The identifier of the pool is optional, as usually it will be the default name:
+	if strings.Contains(request.ID, ",") {
+		r.MachinePoolResource.ImportState(ctx, request, response)
+		return
+	}
+	if request.ID == "" {
+		response.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Expected an import identifier like '<cluster_id>' or "+
+				"'<cluster_id>,<machine_pool_id>'",
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("cluster"),
+		request.ID,
+	)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("id"),
+		defaultMachinePoolName,
+	)...)
+}
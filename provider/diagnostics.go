@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// diagnosticCode is the stable code that classifies the cause of an error reported by the
+// provider. The codes are part of the text of the diagnostics, so that CI systems can detect
+// them, and must not change.
+type diagnosticCode string
+
+const (
+	// authDiagnosticCode is used when OCM rejects the credentials or the permissions of the
+	// user.
+	authDiagnosticCode diagnosticCode = "OCM-TF-AUTH"
+
+	// quotaDiagnosticCode is used when the organization, or the AWS account, doesn't have
+	// enough quota for the requested resources.
+	quotaDiagnosticCode diagnosticCode = "OCM-TF-QUOTA"
+
+	// validationDiagnosticCode is used when the request is rejected because of the values of
+	// the attributes.
+	validationDiagnosticCode diagnosticCode = "OCM-TF-VALIDATION"
+
+	// awsDiagnosticCode is used when the error comes from AWS, either directly or reported by
+	// OCM.
+	awsDiagnosticCode diagnosticCode = "OCM-TF-AWS"
+
+	// ocmDiagnosticCode is used when the OCM API fails or can't be reached.
+	ocmDiagnosticCode diagnosticCode = "OCM-TF-OCM"
+)
+
+// diagnosticRemediation is the guidance added to the diagnostics of each code.
+type diagnosticRemediation struct {
+	text string
+	link string
+}
+
+var diagnosticRemediations = map[diagnosticCode]diagnosticRemediation{
+	authDiagnosticCode: {
+		text: "Check that the token or the client credentials of the provider are valid and " +
+			"haven't expired, and that the user has the roles needed for the operation.",
+		link: "https://console.redhat.com/openshift/token",
+	},
+	quotaDiagnosticCode: {
+		text: "Check the subscriptions of the organization and the service quotas of the " +
+			"AWS account, and request an increase or release unused resources.",
+		link: "https://console.redhat.com/openshift/quota",
+	},
+	validationDiagnosticCode: {
+		text: "Check the values of the attributes mentioned in the error against the " +
+			"documentation of the resource.",
+		link: "https://registry.terraform.io/providers/terraform-redhat/ocm/latest/docs",
+	},
+	awsDiagnosticCode: {
+		text: "Check the AWS credentials, the account and operator roles and their policies, " +
+			"and the network resources used by the cluster.",
+		link: "https://docs.openshift.com/rosa/rosa_planning/rosa-sts-aws-prereqs.html",
+	},
+	ocmDiagnosticCode: {
+		text: "The OCM API failed or couldn't be reached. Check the status of the service " +
+			"and the network connection, and retry later.",
+		link: "https://status.redhat.com",
+	},
+}
+
+// classifyError returns the diagnostic code that corresponds to the given error, or an empty
+// string if the cause of the error isn't known.
+func classifyError(err error) diagnosticCode {
+	var sdkErr *ocmerrors.Error
+	if errors.As(err, &sdkErr) {
+		return classifySDKError(sdkErr)
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		if strings.Contains(strings.ToLower(awsErr.Code()), "limitexceeded") {
+			return quotaDiagnosticCode
+		}
+		return awsDiagnosticCode
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ocmDiagnosticCode
+	}
+	return ""
+}
+
+// classifySDKError classifies an error returned by the OCM API using the status and the reason.
+func classifySDKError(sdkErr *ocmerrors.Error) diagnosticCode {
+	reason := strings.ToLower(sdkErr.Reason())
+	switch {
+	case sdkErr.Status() == http.StatusUnauthorized || sdkErr.Status() == http.StatusForbidden:
+		if strings.Contains(reason, "quota") {
+			return quotaDiagnosticCode
+		}
+		return authDiagnosticCode
+	case sdkErr.Status() == http.StatusPaymentRequired || strings.Contains(reason, "quota"):
+		return quotaDiagnosticCode
+	case sdkErr.Status() >= http.StatusInternalServerError:
+		return ocmDiagnosticCode
+	case isIAMPropagationError(sdkErr) || strings.Contains(reason, "aws"):
+		return awsDiagnosticCode
+	case sdkErr.Status() >= http.StatusBadRequest:
+		return validationDiagnosticCode
+	}
+	return ""
+}
+
+// diagnosticDetail adds to the given detail the code and the remediation guidance.
+func diagnosticDetail(code diagnosticCode, detail string) string {
+	remediation, ok := diagnosticRemediations[code]
+	if !ok {
+		return detail
+	}
+	text := fmt.Sprintf(
+		"Error code: %s\nRemediation: %s\nMore information: %s",
+		code, remediation.text, remediation.link,
+	)
+	if detail == "" {
+		return text
+	}
+	return detail + "\n\n" + text
+}
+
+// addCodedError adds an error with the given code to the diagnostics.
+func addCodedError(diags *diag.Diagnostics, code diagnosticCode, summary, detail string) {
+	diags.AddError(summary, diagnosticDetail(code, detail))
+}
+
+// addClassifiedError adds an error to the diagnostics, with the code that corresponds to the
+// given cause. The detail should already describe the cause, it is only used to classify the
+// error.
+func addClassifiedError(diags *diag.Diagnostics, summary, detail string, cause error) {
+	addCodedError(diags, classifyError(cause), summary, detail)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+var _ = Describe("Diagnostics", func() {
+	sdkError := func(status int, reason string) error {
+		err, buildErr := ocmerrors.NewError().Status(status).Reason(reason).Build()
+		Expect(buildErr).ToNot(HaveOccurred())
+		return err
+	}
+
+	It("Classifies the errors", func() {
+		Expect(classifyError(sdkError(http.StatusUnauthorized, "bad token"))).To(Equal(authDiagnosticCode))
+		Expect(classifyError(sdkError(http.StatusForbidden, "not allowed"))).To(Equal(authDiagnosticCode))
+		Expect(classifyError(sdkError(http.StatusForbidden, "Insufficient quota"))).To(Equal(quotaDiagnosticCode))
+		Expect(classifyError(sdkError(http.StatusBadRequest, "cluster quota exceeded"))).To(Equal(quotaDiagnosticCode))
+		Expect(classifyError(sdkError(http.StatusBadRequest, "Invalid name"))).To(Equal(validationDiagnosticCode))
+		Expect(classifyError(sdkError(http.StatusBadRequest, "AWS STS role not found"))).To(Equal(awsDiagnosticCode))
+		Expect(classifyError(sdkError(http.StatusInternalServerError, "failed"))).To(Equal(ocmDiagnosticCode))
+		Expect(classifyError(awserr.New("AccessDenied", "denied", nil))).To(Equal(awsDiagnosticCode))
+		Expect(classifyError(awserr.New("VcpuLimitExceeded", "limit", nil))).To(Equal(quotaDiagnosticCode))
+		Expect(classifyError(&net.OpError{Op: "dial", Err: fmt.Errorf("refused")})).To(Equal(ocmDiagnosticCode))
+		Expect(classifyError(fmt.Errorf("failed"))).To(BeEmpty())
+	})
+
+	It("Adds the code and the remediation to the detail", func() {
+		var diags diag.Diagnostics
+		addClassifiedError(&diags, "Can't create cluster", "Rejected", sdkError(http.StatusUnauthorized, "bad token"))
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Detail()).To(HavePrefix("Rejected\n\nError code: OCM-TF-AUTH\n"))
+		Expect(diags[0].Detail()).To(ContainSubstring("More information: https://"))
+	})
+
+	It("Doesn't change the detail of unknown errors", func() {
+		var diags diag.Diagnostics
+		addClassifiedError(&diags, "Can't create cluster", "Failed", fmt.Errorf("failed"))
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Detail()).To(Equal("Failed"))
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+	"github.com/thoas/go-funk"
+)
+
+// URLs of the FedRAMP High environment of OCM, used by default when the 'fedramp' attribute of the
+// provider is set:
+const (
+	fedRAMPURL      = "https://api.openshiftusgov.com"
+	fedRAMPTokenURL = "https://sso.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token"
+)
+
+// fedRAMPDomain is the domain of the API servers of the FedRAMP environments, used to detect
+// them when the URL is given explicitly.
+const fedRAMPDomain = "openshiftusgov.com"
+
+// govCloudPartition is the ARN partition of the AWS GovCloud regions.
+const govCloudPartition = "aws-us-gov"
+
+// govCloudRegions are the regions where the FedRAMP environment can create clusters.
+var govCloudRegions = []string{"us-gov-east-1", "us-gov-west-1"}
+
+// isFedRAMPURL checks if the given API URL belongs to a FedRAMP environment of OCM.
+func isFedRAMPURL(text string) bool {
+	parsed, err := url.Parse(text)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	return host == fedRAMPDomain || strings.HasSuffix(host, "."+fedRAMPDomain)
+}
+
+// checkClusterPartition checks that the region and the ARNs of the cluster can be used in the
+// OCM environment that the provider is connected to. The FedRAMP environment only accepts the
+// GovCloud regions and ARNs in the GovCloud partition, and the commercial environment rejects
+// them. Values that aren't known yet are ignored.
+func checkClusterPartition(state *ClusterRosaClassicState, fedRAMP bool) []error {
+	var errs []error
+	if !common.IsStringAttributeEmpty(state.CloudRegion) {
+		region := state.CloudRegion.Value
+		govCloud := funk.ContainsString(govCloudRegions, region)
+		if fedRAMP && !govCloud {
+			errs = append(errs, fmt.Errorf(
+				"region '%s' isn't supported by the FedRAMP environment, it must be one of %s",
+				region, strings.Join(govCloudRegions, ", "),
+			))
+		}
+		if !fedRAMP && govCloud {
+			errs = append(errs, fmt.Errorf(
+				"region '%s' is only supported by the FedRAMP environment, set the "+
+					"'fedramp' attribute of the provider to use it",
+				region,
+			))
+		}
+	}
+	for _, attribute := range clusterARNs(state) {
+		parsed, err := arn.Parse(attribute.value)
+		if err != nil {
+			// Invalid ARNs are reported by the validators of the attributes.
+			continue
+		}
+		if fedRAMP && parsed.Partition != govCloudPartition {
+			errs = append(errs, fmt.Errorf(
+				"the value '%s' of attribute '%s' must be in the '%s' partition to be "+
+					"used with the FedRAMP environment",
+				attribute.value, attribute.name, govCloudPartition,
+			))
+		}
+		if !fedRAMP && parsed.Partition == govCloudPartition {
+			errs = append(errs, fmt.Errorf(
+				"the value '%s' of attribute '%s' is in the '%s' partition, which is "+
+					"only supported by the FedRAMP environment, set the 'fedramp' "+
+					"attribute of the provider to use it",
+				attribute.value, attribute.name, govCloudPartition,
+			))
+		}
+	}
+	return errs
+}
+
+// clusterARN is an ARN given in an attribute of the cluster.
+type clusterARN struct {
+	name  string
+	value string
+}
+
+// clusterARNs returns the ARNs given in the attributes of the cluster, skipping the ones that
+// are empty or not known yet.
+func clusterARNs(state *ClusterRosaClassicState) []clusterARN {
+	var result []clusterARN
+	add := func(name string, value types.String) {
+		if !common.IsStringAttributeEmpty(value) {
+			result = append(result, clusterARN{name: name, value: value.Value})
+		}
+	}
+	add("kms_key_arn", state.KMSKeyArn)
+	if state.Sts != nil {
+		add("sts.role_arn", state.Sts.RoleARN)
+		add("sts.support_role_arn", state.Sts.SupportRoleArn)
+		add("sts.instance_iam_roles.master_role_arn", state.Sts.InstanceIAMRoles.MasterRoleARN)
+		add("sts.instance_iam_roles.worker_role_arn", state.Sts.InstanceIAMRoles.WorkerRoleARN)
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("FedRAMP", func() {
+	buildState := func(region, partition string) *ClusterRosaClassicState {
+		return &ClusterRosaClassicState{
+			CloudRegion: types.String{Value: region},
+			KMSKeyArn: types.String{
+				Value: "arn:" + partition + ":kms:" + region + ":123456789012:key/abc",
+			},
+			Sts: &Sts{
+				RoleARN: types.String{
+					Value: "arn:" + partition + ":iam::123456789012:role/Installer",
+				},
+				SupportRoleArn: types.String{Unknown: true},
+			},
+		}
+	}
+
+	It("Detects the FedRAMP URLs", func() {
+		Expect(isFedRAMPURL(fedRAMPURL)).To(BeTrue())
+		Expect(isFedRAMPURL("https://api.int.openshiftusgov.com")).To(BeTrue())
+		Expect(isFedRAMPURL("https://api.openshift.com")).To(BeFalse())
+		Expect(isFedRAMPURL("https://openshiftusgov.com.example.com")).To(BeFalse())
+	})
+
+	It("Accepts GovCloud clusters in the FedRAMP environment", func() {
+		Expect(checkClusterPartition(buildState("us-gov-west-1", "aws-us-gov"), true)).To(BeEmpty())
+	})
+
+	It("Accepts commercial clusters in the commercial environment", func() {
+		Expect(checkClusterPartition(buildState("us-east-1", "aws"), false)).To(BeEmpty())
+	})
+
+	It("Rejects commercial regions and ARNs in the FedRAMP environment", func() {
+		errs := checkClusterPartition(buildState("us-east-1", "aws"), true)
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Error()).To(ContainSubstring("region 'us-east-1' isn't supported"))
+		Expect(errs[1].Error()).To(ContainSubstring("'kms_key_arn'"))
+		Expect(errs[2].Error()).To(ContainSubstring("'sts.role_arn'"))
+	})
+
+	It("Rejects GovCloud regions and ARNs in the commercial environment", func() {
+		errs := checkClusterPartition(buildState("us-gov-east-1", "aws-us-gov"), false)
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Error()).To(ContainSubstring("only supported by the FedRAMP environment"))
+	})
+
+	It("Ignores unknown values", func() {
+		state := &ClusterRosaClassicState{
+			CloudRegion: types.String{Unknown: true},
+			KMSKeyArn:   types.String{Null: true},
+		}
+		Expect(checkClusterPartition(state, true)).To(BeEmpty())
+	})
+})
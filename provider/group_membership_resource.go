@@ -105,12 +105,14 @@ func (r *GroupMembershipResource) Create(ctx context.Context,
 		}).
 		StartContext(pollCtx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't poll cluster state",
 			fmt.Sprintf(
 				"Can't poll state of cluster with identifier '%s': %v",
 				state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -120,24 +122,28 @@ func (r *GroupMembershipResource) Create(ctx context.Context,
 	builder.ID(state.User.Value)
 	object, err := builder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't build group membership",
 			fmt.Sprintf(
 				"Can't build group membership for cluster '%s' and group '%s': %v",
 				state.Cluster.Value, state.Group.Value, err,
 			),
+			err,
 		)
 		return
 	}
 	collection := resource.Groups().Group(state.Group.Value).Users()
 	add, err := collection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't create group membership",
 			fmt.Sprintf(
 				"Can't create group membership for cluster '%s' and group '%s': %v",
 				state.Cluster.Value, state.Group.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -165,13 +171,15 @@ func (r *GroupMembershipResource) Read(ctx context.Context, request tfsdk.ReadRe
 		User(state.ID.Value)
 	get, err := resource.Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find group membership",
 			fmt.Sprintf(
 				"Can't find user group membership identifier '%s' for "+
 					"cluster '%s' and group '%s': %v",
 				state.ID.Value, state.Cluster.Value, state.Group.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -203,13 +211,15 @@ func (r *GroupMembershipResource) Delete(ctx context.Context, request tfsdk.Dele
 		User(state.ID.Value)
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't delete group membership",
 			fmt.Sprintf(
 				"Can't delete group membership with identifier '%s' for "+
 					"cluster '%s' and group '%s': %v",
 				state.ID.Value, state.Cluster.Value, state.Group.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -0,0 +1,105 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+const (
+	// defaultIAMPropagationTimeoutInSeconds is the time that the creation of a cluster is
+	// retried when the 'iam_propagation_timeout' attribute of the provider isn't set.
+	defaultIAMPropagationTimeoutInSeconds = int64(120)
+
+	// iamPropagationRetryInterval is the maximum time between the retries of a request rejected
+	// because of IAM propagation.
+	iamPropagationRetryInterval = 10 * time.Second
+)
+
+// iamPropagationErrors are the messages, in lower case, that AWS returns, and that OCM passes on,
+// when it can't use roles that were created or changed a few seconds before, because the change
+// hasn't yet reached all the AWS IAM endpoints. Each message is described by fragments that must
+// all be present, so that errors about other missing objects, like subnets or OIDC configurations,
+// aren't retried. See the troubleshooting guide of IAM:
+//
+//	https://docs.aws.amazon.com/IAM/latest/UserGuide/troubleshoot_general.html#troubleshoot_general_eventual-consistency
+var iamPropagationErrors = [][]string{
+	// The role exists but its trust policy doesn't allow OCM to assume it yet:
+	{"is not authorized to perform", "sts:assumerole"},
+
+	// The role was just created and isn't visible yet:
+	{"nosuchentity", "role"},
+	{"the role with name", "cannot be found"},
+
+	// The trust policy references a role that was just created:
+	{"invalid principal in policy"},
+}
+
+// isIAMPropagationError checks if the given error is one of the errors that OCM returns while
+// the IAM roles that it uses are still propagating.
+func isIAMPropagationError(err error) bool {
+	sdkErr, ok := err.(*errors.Error)
+	if !ok || sdkErr.Status() < http.StatusBadRequest ||
+		sdkErr.Status() >= http.StatusInternalServerError {
+		return false
+	}
+	reason := strings.ToLower(sdkErr.Reason())
+	for _, fragments := range iamPropagationErrors {
+		matches := true
+		for _, fragment := range fragments {
+			if !strings.Contains(reason, fragment) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// retryOnIAMPropagation calls the send function, and calls it again, with exponential backoff,
+// while it fails with an IAM propagation error and the timeout hasn't expired. It returns the
+// error of the last call.
+func retryOnIAMPropagation(ctx context.Context, timeout, maxInterval time.Duration,
+	send func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return send(ctx)
+	}
+	deadline := time.Now().Add(timeout)
+	var last error
+	err := pollWithBackoff(ctx, maxInterval, func(ctx context.Context) (bool, error) {
+		last = send(ctx)
+		if last == nil {
+			return true, nil
+		}
+		if isIAMPropagationError(last) && time.Now().Before(deadline) {
+			return false, nil
+		}
+		return false, last
+	})
+	if err != nil && last != nil {
+		return last
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	"github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+var _ = Describe("IAM propagation", func() {
+	buildError := func(status int, reason string) error {
+		err, buildErr := errors.NewError().Status(status).Reason(reason).Build()
+		Expect(buildErr).ToNot(HaveOccurred())
+		return err
+	}
+
+	// roleNotFound is the message that OCM returns when the installer role was created a few
+	// seconds before and isn't visible yet:
+	roleNotFound := "NoSuchEntity: The role with name my-Installer-Role cannot be found."
+
+	It("Detects the errors caused by IAM propagation", func() {
+		Expect(isIAMPropagationError(buildError(
+			http.StatusBadRequest,
+			roleNotFound,
+		))).To(BeTrue())
+		Expect(isIAMPropagationError(buildError(
+			http.StatusBadRequest,
+			"User: arn:aws:sts::123456789012:assumed-role/RH-Managed-OpenShift-Installer/OCM "+
+				"is not authorized to perform: sts:AssumeRole on resource: "+
+				"arn:aws:iam::123456789012:role/my-Installer-Role",
+		))).To(BeTrue())
+		Expect(isIAMPropagationError(buildError(
+			http.StatusBadRequest,
+			"MalformedPolicyDocument: Invalid principal in policy: "+
+				"\"AWS\":\"arn:aws:iam::123456789012:role/my-Support-Role\"",
+		))).To(BeTrue())
+	})
+
+	It("Doesn't detect errors about other objects", func() {
+		Expect(isIAMPropagationError(buildError(
+			http.StatusBadRequest,
+			"Subnet 'subnet-0123456789abcdef0' cannot be found",
+		))).To(BeFalse())
+		Expect(isIAMPropagationError(buildError(
+			http.StatusNotFound,
+			"OIDC config '23mpplkdu9u3lbvlr3gp3mj4b8ph7ahh' not found",
+		))).To(BeFalse())
+		Expect(isIAMPropagationError(buildError(
+			http.StatusBadRequest,
+			"Version '4.1' is not supported",
+		))).To(BeFalse())
+		Expect(isIAMPropagationError(buildError(
+			http.StatusInternalServerError,
+			roleNotFound,
+		))).To(BeFalse())
+		Expect(isIAMPropagationError(fmt.Errorf("%s", roleNotFound))).To(BeFalse())
+		Expect(isIAMPropagationError(nil)).To(BeFalse())
+	})
+
+	It("Retries till the roles are visible", func() {
+		calls := 0
+		err := retryOnIAMPropagation(context.Background(), time.Minute, 10*time.Millisecond,
+			func(ctx context.Context) error {
+				calls++
+				if calls < 3 {
+					return buildError(http.StatusBadRequest, roleNotFound)
+				}
+				return nil
+			})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("Doesn't retry other errors", func() {
+		calls := 0
+		err := retryOnIAMPropagation(context.Background(), time.Minute, 10*time.Millisecond,
+			func(ctx context.Context) error {
+				calls++
+				return buildError(http.StatusBadRequest, "Name is already in use")
+			})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("already in use"))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("Returns the last error when the timeout expires", func() {
+		calls := 0
+		err := retryOnIAMPropagation(context.Background(), 50*time.Millisecond, 10*time.Millisecond,
+			func(ctx context.Context) error {
+				calls++
+				return buildError(http.StatusBadRequest, roleNotFound)
+			})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cannot be found"))
+		Expect(calls).To(BeNumerically(">", 1))
+	})
+
+	It("Doesn't retry when the timeout is zero", func() {
+		calls := 0
+		err := retryOnIAMPropagation(context.Background(), 0, 10*time.Millisecond,
+			func(ctx context.Context) error {
+				calls++
+				return buildError(http.StatusBadRequest, roleNotFound)
+			})
+		Expect(err).To(HaveOccurred())
+		Expect(calls).To(Equal(1))
+	})
+})
@@ -19,6 +19,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -37,6 +38,7 @@ type IdentityProviderResourceType struct {
 type IdentityProviderResource struct {
 	logger     logging.Logger
 	collection *cmv1.ClustersClient
+	refresh    *refreshCache
 }
 
 func (t *IdentityProviderResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
@@ -70,6 +72,7 @@ func (t *IdentityProviderResourceType) GetSchema(ctx context.Context) (result tf
 				Description: "Details of the 'htpasswd' identity provider.",
 				Attributes:  idps.HtpasswdSchema(),
 				Optional:    true,
+				Validators:  idps.HTPasswdValidators(),
 			},
 			"gitlab": {
 				Description: "Details of the Gitlab identity provider.",
@@ -117,6 +120,7 @@ func (t *IdentityProviderResourceType) NewResource(ctx context.Context,
 	result = &IdentityProviderResource{
 		logger:     parent.logger,
 		collection: collection,
+		refresh:    parent.refresh,
 	}
 
 	return
@@ -131,6 +135,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context,
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.Cluster.Value)
 
 	// Wait till the cluster is ready:
 	resource := r.collection.Cluster(state.Cluster.Value)
@@ -143,12 +148,14 @@ func (r *IdentityProviderResource) Create(ctx context.Context,
 		}).
 		StartContext(pollCtx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't poll cluster state",
 			fmt.Sprintf(
 				"Can't poll state of cluster with identifier '%s': %v",
 				state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -210,25 +217,29 @@ func (r *IdentityProviderResource) Create(ctx context.Context,
 	}
 	object, err := builder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't build identity provider",
 			fmt.Sprintf(
 				"Can't build identity provider with name '%s': %v",
 				state.Name.Value, err,
 			),
+			err,
 		)
 		return
 	}
 	collection := resource.IdentityProviders()
 	add, err := collection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't create identity provider",
 			fmt.Sprintf(
 				"Can't create identity provider with name '%s' for "+
 					"cluster '%s': %v",
 				state.Name.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -278,28 +289,33 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request tfsdk.ReadR
 		return
 	}
 
-	// Find the identity provider:
-	resource := r.collection.Cluster(state.Cluster.Value).
-		IdentityProviders().
-		IdentityProvider(state.ID.Value)
-	get, err := resource.Get().SendContext(ctx)
+	// Find the identity provider, the identity providers of the cluster are retrieved together
+	// and shared with the other identity providers that are read during the same refresh:
+	object, err := r.refresh.getIdentityProvider(ctx, r.collection, state.Cluster.Value, state.ID.Value)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find identity provider",
 			fmt.Sprintf(
 				"Can't find identity provider with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
-	object := get.Body()
 
 	// Copy the identity provider data into the state:
 	state.Name = types.String{
 		Value: object.Name(),
 	}
+	mappingMethod, ok := object.GetMappingMethod()
+	if ok && mappingMethod != "" {
+		state.MappingMethod = types.String{
+			Value: string(mappingMethod),
+		}
+	}
 
 	htpasswdObject := object.Htpasswd()
 	gitlabObject := object.Gitlab()
@@ -312,6 +328,11 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request tfsdk.ReadR
 		if state.HTPasswd == nil {
 			state.HTPasswd = &idps.HTPasswdIdentityProvider{}
 		}
+		// The passwords of the users aren't returned by the server, so the list of users
+		// is kept as it is in the state:
+		if len(state.HTPasswd.Users) > 0 {
+			break
+		}
 		username, ok := htpasswdObject.GetUsername()
 		if ok {
 			state.HTPasswd.Username = types.String{
@@ -324,6 +345,25 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request tfsdk.ReadR
 				Value: password,
 			}
 		}
+		if common.IsStringAttributeEmpty(state.HTPasswd.Username) {
+			// This happens after importing the identity provider, the names of the users
+			// are retrieved so that the generated configuration only needs the passwords:
+			users, err := r.listHTPasswdUsers(ctx, state.Cluster.Value, object.ID())
+			if err != nil {
+				addClassifiedError(
+					&response.Diagnostics,
+					"Can't list users",
+					fmt.Sprintf(
+						"Can't list users of identity provider with identifier '%s' "+
+							"for cluster '%s': %v",
+						state.ID.Value, state.Cluster.Value, err,
+					),
+					err,
+				)
+				return
+			}
+			state.HTPasswd.Users = users
+		}
 	case gitlabObject != nil:
 		if state.Gitlab == nil {
 			state.Gitlab = &idps.GitlabIdentityProvider{}
@@ -526,6 +566,112 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request tfsdk.ReadR
 
 func (r *IdentityProviderResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
 	response *tfsdk.UpdateResourceResponse) {
+	// Get the state:
+	state := &IdentityProviderState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	defer r.refresh.invalidate(state.Cluster.Value)
+
+	// Get the plan:
+	plan := &IdentityProviderState{}
+	diags = request.Plan.Get(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the users of 'htpasswd' identity providers can be changed:
+	if state.HTPasswd != nil && plan.HTPasswd != nil {
+		err := r.updateHTPasswdUsers(ctx, state, plan)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't update identity provider",
+				fmt.Sprintf(
+					"Can't update users of identity provider with identifier '%s' for "+
+						"cluster '%s': %v",
+					state.ID.Value, state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+	}
+
+	// Save the state:
+	plan.ID = state.ID
+	if plan.MappingMethod.Unknown {
+		plan.MappingMethod = state.MappingMethod
+	}
+	diags = response.State.Set(ctx, plan)
+	response.Diagnostics.Append(diags...)
+}
+
+// updateHTPasswdUsers adds, removes and changes the passwords of the users of an 'htpasswd'
+// identity provider so that they match the plan.
+func (r *IdentityProviderResource) updateHTPasswdUsers(ctx context.Context,
+	state, plan *IdentityProviderState) error {
+	current := idps.HTPasswdUsers(state.HTPasswd)
+	desired := idps.HTPasswdUsers(plan.HTPasswd)
+	usersResource := r.collection.Cluster(state.Cluster.Value).
+		IdentityProviders().
+		IdentityProvider(state.ID.Value).
+		HtpasswdUsers()
+
+	// The users are identified by the server, so find the identifiers of the existing ones:
+	list, err := usersResource.List().SendContext(ctx)
+	if err != nil {
+		return err
+	}
+	ids := map[string]string{}
+	list.Items().Each(func(user *cmv1.HTPasswdUser) bool {
+		ids[user.Username()] = user.ID()
+		return true
+	})
+
+	for username, password := range desired {
+		id, exists := ids[username]
+		if !exists {
+			user, err := cmv1.NewHTPasswdUser().
+				Username(username).
+				Password(password).
+				Build()
+			if err != nil {
+				return err
+			}
+			_, err = usersResource.Add().Body(user).SendContext(ctx)
+			if err != nil {
+				return fmt.Errorf("can't add user '%s': %v", username, err)
+			}
+			continue
+		}
+		if current[username] == password {
+			continue
+		}
+		user, err := cmv1.NewHTPasswdUser().
+			Password(password).
+			Build()
+		if err != nil {
+			return err
+		}
+		_, err = usersResource.HtpasswdUser(id).Update().Body(user).SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("can't change password of user '%s': %v", username, err)
+		}
+	}
+	for username, id := range ids {
+		if _, ok := desired[username]; ok {
+			continue
+		}
+		_, err = usersResource.HtpasswdUser(id).Delete().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("can't remove user '%s': %v", username, err)
+		}
+	}
+	return nil
 }
 
 func (r *IdentityProviderResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
@@ -537,6 +683,7 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, request tfsdk.Del
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.Cluster.Value)
 
 	// Send the request to delete the identity provider:
 	resource := r.collection.Cluster(state.Cluster.Value).
@@ -544,13 +691,15 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, request tfsdk.Del
 		IdentityProvider(state.ID.Value)
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't delete identity provider",
 			fmt.Sprintf(
 				"Can't delete identity provider with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -561,10 +710,64 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, request tfsdk.Del
 
 func (r *IdentityProviderResource) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest,
 	response *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(
-		ctx,
+	// The identifier of the identity provider is only unique inside the cluster, so the import
+	// identifier contains both:
+	fields := strings.Split(request.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		response.Diagnostics.AddError(
+			"Invalid import identifier",
+			fmt.Sprintf(
+				"Expected an import identifier like '<cluster_id>,<identity_provider_id>' "+
+					"but got '%s'",
+				request.ID,
+			),
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("cluster"),
+		fields[0],
+	)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
 		tftypes.NewAttributePath().WithAttributeName("id"),
-		request,
-		response,
-	)
+		fields[1],
+	)...)
+}
+
+// listHTPasswdUsers retrieves the names of the users of the given 'htpasswd' identity provider.
+// The passwords aren't returned by the server, so they are left empty.
+func (r *IdentityProviderResource) listHTPasswdUsers(ctx context.Context, clusterID,
+	idpID string) ([]idps.HTPasswdUser, error) {
+	var result []idps.HTPasswdUser
+	page := 1
+	size := 100
+	for {
+		list, err := r.collection.Cluster(clusterID).
+			IdentityProviders().
+			IdentityProvider(idpID).
+			HtpasswdUsers().
+			List().
+			Page(page).
+			Size(size).
+			SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		list.Items().Each(func(item *cmv1.HTPasswdUser) bool {
+			result = append(result, idps.HTPasswdUser{
+				Username: types.String{
+					Value: item.Username(),
+				},
+				Password: types.String{
+					Null: true,
+				},
+			})
+			return true
+		})
+		if list.Items().Len() == 0 || len(result) >= list.Total() {
+			break
+		}
+		page++
+	}
+	return result, nil
 }
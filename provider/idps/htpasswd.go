@@ -2,13 +2,21 @@ package idps
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
 )
 
 type HTPasswdIdentityProvider struct {
+	Username types.String   `tfsdk:"username"`
+	Password types.String   `tfsdk:"password"`
+	Users    []HTPasswdUser `tfsdk:"users"`
+}
+
+type HTPasswdUser struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 }
@@ -16,21 +24,111 @@ type HTPasswdIdentityProvider struct {
 func HtpasswdSchema() tfsdk.NestedAttributes {
 	return tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
 		"username": {
-			Description: "User name.",
+			Description: "User name. Use 'users' instead to define more than one user.",
 			Type:        types.StringType,
-			Required:    true,
+			Optional:    true,
 		},
 		"password": {
-			Description: "User password.",
+			Description: "User password. Use 'users' instead to define more than one user.",
 			Type:        types.StringType,
-			Required:    true,
+			Optional:    true,
 			Sensitive:   true,
 		},
+		"users": {
+			Description: "List of users of the identity provider. Users can be added, " +
+				"removed and have their passwords changed without recreating the " +
+				"identity provider.",
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"username": {
+					Description: "User name.",
+					Type:        types.StringType,
+					Required:    true,
+				},
+				"password": {
+					Description: "User password.",
+					Type:        types.StringType,
+					Required:    true,
+					Sensitive:   true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+			Optional: true,
+		},
 	})
 }
 
+func HTPasswdValidators() []tfsdk.AttributeValidator {
+	errSumm := "Invalid htpasswd IDP resource configuration"
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate htpasswd users",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				state := &HTPasswdIdentityProvider{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, state)
+				if diag.HasError() {
+					// No attribute to validate
+					return
+				}
+				single := !state.Username.Null || !state.Password.Null
+				if single && len(state.Users) > 0 {
+					resp.Diagnostics.AddError(errSumm,
+						"Expected either 'username' and 'password' or 'users', but not both.")
+					return
+				}
+				if single && (state.Username.Null || state.Password.Null) {
+					resp.Diagnostics.AddError(errSumm,
+						"Expected both 'username' and 'password'.")
+					return
+				}
+				if !single && len(state.Users) == 0 {
+					resp.Diagnostics.AddError(errSumm,
+						"Expected at least one user.")
+					return
+				}
+				usernames := map[string]bool{}
+				for _, user := range state.Users {
+					if user.Username.Unknown {
+						continue
+					}
+					if usernames[user.Username.Value] {
+						resp.Diagnostics.AddError(errSumm,
+							fmt.Sprintf("User name '%s' is duplicated.", user.Username.Value))
+						return
+					}
+					usernames[user.Username.Value] = true
+				}
+			},
+		},
+	}
+}
+
+// HTPasswdUsers returns the passwords of the users of the given identity provider, indexed by
+// user name, regardless of how the users are defined.
+func HTPasswdUsers(state *HTPasswdIdentityProvider) map[string]string {
+	result := map[string]string{}
+	if state == nil {
+		return result
+	}
+	if !state.Username.Null && !state.Username.Unknown {
+		result[state.Username.Value] = state.Password.Value
+	}
+	for _, user := range state.Users {
+		result[user.Username.Value] = user.Password.Value
+	}
+	return result
+}
+
 func CreateHTPasswdIDPBuilder(ctx context.Context, state *HTPasswdIdentityProvider) *cmv1.HTPasswdIdentityProviderBuilder {
 	builder := cmv1.NewHTPasswdIdentityProvider()
+	if len(state.Users) > 0 {
+		users := make([]*cmv1.HTPasswdUserBuilder, len(state.Users))
+		for i, user := range state.Users {
+			users[i] = cmv1.NewHTPasswdUser().
+				Username(user.Username.Value).
+				Password(user.Password.Value)
+		}
+		builder.Users(cmv1.NewHTPasswdUserList().Items(users...))
+		return builder
+	}
 	if !state.Username.Null {
 		builder.Username(state.Username.Value)
 	}
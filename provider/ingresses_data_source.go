@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+type IngressesDataSourceType struct {
+}
+
+type IngressesDataSource struct {
+	logger     logging.Logger
+	collection *cmv1.ClustersClient
+}
+
+func (t *IngressesDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "List of the ingresses of a cluster, the default one and the " +
+			"additional ones.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"items": {
+				Description: "Ingresses of the cluster, the default one first.",
+				Attributes: tfsdk.ListNestedAttributes(
+					t.itemAttributes(),
+					tfsdk.ListNestedAttributesOptions{},
+				),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *IngressesDataSourceType) itemAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			Description: "Unique identifier of the ingress.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"default": {
+			Description: "Indicates if this is the default ingress of the cluster.",
+			Type:        types.BoolType,
+			Computed:    true,
+		},
+		"dns_name": {
+			Description: "DNS name of the ingress.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"listening": {
+			Description: "Listening method of the ingress, 'external' or 'internal'.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"route_selectors": {
+			Description: "Labels that the routes must have to be served by the ingress.",
+			Type: types.MapType{
+				ElemType: types.StringType,
+			},
+			Computed: true,
+		},
+	}
+}
+
+func (t *IngressesDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &IngressesDataSource{
+		logger:     parent.logger,
+		collection: parent.connection.ClustersMgmt().V1().Clusters(),
+	}
+	return
+}
+
+func (s *IngressesDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &IngressesState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the ingresses:
+	list, err := s.collection.Cluster(state.Cluster.Value).Ingresses().List().SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't list ingresses",
+			fmt.Sprintf(
+				"Can't list ingresses for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state, with the default ingress first:
+	state.Items = []*IngressesItemState{}
+	list.Items().Each(func(ingress *cmv1.Ingress) bool {
+		if ingress.Default() {
+			state.Items = append(state.Items, ingressesItemState(ingress))
+		}
+		return true
+	})
+	list.Items().Each(func(ingress *cmv1.Ingress) bool {
+		if !ingress.Default() {
+			state.Items = append(state.Items, ingressesItemState(ingress))
+		}
+		return true
+	})
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// ingressesItemState copies the data from the API object to an item of the Terraform state.
+func ingressesItemState(object *cmv1.Ingress) *IngressesItemState {
+	item := &IngressesItemState{
+		ID: types.String{
+			Value: object.ID(),
+		},
+		Default: types.Bool{
+			Value: object.Default(),
+		},
+		DNSName: types.String{
+			Value: object.DNSName(),
+		},
+		Listening: types.String{
+			Value: string(object.Listening()),
+		},
+		RouteSelectors: types.Map{
+			ElemType: types.StringType,
+			Elems:    map[string]attr.Value{},
+		},
+	}
+	for k, v := range object.RouteSelectors() {
+		item.RouteSelectors.Elems[k] = types.String{
+			Value: v,
+		}
+	}
+	return item
+}
@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type IngressesState struct {
+	Cluster types.String          `tfsdk:"cluster"`
+	Items   []*IngressesItemState `tfsdk:"items"`
+}
+
+type IngressesItemState struct {
+	ID             types.String `tfsdk:"id"`
+	Default        types.Bool   `tfsdk:"default"`
+	DNSName        types.String `tfsdk:"dns_name"`
+	Listening      types.String `tfsdk:"listening"`
+	RouteSelectors types.Map    `tfsdk:"route_selectors"`
+}
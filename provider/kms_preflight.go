@@ -0,0 +1,236 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// defaultKeyPolicyName is the name of the only policy that KMS keys support.
+const defaultKeyPolicyName = "default"
+
+// Actions that the roles of the cluster need on the KMS key used to encrypt the volumes:
+var (
+	installerKMSKeyActions = []string{
+		"kms:CreateGrant",
+		"kms:DescribeKey",
+		"kms:GenerateDataKeyWithoutPlaintext",
+	}
+	ebsOperatorKMSKeyActions = []string{
+		"kms:CreateGrant",
+		"kms:Decrypt",
+		"kms:DescribeKey",
+		"kms:Encrypt",
+		"kms:GenerateDataKeyWithoutPlaintext",
+		"kms:ReEncryptFrom",
+		"kms:ReEncryptTo",
+	}
+)
+
+// kmsKeyPrincipal is a role that needs to be allowed to use the KMS key of the cluster.
+type kmsKeyPrincipal struct {
+	// description is the role of the principal in the cluster, used in error messages.
+	description string
+
+	// arn is the ARN of the role.
+	arn string
+
+	// actions are the actions that the key policy needs to allow to the role.
+	actions []string
+}
+
+// kmsKeyPrincipals returns the roles of the given cluster that need to use the KMS key. The role
+// of the EBS CSI driver is only included when the operator role prefix is known.
+func kmsKeyPrincipals(state *ClusterRosaClassicState) []kmsKeyPrincipal {
+	if state.Sts == nil || common.IsStringAttributeEmpty(state.Sts.RoleARN) {
+		return nil
+	}
+	result := []kmsKeyPrincipal{{
+		description: "installer role",
+		arn:         state.Sts.RoleARN.Value,
+		actions:     installerKMSKeyActions,
+	}}
+	if common.IsStringAttributeEmpty(state.Sts.OperatorRolePrefix) {
+		return result
+	}
+
+	// The partition, account and path of the operator roles are the ones of the installer role:
+	installerRole, err := arn.Parse(state.Sts.RoleARN.Value)
+	if err != nil {
+		return result
+	}
+	operator, err := cmv1.NewSTSOperator().
+		Namespace("openshift-cluster-csi-drivers").
+		Name("ebs-cloud-credentials").
+		Build()
+	if err != nil {
+		return result
+	}
+	roleName := getRoleName(state.Sts.OperatorRolePrefix.Value, operator)
+	return append(result, kmsKeyPrincipal{
+		description: "EBS CSI driver operator role",
+		arn: getRoleARN(installerRole.Partition, installerRole.AccountID,
+			rolePathFromResource(installerRole.Resource), roleName),
+		actions: ebsOperatorKMSKeyActions,
+	})
+}
+
+// missingKMSKeyActions returns the actions required by the given principal that the given key
+// policy doesn't allow. Statements that allow the root of the account of the principal delegate
+// the decision to IAM, so they are considered to allow the principal.
+func missingKMSKeyActions(document string, principal kmsKeyPrincipal) ([]string, error) {
+	var policy policyDocument
+	err := json.Unmarshal([]byte(document), &policy)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := arn.Parse(principal.arn)
+	if err != nil {
+		return nil, err
+	}
+	accepted := []string{
+		"*",
+		principal.arn,
+		parsed.AccountID,
+		fmt.Sprintf("arn:%s:iam::%s:root", parsed.Partition, parsed.AccountID),
+	}
+	var missing []string
+	for _, action := range principal.actions {
+		allowed := false
+		for _, statement := range policy.Statement {
+			if statement.Effect != "Allow" ||
+				!statementPrincipalMatches(statement.Principal, accepted) ||
+				!statementActionMatches(statement.Action, action) {
+				continue
+			}
+			allowed = true
+			break
+		}
+		if !allowed {
+			missing = append(missing, action)
+		}
+	}
+	return missing, nil
+}
+
+// statementPrincipalMatches checks if the principal of a policy statement, which can be the
+// string '*' or an object, contains one of the accepted AWS principals.
+func statementPrincipalMatches(raw json.RawMessage, accepted []string) bool {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single == "*"
+	}
+	principals := map[string]stringOrSlice{}
+	if json.Unmarshal(raw, &principals) != nil {
+		return false
+	}
+	for _, principal := range principals["AWS"] {
+		for _, candidate := range accepted {
+			if principal == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// statementActionMatches checks if the actions of a policy statement, which may contain
+// wildcards, include the given action. Actions aren't case sensitive.
+func statementActionMatches(actions stringOrSlice, action string) bool {
+	action = strings.ToLower(action)
+	for _, pattern := range actions {
+		matched, err := path.Match(strings.ToLower(pattern), action)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// checkKMSKeyPolicy retrieves the policy of the given key and verifies that it allows the given
+// principals to use the key. It returns one error for each principal that isn't allowed.
+func checkKMSKeyPolicy(client kmsiface.KMSAPI, keyARN string, principals []kmsKeyPrincipal) []error {
+	output, err := client.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(defaultKeyPolicyName),
+	})
+	if err != nil {
+		return []error{fmt.Errorf("can't get the policy of KMS key '%s': %v", keyARN, err)}
+	}
+	var result []error
+	for _, principal := range principals {
+		missing, err := missingKMSKeyActions(aws.StringValue(output.Policy), principal)
+		if err != nil {
+			return append(result, fmt.Errorf(
+				"can't parse the policy of KMS key '%s': %v", keyARN, err,
+			))
+		}
+		if len(missing) > 0 {
+			result = append(result, fmt.Errorf(
+				"the policy of KMS key '%s' doesn't allow principal '%s', the %s, "+
+					"to use actions '%s'",
+				keyARN, principal.arn, principal.description, strings.Join(missing, "', '"),
+			))
+		}
+	}
+	return result
+}
+
+// checkKMSKey verifies, using the AWS API, that the policy of the KMS key given in the
+// 'kms_key_arn' attribute allows the roles of the cluster to use it. The check is skipped with a
+// warning when there are no AWS credentials.
+func (r *ClusterRosaClassicResource) checkKMSKey(ctx context.Context, state *ClusterRosaClassicState,
+	diags *diag.Diagnostics) {
+	if common.IsStringAttributeEmpty(state.KMSKeyArn) {
+		return
+	}
+	principals := kmsKeyPrincipals(state)
+	if len(principals) == 0 {
+		return
+	}
+	key, err := arn.Parse(state.KMSKeyArn.Value)
+	if err != nil {
+		// The syntax of the ARN is checked by the validator of the attribute:
+		return
+	}
+	sess, err := buildSession(key.Region, r.awsSettings)
+	if err != nil {
+		diags.AddWarning(
+			"KMS key not verified",
+			fmt.Sprintf("Can't verify the policy of the KMS key without AWS credentials: %v", err),
+		)
+		return
+	}
+	r.logger.Debug(ctx, "Verifying policy of KMS key '%s'", state.KMSKeyArn.Value)
+	attributePath := tftypes.NewAttributePath().WithAttributeName("kms_key_arn")
+	for _, err := range checkKMSKeyPolicy(kms.New(sess), state.KMSKeyArn.Value, principals) {
+		diags.AddAttributeError(attributePath, preflightSummary, err.Error())
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+// fakeKMSClient returns a fixed key policy.
+type fakeKMSClient struct {
+	kmsiface.KMSAPI
+	policy string
+}
+
+func (c *fakeKMSClient) GetKeyPolicy(input *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error) {
+	return &kms.GetKeyPolicyOutput{
+		Policy: aws.String(c.policy),
+	}, nil
+}
+
+var _ = Describe("KMS key preflight checks", func() {
+	const keyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	const installerARN = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+
+	state := &ClusterRosaClassicState{
+		Sts: &Sts{
+			RoleARN:            types.String{Value: installerARN},
+			OperatorRolePrefix: types.String{Value: "my-cluster"},
+		},
+	}
+
+	It("Includes the installer and EBS CSI driver operator roles", func() {
+		principals := kmsKeyPrincipals(state)
+		Expect(principals).To(HaveLen(2))
+		Expect(principals[0].arn).To(Equal(installerARN))
+		Expect(principals[1].arn).To(Equal(
+			"arn:aws:iam::123456789012:role/my-cluster-openshift-cluster-csi-drivers-ebs-cloud-credentials",
+		))
+	})
+
+	It("Accepts a policy that delegates to IAM", func() {
+		client := &fakeKMSClient{
+			policy: `{
+			  "Version": "2012-10-17",
+			  "Statement": [{
+			    "Effect": "Allow",
+			    "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+			    "Action": "kms:*",
+			    "Resource": "*"
+			  }]
+			}`,
+		}
+		Expect(checkKMSKeyPolicy(client, keyARN, kmsKeyPrincipals(state))).To(BeEmpty())
+	})
+
+	It("Reports the principal and the actions that are missing", func() {
+		client := &fakeKMSClient{
+			policy: `{
+			  "Version": "2012-10-17",
+			  "Statement": [
+			    {
+			      "Effect": "Allow",
+			      "Principal": {"AWS": ["` + installerARN + `"]},
+			      "Action": ["kms:DescribeKey", "kms:CreateGrant"],
+			      "Resource": "*"
+			    },
+			    {
+			      "Effect": "Allow",
+			      "Principal": {"AWS": "arn:aws:iam::123456789012:role/my-cluster-openshift-cluster-csi-drivers-ebs-cloud-credentials"},
+			      "Action": ["kms:Decrypt", "kms:Encrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*", "kms:DescribeKey", "kms:CreateGrant"],
+			      "Resource": "*"
+			    }
+			  ]
+			}`,
+		}
+		errs := checkKMSKeyPolicy(client, keyARN, kmsKeyPrincipals(state))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("principal '" + installerARN + "'"))
+		Expect(errs[0].Error()).To(ContainSubstring("'kms:GenerateDataKeyWithoutPlaintext'"))
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// The resources and data sources are registered with the new 'rhcs_' prefix and also with the
+// legacy 'ocm_' prefix, so that existing configurations and states keep working while users move
+// to the new names. The schemas are identical, so the state of a legacy resource can be moved to
+// the new name removing it from the state and importing it again.
+const (
+	legacyTypePrefix = "ocm_"
+	typePrefix       = "rhcs_"
+)
+
+// deprecatedResourceType is a resource type registered with its legacy name. It behaves exactly
+// like the wrapped type, but its schema is marked as deprecated so that Terraform warns the
+// users that still use it.
+type deprecatedResourceType struct {
+	tfsdk.ResourceType
+	replacement string
+}
+
+func (t *deprecatedResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result, diags = t.ResourceType.GetSchema(ctx)
+	result.DeprecationMessage = deprecationMessage("resource", t.replacement)
+	return
+}
+
+// deprecatedDataSourceType is the equivalent of deprecatedResourceType for data sources.
+type deprecatedDataSourceType struct {
+	tfsdk.DataSourceType
+	replacement string
+}
+
+func (t *deprecatedDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result, diags = t.DataSourceType.GetSchema(ctx)
+	result.DeprecationMessage = deprecationMessage("data source", t.replacement)
+	return
+}
+
+func deprecationMessage(kind, replacement string) string {
+	return fmt.Sprintf(
+		"Use the '%s' %s instead, this name will be removed in a future version of the "+
+			"provider. To keep an existing object remove it from the state with 'terraform "+
+			"state rm' and add it back with 'terraform import' using the new name.",
+		replacement, kind,
+	)
+}
+
+// withLegacyResourceNames returns a map that contains the given resource types with their new
+// names, and also with the corresponding deprecated legacy names.
+func withLegacyResourceNames(resources map[string]tfsdk.ResourceType) map[string]tfsdk.ResourceType {
+	result := map[string]tfsdk.ResourceType{}
+	for name, value := range resources {
+		result[name] = value
+		result[legacyTypeName(name)] = &deprecatedResourceType{
+			ResourceType: value,
+			replacement:  name,
+		}
+	}
+	return result
+}
+
+// withLegacyDataSourceNames is the equivalent of withLegacyResourceNames for data sources.
+func withLegacyDataSourceNames(dataSources map[string]tfsdk.DataSourceType) map[string]tfsdk.DataSourceType {
+	result := map[string]tfsdk.DataSourceType{}
+	for name, value := range dataSources {
+		result[name] = value
+		result[legacyTypeName(name)] = &deprecatedDataSourceType{
+			DataSourceType: value,
+			replacement:    name,
+		}
+	}
+	return result
+}
+
+// legacyTypeName returns the legacy name that corresponds to the given new name.
+func legacyTypeName(name string) string {
+	return legacyTypePrefix + strings.TrimPrefix(name, typePrefix)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Legacy names", func() {
+	ctx := context.Background()
+
+	It("Registers every resource with both names", func() {
+		resources, diags := New().GetResources(ctx)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(resources).To(HaveKey("rhcs_cluster_rosa_classic"))
+		Expect(resources).To(HaveKey("ocm_cluster_rosa_classic"))
+		Expect(resources).To(HaveLen(28))
+
+		schema, diags := resources["rhcs_cluster_rosa_classic"].GetSchema(ctx)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(schema.DeprecationMessage).To(BeEmpty())
+
+		schema, diags = resources["ocm_cluster_rosa_classic"].GetSchema(ctx)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(schema.DeprecationMessage).To(ContainSubstring("'rhcs_cluster_rosa_classic' resource"))
+	})
+
+	It("Registers every data source with both names", func() {
+		dataSources, diags := New().GetDataSources(ctx)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(dataSources).To(HaveLen(32))
+
+		schema, diags := dataSources["ocm_versions"].GetSchema(ctx)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(schema.DeprecationMessage).To(ContainSubstring("'rhcs_versions' data source"))
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"sync"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// lookupCache stores the results of the lookups of versions and machine types, which don't change
+// while the provider runs. It is shared by all the resources and data sources of a provider
+// instance, so that applying many clusters sends each request only once. Errors aren't cached.
+type lookupCache struct {
+	lock         sync.Mutex
+	versions     map[string][]*cmv1.Version
+	machineTypes []*cmv1.MachineType
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		versions: map[string][]*cmv1.Version{},
+	}
+}
+
+// getVersions returns the versions stored with the given key, calling the fetch function to
+// retrieve them the first time. The lock is held while fetching so that concurrent callers wait
+// for the first request instead of sending their own.
+func (c *lookupCache) getVersions(key string,
+	fetch func() ([]*cmv1.Version, error)) ([]*cmv1.Version, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	versions, ok := c.versions[key]
+	if ok {
+		return versions, nil
+	}
+	versions, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.versions[key] = versions
+	return versions, nil
+}
+
+// getMachineTypes returns the machine types, calling the fetch function to retrieve them the first
+// time.
+func (c *lookupCache) getMachineTypes(
+	fetch func() ([]*cmv1.MachineType, error)) ([]*cmv1.MachineType, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.machineTypes != nil {
+		return c.machineTypes, nil
+	}
+	machineTypes, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.machineTypes = machineTypes
+	return machineTypes, nil
+}
+
+// listMachineTypes retrieves all the pages of the machine types collection.
+func listMachineTypes(ctx context.Context, collection *cmv1.MachineTypesClient) ([]*cmv1.MachineType, error) {
+	var listItems []*cmv1.MachineType
+	listSize := 10
+	listPage := 1
+	listRequest := collection.List().Size(listSize)
+	for {
+		listResponse, err := listRequest.SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if listItems == nil {
+			listItems = make([]*cmv1.MachineType, 0, listResponse.Total())
+		}
+		listResponse.Items().Each(func(listItem *cmv1.MachineType) bool {
+			listItems = append(listItems, listItem)
+			return true
+		})
+		if listResponse.Size() < listSize {
+			break
+		}
+		listPage++
+		listRequest.Page(listPage)
+	}
+	return listItems, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Lookup cache", func() {
+	It("Fetches each key of versions only once", func() {
+		cache := newLookupCache()
+		calls := 0
+		fetch := func() ([]*cmv1.Version, error) {
+			calls++
+			version, err := cmv1.NewVersion().ID("openshift-v4.12.1").Build()
+			return []*cmv1.Version{version}, err
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				versions, err := cache.getVersions("stable", fetch)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(HaveLen(1))
+			}()
+		}
+		wg.Wait()
+		Expect(calls).To(Equal(1))
+		_, err := cache.getVersions("fast", fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(2))
+	})
+
+	It("Doesn't cache errors", func() {
+		cache := newLookupCache()
+		calls := 0
+		fetch := func() ([]*cmv1.MachineType, error) {
+			calls++
+			if calls == 1 {
+				return nil, fmt.Errorf("temporary failure")
+			}
+			return []*cmv1.MachineType{}, nil
+		}
+		_, err := cache.getMachineTypes(fetch)
+		Expect(err).To(HaveOccurred())
+		machineTypes, err := cache.getMachineTypes(fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(machineTypes).To(BeEmpty())
+		_, err = cache.getMachineTypes(fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(2))
+	})
+})
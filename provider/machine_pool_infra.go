@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// The nodes of infra machine pools are labeled and tainted with the infra role, so that only the
+// router, registry and monitoring workloads, that tolerate the taint, run there:
+const (
+	infraNodeRoleKey     = "node-role.kubernetes.io/infra"
+	infraNodeTaintEffect = "NoSchedule"
+)
+
+// validateInfraMachinePool checks that infra machine pools can be created in the given cluster,
+// which is only possible for OpenShift Dedicated clusters.
+func validateInfraMachinePool(cluster *cmv1.Cluster) error {
+	if cluster.Product().ID() != osdProduct {
+		return fmt.Errorf(
+			"infra machine pools can only be created in '%s' clusters, but cluster '%s' "+
+				"is a '%s' cluster",
+			osdProduct, cluster.ID(), cluster.Product().ID(),
+		)
+	}
+	return nil
+}
+
+// infraSettings adds the label and taint of infra nodes to the given ones.
+func infraSettings(labels map[string]string,
+	taints []*cmv1.TaintBuilder) (map[string]string, []*cmv1.TaintBuilder) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[infraNodeRoleKey] = ""
+	taints = append(taints, cmv1.NewTaint().Key(infraNodeRoleKey).Effect(infraNodeTaintEffect))
+	return labels, taints
+}
+
+// isInfraTaint checks if the given taint is the one added to infra nodes by the provider.
+func isInfraTaint(taint *cmv1.Taint) bool {
+	return taint.Key() == infraNodeRoleKey && taint.Effect() == infraNodeTaintEffect
+}
+
+// isInfraMachinePool checks if the given machine pool has the label and the taint of infra
+// nodes.
+func isInfraMachinePool(object *cmv1.MachinePool) bool {
+	_, ok := object.Labels()[infraNodeRoleKey]
+	if !ok {
+		return false
+	}
+	for _, taint := range object.Taints() {
+		if isInfraTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
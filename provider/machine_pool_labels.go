@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// The following rules are the same that Kubernetes uses to validate the keys and values of labels
+// and the keys of taints:
+const (
+	qualifiedNameMaxLength = 63
+	labelValueMaxLength    = 63
+	dnsSubdomainMaxLength  = 253
+)
+
+var (
+	qualifiedNameRE = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	labelValueRE    = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+	dnsSubdomainRE  = regexp.MustCompile(
+		`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`,
+	)
+)
+
+// reservedLabelDomains are the domains of the prefixes of labels reserved for Kubernetes.
+var reservedLabelDomains = []string{"kubernetes.io", "k8s.io"}
+
+// taintEffects are the valid effects of taints.
+var taintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// validateQualifiedName checks that the given text is a valid key for a label or a taint, that is
+// an optional DNS subdomain prefix followed by a slash and a name.
+func validateQualifiedName(key string) error {
+	prefix, name := "", key
+	if index := strings.LastIndex(key, "/"); index >= 0 {
+		prefix, name = key[:index], key[index+1:]
+		if prefix == "" {
+			return fmt.Errorf("the prefix of key '%s' must not be empty", key)
+		}
+		if len(prefix) > dnsSubdomainMaxLength {
+			return fmt.Errorf(
+				"the prefix of key '%s' must be no more than %d characters",
+				key, dnsSubdomainMaxLength,
+			)
+		}
+		if !dnsSubdomainRE.MatchString(prefix) {
+			return fmt.Errorf(
+				"the prefix of key '%s' must be a DNS subdomain: lower case "+
+					"alphanumeric characters, '-' or '.', starting and ending with "+
+					"an alphanumeric character",
+				key,
+			)
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("the name of key '%s' must not be empty", key)
+	}
+	if len(name) > qualifiedNameMaxLength {
+		return fmt.Errorf(
+			"the name of key '%s' must be no more than %d characters",
+			key, qualifiedNameMaxLength,
+		)
+	}
+	if !qualifiedNameRE.MatchString(name) {
+		return fmt.Errorf(
+			"the name of key '%s' must consist of alphanumeric characters, '-', '_' "+
+				"or '.', and must start and end with an alphanumeric character",
+			key,
+		)
+	}
+	return nil
+}
+
+// validateLabelValue checks that the given text is a valid value for a label or a taint.
+func validateLabelValue(key, value string) error {
+	if len(value) > labelValueMaxLength {
+		return fmt.Errorf(
+			"the value of key '%s' must be no more than %d characters",
+			key, labelValueMaxLength,
+		)
+	}
+	if !labelValueRE.MatchString(value) {
+		return fmt.Errorf(
+			"the value '%s' of key '%s' must be empty or consist of alphanumeric "+
+				"characters, '-', '_' or '.', and must start and end with an "+
+				"alphanumeric character",
+			value, key,
+		)
+	}
+	return nil
+}
+
+// validateLabel checks the key and value of a label, including that the prefix of the key isn't
+// reserved for Kubernetes.
+func validateLabel(key, value string) error {
+	err := validateQualifiedName(key)
+	if err != nil {
+		return err
+	}
+	if index := strings.LastIndex(key, "/"); index >= 0 {
+		prefix := key[:index]
+		for _, domain := range reservedLabelDomains {
+			if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+				return fmt.Errorf(
+					"the prefix of key '%s' is reserved for Kubernetes",
+					key,
+				)
+			}
+		}
+	}
+	return validateLabelValue(key, value)
+}
+
+// validateTaint checks the key, value and effect of a taint.
+func validateTaint(key, value, effect string) error {
+	err := validateQualifiedName(key)
+	if err != nil {
+		return err
+	}
+	err = validateLabelValue(key, value)
+	if err != nil {
+		return err
+	}
+	for _, valid := range taintEffects {
+		if effect == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"the effect of taint '%s' must be one of '%s' but got '%s'",
+		key, strings.Join(taintEffects, "', '"), effect,
+	)
+}
+
+func labelsValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate labels",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				labels := &types.Map{
+					ElemType: types.StringType,
+				}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, labels)
+				if diag.HasError() || labels.Null || labels.Unknown {
+					// No attribute to validate
+					return
+				}
+				for key, elem := range labels.Elems {
+					value, ok := elem.(types.String)
+					if !ok || value.Unknown || value.Null {
+						// Unknown values are checked when they are known
+						continue
+					}
+					if err := validateLabel(key, value.Value); err != nil {
+						resp.Diagnostics.AddAttributeError(
+							req.AttributePath,
+							"Invalid label",
+							err.Error(),
+						)
+					}
+				}
+			},
+		},
+	}
+}
+
+func taintsValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate taints",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				var taints []Taints
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, &taints)
+				if diag.HasError() {
+					// No attribute to validate
+					return
+				}
+				for _, taint := range taints {
+					if taint.Key.Unknown || taint.Value.Unknown || taint.ScheduleType.Unknown {
+						// Unknown values are checked when they are known
+						continue
+					}
+					err := validateTaint(taint.Key.Value, taint.Value.Value, taint.ScheduleType.Value)
+					if err != nil {
+						resp.Diagnostics.AddAttributeError(
+							req.AttributePath,
+							"Invalid taint",
+							err.Error(),
+						)
+					}
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Machine pool labels and taints", func() {
+	It("Accepts valid labels", func() {
+		Expect(validateLabel("app", "web")).To(Succeed())
+		Expect(validateLabel("example.com/tier", "")).To(Succeed())
+		Expect(validateLabel("my.domain.io/my_key-1", "value.1_a")).To(Succeed())
+	})
+
+	It("Rejects invalid label keys", func() {
+		Expect(validateLabel("", "value")).ToNot(Succeed())
+		Expect(validateLabel("-app", "value")).ToNot(Succeed())
+		Expect(validateLabel("/app", "value")).ToNot(Succeed())
+		Expect(validateLabel("Example.com/app", "value")).ToNot(Succeed())
+		Expect(validateLabel("example.com/", "value")).ToNot(Succeed())
+		Expect(validateLabel(strings.Repeat("a", 64), "value")).ToNot(Succeed())
+	})
+
+	It("Rejects invalid label values", func() {
+		Expect(validateLabel("app", "web server")).ToNot(Succeed())
+		Expect(validateLabel("app", "web-")).ToNot(Succeed())
+		Expect(validateLabel("app", strings.Repeat("a", 64))).ToNot(Succeed())
+	})
+
+	It("Rejects labels with reserved prefixes", func() {
+		err := validateLabel("kubernetes.io/role", "worker")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("reserved"))
+		Expect(validateLabel("node-role.kubernetes.io/infra", "")).ToNot(Succeed())
+		Expect(validateLabel("k8s.io/app", "web")).ToNot(Succeed())
+		Expect(validateLabel("mykubernetes.io/app", "web")).To(Succeed())
+	})
+
+	It("Validates taints", func() {
+		Expect(validateTaint("dedicated", "gpu", "NoSchedule")).To(Succeed())
+		Expect(validateTaint("node-role.kubernetes.io/infra", "", "NoExecute")).To(Succeed())
+		Expect(validateTaint("dedicated", "gpu", "noschedule")).ToNot(Succeed())
+		Expect(validateTaint("dedicated key", "gpu", "NoSchedule")).ToNot(Succeed())
+		Expect(validateTaint("dedicated", "gpu/a", "PreferNoSchedule")).ToNot(Succeed())
+	})
+})
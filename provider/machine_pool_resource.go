@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -41,9 +43,15 @@ var machinepoolNameRE = regexp.MustCompile(
 	`^[a-z]([-a-z0-9]*[a-z0-9])?$`,
 )
 
+var _ tfsdk.ResourceWithModifyPlan = &MachinePoolResource{}
+
 type MachinePoolResource struct {
-	logger     logging.Logger
-	collection *cmv1.ClustersClient
+	logger                logging.Logger
+	collection            *cmv1.ClustersClient
+	machineTypeCollection *cmv1.MachineTypesClient
+	cache                 *lookupCache
+	refresh               *refreshCache
+	awsSettings           awsSettings
 }
 
 func (t *MachinePoolResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
@@ -76,6 +84,18 @@ func (t *MachinePoolResourceType) GetSchema(ctx context.Context) (result tfsdk.S
 					ValueCannotBeChangedModifier(t.logger),
 				},
 			},
+			"machine_type_category": {
+				Description: "Category of the machine type, as reported by OCM, " +
+					"for example `general_purpose` or `accelerated_computing`.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"gpus": {
+				Description: "Number of GPUs of each node of the pool, zero for " +
+					"machine types without GPUs.",
+				Type:     types.Int64Type,
+				Computed: true,
+			},
 			"replicas": {
 				Description: "The number of machines of the pool",
 				Type:        types.Int64Type,
@@ -112,6 +132,22 @@ func (t *MachinePoolResourceType) GetSchema(ctx context.Context) (result tfsdk.S
 				Type:        types.Int64Type,
 				Optional:    true,
 			},
+			"ignore_autoscaling_replicas": {
+				Description: "When autoscaling is enabled, ignore the number of " +
+					"replicas reported by the server, as it changes whenever the " +
+					"autoscaler adds or removes nodes. Changes to `min_replicas` and " +
+					"`max_replicas` are still detected.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"force": {
+				Description: "Delete the machine pool even if it is the last one of the " +
+					"cluster that can run workloads. Without it deleting that pool fails, " +
+					"as it would leave the cluster without nodes for the workloads. It " +
+					"must be applied before destroying the machine pool.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
 			"taints": {
 				Description: "Taints for machine pool. Format should be a comma-separated " +
 					"list of 'key=value:ScheduleType'. This list will overwrite any modifications " +
@@ -128,13 +164,15 @@ func (t *MachinePoolResourceType) GetSchema(ctx context.Context) (result tfsdk.S
 						Required:    true,
 					},
 					"schedule_type": {
-						Description: "Taints schedule type",
-						Type:        types.StringType,
-						Required:    true,
+						Description: "Taints schedule type, one of 'NoSchedule', " +
+							"'PreferNoSchedule' or 'NoExecute'.",
+						Type:     types.StringType,
+						Required: true,
 					},
 				}, tfsdk.ListNestedAttributesOptions{},
 				),
-				Optional: true,
+				Optional:   true,
+				Validators: taintsValidators(),
 			},
 			"labels": {
 				Description: "Labels for machine pool. Format should be a comma-separated list of 'key = value'." +
@@ -142,7 +180,53 @@ func (t *MachinePoolResourceType) GetSchema(ctx context.Context) (result tfsdk.S
 				Type: types.MapType{
 					ElemType: types.StringType,
 				},
+				Optional:   true,
+				Validators: labelsValidators(),
+			},
+			"subnet_id": {
+				Description: "Identifier of the subnet where the nodes of the pool will be " +
+					"created, for example a subnet of an AWS Local Zone or Wavelength Zone. " +
+					"The cluster must be installed in an existing VPC, and the subnet must " +
+					"belong to that VPC. Nodes in Local Zones " +
+					"and Wavelength Zones get the `" + edgeNodeRoleKey + "` label and a " +
+					"`" + edgeNodeTaintEffect + "` taint with the same key.",
+				Type:     types.StringType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
+			"availability_zone": {
+				Description: "Availability zone where the nodes of the pool will be " +
+					"created, it must be one of the zones of the cluster. By default the " +
+					"nodes of pools of multi-AZ clusters are spread across all the zones. " +
+					"If `subnet_id` is also set, it must be the zone of the subnet.",
+				Type:     types.StringType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
+			"infra": {
+				Description: "Indicates if the nodes of the pool are infra nodes, " +
+					"dedicated to the router, registry and monitoring workloads. Only " +
+					"supported for OpenShift Dedicated clusters. Infra nodes get the `" +
+					infraNodeRoleKey + "` label and a `" + infraNodeTaintEffect +
+					"` taint with the same key.",
+				Type:     types.BoolType,
 				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					ValueCannotBeChangedModifier(t.logger),
+				},
+			},
+			"zone_type": {
+				Description: "Type of the AWS zone of the subnet of the pool, for example " +
+					"`" + localZoneType + "` or `" + wavelengthZoneType + "`.",
+				Type:     types.StringType,
+				Computed: true,
 			},
 		},
 	}
@@ -157,10 +241,17 @@ func (t *MachinePoolResourceType) NewResource(ctx context.Context,
 	// Get the collection of clusters:
 	collection := parent.connection.ClustersMgmt().V1().Clusters()
 
+	// Get the collection of machine types:
+	machineTypeCollection := parent.connection.ClustersMgmt().V1().MachineTypes()
+
 	// Create the resource:
 	result = &MachinePoolResource{
-		logger:     parent.logger,
-		collection: collection,
+		logger:                parent.logger,
+		collection:            collection,
+		machineTypeCollection: machineTypeCollection,
+		cache:                 parent.cache,
+		refresh:               parent.refresh,
+		awsSettings:           parent.awsSettings,
 	}
 
 	return
@@ -175,6 +266,7 @@ func (r *MachinePoolResource) Create(ctx context.Context,
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.Cluster.Value)
 
 	machinepoolName := state.Name.Value
 	if !machinepoolNameRE.MatchString(machinepoolName) {
@@ -191,28 +283,110 @@ func (r *MachinePoolResource) Create(ctx context.Context,
 	resource := r.collection.Cluster(state.Cluster.Value)
 	pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
 	defer cancel()
-	_, err := resource.Poll().
+	pollResponse, err := resource.Poll().
 		Interval(30 * time.Second).
 		Predicate(func(get *cmv1.ClusterGetResponse) bool {
 			return get.Body().State() == cmv1.ClusterStateReady
 		}).
 		StartContext(pollCtx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't poll cluster state",
 			fmt.Sprintf(
 				"Can't poll state of cluster with identifier '%s': %v",
 				state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
 
+	// Get the details of the machine type:
+	machineType, err := findMachineType(ctx, r.cache, r.machineTypeCollection, state.MachineType.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't create machine pool",
+			fmt.Sprintf(
+				"Can't create machine pool for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	populateMachineTypeState(machineType, state)
+
+	// Check that the cluster supports infra machine pools:
+	if state.Infra.Unknown || state.Infra.Null {
+		state.Infra = types.Bool{Value: false}
+	}
+	if state.Infra.Value {
+		err = validateInfraMachinePool(pollResponse.Body())
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't create machine pool",
+				fmt.Sprintf(
+					"Can't create machine pool for cluster '%s': %v",
+					state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+	}
+
 	// Create the machine pool:
 	builder := cmv1.NewMachinePool().ID(state.ID.Value).InstanceType(state.MachineType.Value)
 	builder.ID(state.Name.Value)
 
-	_, errMsg := getSpotInstances(state, builder)
+	// Find the zone of the subnet, as the nodes in Local Zones and Wavelength Zones need the
+	// settings of edge nodes:
+	var subnet *subnetDetails
+	if !state.SubnetID.Unknown && !state.SubnetID.Null {
+		subnet, err = r.findSubnet(pollResponse.Body(), state.SubnetID.Value)
+		if err == nil && !common.IsStringAttributeEmpty(state.AvailabilityZone) &&
+			state.AvailabilityZone.Value != subnet.availabilityZone {
+			err = fmt.Errorf(
+				"subnet '%s' is in availability zone '%s' instead of '%s'",
+				subnet.id, subnet.availabilityZone, state.AvailabilityZone.Value,
+			)
+		}
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't create machine pool",
+				fmt.Sprintf(
+					"Can't create machine pool for cluster '%s': %v",
+					state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+		builder.Subnets(subnet.id)
+		builder.AvailabilityZones(subnet.availabilityZone)
+	} else if !common.IsStringAttributeEmpty(state.AvailabilityZone) {
+		err = validateAvailabilityZone(pollResponse.Body(), state.AvailabilityZone.Value)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't create machine pool",
+				fmt.Sprintf(
+					"Can't create machine pool for cluster '%s': %v",
+					state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+		builder.AvailabilityZones(state.AvailabilityZone.Value)
+	}
+
+	awsMachinePool := cmv1.NewAWSMachinePool()
+	_, errMsg := getSpotInstances(state, awsMachinePool)
 	if errMsg != "" {
 		response.Diagnostics.AddError(
 			"Can't build machine pool",
@@ -223,6 +397,10 @@ func (r *MachinePoolResource) Create(ctx context.Context,
 		return
 	}
 
+	if !awsMachinePool.Empty() {
+		builder.AWS(awsMachinePool)
+	}
+
 	autoscalingEnabled := false
 	computeNodeEnabled := false
 	autoscalingEnabled, errMsg = getAutoscaling(state, builder)
@@ -251,30 +429,47 @@ func (r *MachinePoolResource) Create(ctx context.Context,
 		return
 	}
 
-	if state.Taints != nil && len(state.Taints) > 0 {
-		var taintBuilders []*cmv1.TaintBuilder
-		for _, taint := range state.Taints {
-			taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(taint.Key.Value).Value(taint.Value.Value).Effect(taint.ScheduleType.Value))
-		}
-		builder.Taints(taintBuilders...)
+	var taintBuilders []*cmv1.TaintBuilder
+	for _, taint := range state.Taints {
+		taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(taint.Key.Value).Value(taint.Value.Value).Effect(taint.ScheduleType.Value))
 	}
 
+	var labels map[string]string
 	if !state.Labels.Unknown && !state.Labels.Null {
-		labels := map[string]string{}
+		labels = map[string]string{}
 		for k, v := range state.Labels.Elems {
 			labels[k] = v.(types.String).Value
 		}
+	}
+
+	state.ZoneType = types.String{Null: true}
+	if subnet != nil {
+		state.ZoneType = types.String{Value: subnet.zoneType}
+		if isEdgeZone(subnet.zoneType) {
+			labels, taintBuilders = edgeSettings(labels, taintBuilders)
+		}
+	}
+	if state.Infra.Value {
+		labels, taintBuilders = infraSettings(labels, taintBuilders)
+	}
+
+	if len(taintBuilders) > 0 {
+		builder.Taints(taintBuilders...)
+	}
+	if labels != nil {
 		builder.Labels(labels)
 	}
 
 	object, err := builder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't build machine pool",
 			fmt.Sprintf(
 				"Can't build machine pool for cluster '%s': %v",
 				state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -282,12 +477,14 @@ func (r *MachinePoolResource) Create(ctx context.Context,
 	collection := resource.MachinePools()
 	add, err := collection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't create machine pool",
 			fmt.Sprintf(
 				"Can't create machine pool for cluster '%s': %v",
 				state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -309,30 +506,149 @@ func (r *MachinePoolResource) Read(ctx context.Context, request tfsdk.ReadResour
 		return
 	}
 
-	// Find the machine pool:
-	resource := r.collection.Cluster(state.Cluster.Value).
-		MachinePools().
-		MachinePool(state.ID.Value)
-	get, err := resource.Get().SendContext(ctx)
+	// Find the machine pool, the machine pools of the cluster are retrieved together and shared
+	// with the other machine pools that are read during the same refresh:
+	object, err := r.refresh.getMachinePool(ctx, r.collection, state.Cluster.Value, state.ID.Value)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find machine pool",
 			fmt.Sprintf(
 				"Can't find machine pool with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
-	object := get.Body()
 
 	// Save the state:
 	r.populateState(object, state)
+	if state.GPUs.Null || state.GPUs.Unknown {
+		// The details of the machine type are missing after importing the machine pool:
+		machineType, err := findMachineType(ctx, r.cache, r.machineTypeCollection, state.MachineType.Value)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't find machine type",
+				fmt.Sprintf(
+					"Can't find machine type of machine pool '%s' for cluster '%s': %v",
+					state.ID.Value, state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+		populateMachineTypeState(machineType, state)
+	}
 	diags = response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
 
+// findSubnet retrieves the details of the given subnet, which must be in the VPC of the cluster.
+func (r *MachinePoolResource) findSubnet(cluster *cmv1.Cluster, subnetID string) (*subnetDetails, error) {
+	if len(cluster.AWS().SubnetIDs()) == 0 {
+		return nil, fmt.Errorf(
+			"can't use subnet '%s' because cluster '%s' isn't installed in an existing VPC",
+			subnetID, cluster.ID(),
+		)
+	}
+	sess, err := buildSession(cluster.Region().ID(), r.awsSettings)
+	if err != nil {
+		return nil, err
+	}
+	return findClusterSubnet(ec2.New(sess), cluster, subnetID)
+}
+
+// ModifyPlan calculates the details of the machine type, so that they are known at plan time.
+func (r *MachinePoolResource) ModifyPlan(ctx context.Context, request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	plan := &MachinePoolState{}
+	diags := request.Plan.Get(ctx, plan)
+	if diags.HasError() {
+		return
+	}
+
+	// The machine type can't be changed, so the details in the state are still valid:
+	if !request.State.Raw.IsNull() {
+		state := &MachinePoolState{}
+		diags = request.State.Get(ctx, state)
+		if diags.HasError() {
+			return
+		}
+		// Same for the subnet and the availability zone:
+		if plan.SubnetID.Unknown {
+			response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+				tftypes.NewAttributePath().WithAttributeName("subnet_id"),
+				state.SubnetID,
+			)...)
+		}
+		if plan.AvailabilityZone.Unknown {
+			response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+				tftypes.NewAttributePath().WithAttributeName("availability_zone"),
+				state.AvailabilityZone,
+			)...)
+		}
+		if plan.Infra.Unknown {
+			response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+				tftypes.NewAttributePath().WithAttributeName("infra"),
+				state.Infra,
+			)...)
+		}
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+			tftypes.NewAttributePath().WithAttributeName("zone_type"),
+			state.ZoneType,
+		)...)
+		if state.MachineType.Value == plan.MachineType.Value && !state.GPUs.Null {
+			response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+				tftypes.NewAttributePath().WithAttributeName("machine_type_category"),
+				state.MachineTypeCategory,
+			)...)
+			response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+				tftypes.NewAttributePath().WithAttributeName("gpus"),
+				state.GPUs,
+			)...)
+			return
+		}
+	}
+
+	if plan.MachineType.Unknown || plan.MachineType.Null {
+		return
+	}
+	machineType, err := findMachineType(ctx, r.cache, r.machineTypeCollection, plan.MachineType.Value)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("machine_type"),
+			"Invalid machine type",
+			fmt.Sprintf("Can't use machine type '%s': %v", plan.MachineType.Value, err),
+		)
+		return
+	}
+	populateMachineTypeState(machineType, plan)
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("machine_type_category"),
+		plan.MachineTypeCategory,
+	)...)
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("gpus"),
+		plan.GPUs,
+	)...)
+}
+
+// populateMachineTypeState copies the details of the machine type to the Terraform state.
+func populateMachineTypeState(machineType *cmv1.MachineType, state *MachinePoolState) {
+	state.MachineTypeCategory = types.String{
+		Value: string(machineType.Category()),
+	}
+	state.GPUs = types.Int64{
+		Value: machineTypeGPUs(machineType),
+	}
+}
+
 func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
 	response *tfsdk.UpdateResourceResponse) {
 	var diags diag.Diagnostics
@@ -344,6 +660,7 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.Cluster.Value)
 
 	// Get the plan:
 	plan := &MachinePoolState{}
@@ -359,22 +676,40 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 	_, err := resource.Get().SendContext(ctx)
 
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find machine pool",
 			fmt.Sprintf(
 				"Can't find machine pool with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
 
+	// Send the update:
+	response.Diagnostics.Append(r.update(ctx, state, plan, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// update sends the request that changes the replicas or the autoscaling of the machine pool to
+// the values of the plan, and updates the state with the result. When nodeSettings is true the
+// labels and taints of the plan are also sent.
+func (r *MachinePoolResource) update(ctx context.Context, state, plan *MachinePoolState,
+	nodeSettings bool) (diags diag.Diagnostics) {
 	mpBuilder := cmv1.NewMachinePool().ID(state.ID.Value)
 
 	_, ok := common.ShouldPatchString(state.MachineType, plan.MachineType)
 	if ok {
-		response.Diagnostics.AddError(
+		diags.AddError(
 			"Can't update machine pool",
 			fmt.Sprintf(
 				"Can't update machine pool for cluster '%s', machine type cannot be updated",
@@ -395,7 +730,7 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 
 	autoscalingEnabled, errMsg := getAutoscaling(plan, mpBuilder)
 	if errMsg != "" {
-		response.Diagnostics.AddError(
+		diags.AddError(
 			"Can't update machine pool",
 			fmt.Sprintf(
 				"Can't update machine pool for cluster '%s, %s ", state.Cluster.Value, errMsg,
@@ -405,7 +740,7 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 	}
 
 	if (autoscalingEnabled && computeNodesEnabled) || (!autoscalingEnabled && !computeNodesEnabled) {
-		response.Diagnostics.AddError(
+		diags.AddError(
 			"Can't update machine pool",
 			fmt.Sprintf(
 				"Can't update machine pool for cluster '%s: either autoscaling or compute nodes should be enabled", state.Cluster.Value,
@@ -414,13 +749,35 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 		return
 	}
 
+	if nodeSettings {
+		if !plan.Labels.Unknown && !plan.Labels.Null {
+			labels := map[string]string{}
+			for k, v := range plan.Labels.Elems {
+				labels[k] = v.(types.String).Value
+			}
+			mpBuilder.Labels(labels)
+		}
+		if plan.Taints != nil {
+			var taintBuilders []*cmv1.TaintBuilder
+			for _, taint := range plan.Taints {
+				taintBuilders = append(taintBuilders, cmv1.NewTaint().
+					Key(taint.Key.Value).
+					Value(taint.Value.Value).
+					Effect(taint.ScheduleType.Value))
+			}
+			mpBuilder.Taints(taintBuilders...)
+		}
+	}
+
 	machinePool, err := mpBuilder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&diags,
 			"Can't update machine pool",
 			fmt.Sprintf(
 				"Can't update machine pool for cluster '%s: %v ", state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -428,12 +785,14 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 		MachinePools().
 		MachinePool(state.ID.Value).Update().Body(machinePool).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&diags,
 			"Failed to update machine pool",
 			fmt.Sprintf(
 				"Failed to update machine pool '%s'  on cluster '%s': %v",
 				state.ID.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -444,30 +803,26 @@ func (r *MachinePoolResource) Update(ctx context.Context, request tfsdk.UpdateRe
 	state.AutoScalingEnabled = plan.AutoScalingEnabled
 	// update the Replicas with the plan value (important for nil and zero value cases)
 	state.Replicas = plan.Replicas
+	// update the attributes that only affect the provider with the plan value:
+	state.IgnoreAutoscalingReplicas = plan.IgnoreAutoscalingReplicas
+	state.Force = plan.Force
 
-	// Save the state:
 	r.populateState(object, state)
-	diags = response.State.Set(ctx, state)
-	response.Diagnostics.Append(diags...)
+	return
 }
 
-func getSpotInstances(state *MachinePoolState, mpBuilder *cmv1.MachinePoolBuilder) (
+func getSpotInstances(state *MachinePoolState, awsMachinePool *cmv1.AWSMachinePoolBuilder) (
 	useSpotInstances bool, errMsg string) {
 	useSpotInstances = false
 
 	if !state.UseSpotInstances.Unknown && !state.UseSpotInstances.Null && state.UseSpotInstances.Value {
 		useSpotInstances = true
 
-		awsMachinePool := cmv1.NewAWSMachinePool()
 		spotMarketOptions := cmv1.NewAWSSpotMarketOptions()
 		if !state.MaxSpotPrice.Unknown && !state.MaxSpotPrice.Null {
 			spotMarketOptions.MaxPrice(float64(state.MaxSpotPrice.Value))
 		}
 		awsMachinePool.SpotMarketOptions(spotMarketOptions)
-
-		if !awsMachinePool.Empty() {
-			mpBuilder.AWS(awsMachinePool)
-		}
 	} else {
 		if !state.MaxSpotPrice.Unknown && !state.MaxSpotPrice.Null {
 			return false, "when not using aws spot instances, can't set max_spot_price"
@@ -515,6 +870,41 @@ func (r *MachinePoolResource) Delete(ctx context.Context, request tfsdk.DeleteRe
 	if response.Diagnostics.HasError() {
 		return
 	}
+	defer r.refresh.invalidate(state.Cluster.Value)
+
+	// Check that the cluster will still have nodes for the workloads:
+	force := !state.Force.Unknown && !state.Force.Null && state.Force.Value
+	if !force {
+		list, err := r.collection.Cluster(state.Cluster.Value).
+			MachinePools().
+			List().
+			SendContext(ctx)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't list machine pools",
+				fmt.Sprintf(
+					"Can't list machine pools for cluster '%s': %v",
+					state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+		if otherWorkerPools(list.Items().Slice(), state.ID.Value) == 0 {
+			response.Diagnostics.AddError(
+				"Can't delete machine pool",
+				fmt.Sprintf(
+					"Machine pool '%s' is the last one of cluster '%s' that can run "+
+						"workloads, deleting it would leave the cluster without nodes "+
+						"for them. Set 'force' to 'true' and apply before destroying "+
+						"it to delete it anyhow",
+					state.ID.Value, state.Cluster.Value,
+				),
+			)
+			return
+		}
+	}
 
 	// Send the request to delete the machine pool:
 	resource := r.collection.Cluster(state.Cluster.Value).
@@ -522,13 +912,15 @@ func (r *MachinePoolResource) Delete(ctx context.Context, request tfsdk.DeleteRe
 		MachinePool(state.ID.Value)
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't delete machine pool",
 			fmt.Sprintf(
 				"Can't delete machine pool with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.Value, state.Cluster.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -539,12 +931,28 @@ func (r *MachinePoolResource) Delete(ctx context.Context, request tfsdk.DeleteRe
 
 func (r *MachinePoolResource) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest,
 	response *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(
-		ctx,
+	// The identifier of the machine pool is only unique inside the cluster, so the import
+	// identifier contains both:
+	fields := strings.Split(request.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		response.Diagnostics.AddError(
+			"Invalid import identifier",
+			fmt.Sprintf(
+				"Expected an import identifier like '<cluster_id>,<machine_pool_id>' "+
+					"but got '%s'",
+				request.ID,
+			),
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("cluster"),
+		fields[0],
+	)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
 		tftypes.NewAttributePath().WithAttributeName("id"),
-		request,
-		response,
-	)
+		fields[1],
+	)...)
 }
 
 // populateState copies the data from the API object to the Terraform state.
@@ -557,25 +965,23 @@ func (r *MachinePoolResource) populateState(object *cmv1.MachinePool, state *Mac
 	}
 
 	getAWS, ok := object.GetAWS()
-	if ok {
+	spotMarketOptions, spotOK := getAWS.GetSpotMarketOptions()
+	if ok && spotOK {
 		state.UseSpotInstances = types.Bool{Value: true}
-		spotMarketOptions, ok := getAWS.GetSpotMarketOptions()
-		if ok {
-			if spotMarketOptions.MaxPrice() != 0 {
-				state.MaxSpotPrice = types.Float64{
-					Value: float64(spotMarketOptions.MaxPrice()),
-				}
-			} else {
-				state.MaxSpotPrice.Null = true
+		if spotMarketOptions.MaxPrice() != 0 {
+			state.MaxSpotPrice = types.Float64{
+				Value: float64(spotMarketOptions.MaxPrice()),
 			}
+		} else {
+			state.MaxSpotPrice.Null = true
 		}
 	} else {
 		state.UseSpotInstances.Null = true
 		state.MaxSpotPrice.Null = true
 	}
 
-	autoscaling, ok := object.GetAutoscaling()
-	if ok {
+	autoscaling, autoscalingEnabled := object.GetAutoscaling()
+	if autoscalingEnabled {
 		var minReplicas, maxReplicas int
 		state.AutoScalingEnabled = types.Bool{Value: true}
 		minReplicas, ok = autoscaling.GetMinReplicas()
@@ -605,21 +1011,59 @@ func (r *MachinePoolResource) populateState(object *cmv1.MachinePool, state *Mac
 	}
 
 	replicas, ok := object.GetReplicas()
-	if ok {
+	ignoreReplicas := autoscalingEnabled && !state.IgnoreAutoscalingReplicas.Unknown &&
+		!state.IgnoreAutoscalingReplicas.Null && state.IgnoreAutoscalingReplicas.Value
+	if ok && !ignoreReplicas {
 		state.Replicas = types.Int64{
 			Value: int64(replicas),
 		}
 	}
 
+	subnets := object.Subnets()
+	if len(subnets) == 1 {
+		state.SubnetID = types.String{
+			Value: subnets[0],
+		}
+	} else {
+		state.SubnetID = types.String{Null: true}
+	}
+	availabilityZones := object.AvailabilityZones()
+	if len(availabilityZones) == 1 {
+		state.AvailabilityZone = types.String{
+			Value: availabilityZones[0],
+		}
+	} else {
+		state.AvailabilityZone = types.String{Null: true}
+	}
+	if state.ZoneType.Unknown {
+		state.ZoneType = types.String{Null: true}
+	}
+
+	// Pools that weren't created with this provider, like imported ones, are infra pools if
+	// they have the label and taint of infra nodes:
+	if state.Infra.Unknown || state.Infra.Null {
+		state.Infra = types.Bool{
+			Value: isInfraMachinePool(object),
+		}
+	}
+
+	// The label and taint of edge and infra nodes are added by the provider, so they aren't
+	// part of the configuration:
+	edge := isEdgeZone(state.ZoneType.Value)
+	infra := state.Infra.Value
+
 	taints := object.Taints()
 	if len(taints) > 0 {
-		state.Taints = make([]Taints, len(taints))
-		for i, taint := range taints {
-			state.Taints[i] = Taints{
+		state.Taints = nil
+		for _, taint := range taints {
+			if edge && isEdgeTaint(taint) || infra && isInfraTaint(taint) {
+				continue
+			}
+			state.Taints = append(state.Taints, Taints{
 				Key:          types.String{Value: taint.Key()},
 				Value:        types.String{Value: taint.Value()},
 				ScheduleType: types.String{Value: taint.Effect()},
-			}
+			})
 		}
 
 	}
@@ -631,10 +1075,19 @@ func (r *MachinePoolResource) populateState(object *cmv1.MachinePool, state *Mac
 			Elems:    map[string]attr.Value{},
 		}
 		for k, v := range labels {
+			if edge && k == edgeNodeRoleKey || infra && k == infraNodeRoleKey {
+				continue
+			}
 			state.Labels.Elems[k] = types.String{
 				Value: v,
 			}
 		}
+		if (edge || infra) && len(state.Labels.Elems) == 0 {
+			state.Labels = types.Map{
+				ElemType: types.StringType,
+				Null:     true,
+			}
+		}
 
 	}
 
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/thoas/go-funk"
+)
+
+// Types of AWS zones, as reported by the EC2 API:
+const (
+	availabilityZoneType = "availability-zone"
+	localZoneType        = "local-zone"
+	wavelengthZoneType   = "wavelength-zone"
+)
+
+// The nodes in Local Zones and Wavelength Zones are labeled and tainted as edge nodes, so that
+// only the workloads that tolerate the higher cost and lower capacity of those zones run there:
+const (
+	edgeNodeRoleKey     = "node-role.kubernetes.io/edge"
+	edgeNodeTaintEffect = "NoSchedule"
+)
+
+// subnetDetails contains the details of an AWS subnet that are relevant for machine pools.
+type subnetDetails struct {
+	id               string
+	vpcID            string
+	availabilityZone string
+	zoneType         string
+}
+
+// describeSubnet retrieves the details of the given subnet, including the type of the zone that
+// it belongs to.
+func describeSubnet(client ec2iface.EC2API, subnetID string) (*subnetDetails, error) {
+	subnets, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice([]string{subnetID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't describe subnet '%s': %v", subnetID, err)
+	}
+	if len(subnets.Subnets) == 0 {
+		return nil, fmt.Errorf("subnet '%s' doesn't exist", subnetID)
+	}
+	subnet := subnets.Subnets[0]
+	result := &subnetDetails{
+		id:               subnetID,
+		vpcID:            aws.StringValue(subnet.VpcId),
+		availabilityZone: aws.StringValue(subnet.AvailabilityZone),
+		zoneType:         availabilityZoneType,
+	}
+	zones, err := client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            aws.StringSlice([]string{result.availabilityZone}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't describe zone '%s': %v", result.availabilityZone, err)
+	}
+	if len(zones.AvailabilityZones) > 0 && zones.AvailabilityZones[0].ZoneType != nil {
+		result.zoneType = aws.StringValue(zones.AvailabilityZones[0].ZoneType)
+	}
+	return result, nil
+}
+
+// findClusterSubnet retrieves the details of the given subnet and checks that it belongs to the
+// VPC where the cluster is installed. The VPC is the one of the first subnet of the cluster.
+func findClusterSubnet(client ec2iface.EC2API, cluster *cmv1.Cluster,
+	subnetID string) (*subnetDetails, error) {
+	clusterSubnets := cluster.AWS().SubnetIDs()
+	if len(clusterSubnets) == 0 {
+		return nil, fmt.Errorf(
+			"can't use subnet '%s' because cluster '%s' isn't installed in an existing VPC",
+			subnetID, cluster.ID(),
+		)
+	}
+	clusterSubnet, err := describeSubnet(client, clusterSubnets[0])
+	if err != nil {
+		return nil, err
+	}
+	subnet, err := describeSubnet(client, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if subnet.vpcID != clusterSubnet.vpcID {
+		return nil, fmt.Errorf(
+			"subnet '%s' belongs to VPC '%s' but cluster '%s' is installed in VPC '%s'",
+			subnetID, subnet.vpcID, cluster.ID(), clusterSubnet.vpcID,
+		)
+	}
+	return subnet, nil
+}
+
+// validateAvailabilityZone checks that the nodes of a machine pool can be placed in the given
+// availability zone of the cluster.
+func validateAvailabilityZone(cluster *cmv1.Cluster, zone string) error {
+	zones := cluster.Nodes().AvailabilityZones()
+	if !funk.ContainsString(zones, zone) {
+		return fmt.Errorf(
+			"availability zone '%s' isn't one of the zones of cluster '%s', "+
+				"valid zones are %s",
+			zone, cluster.ID(), strings.Join(zones, ", "),
+		)
+	}
+	return nil
+}
+
+// isEdgeZone checks if the given zone type is a Local Zone or a Wavelength Zone.
+func isEdgeZone(zoneType string) bool {
+	return zoneType == localZoneType || zoneType == wavelengthZoneType
+}
+
+// edgeSettings adds the label and taint of edge nodes to the given ones.
+func edgeSettings(labels map[string]string,
+	taints []*cmv1.TaintBuilder) (map[string]string, []*cmv1.TaintBuilder) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[edgeNodeRoleKey] = ""
+	taints = append(taints, cmv1.NewTaint().Key(edgeNodeRoleKey).Effect(edgeNodeTaintEffect))
+	return labels, taints
+}
+
+// isEdgeTaint checks if the given taint is the one added to edge nodes by the provider.
+func isEdgeTaint(taint *cmv1.Taint) bool {
+	return taint.Key() == edgeNodeRoleKey && taint.Effect() == edgeNodeTaintEffect
+}
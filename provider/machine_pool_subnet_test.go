@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// fakeZonesClient returns the subnets and zones stored in maps indexed by identifier and name.
+type fakeZonesClient struct {
+	ec2iface.EC2API
+	subnets map[string]*ec2.Subnet
+	zones   map[string]string
+}
+
+func (c *fakeZonesClient) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	output := &ec2.DescribeSubnetsOutput{}
+	for _, id := range input.SubnetIds {
+		subnet, ok := c.subnets[aws.StringValue(id)]
+		if ok {
+			output.Subnets = append(output.Subnets, subnet)
+		}
+	}
+	return output, nil
+}
+
+func (c *fakeZonesClient) DescribeAvailabilityZones(
+	input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	output := &ec2.DescribeAvailabilityZonesOutput{}
+	for _, name := range input.ZoneNames {
+		zoneType, ok := c.zones[aws.StringValue(name)]
+		if ok {
+			output.AvailabilityZones = append(output.AvailabilityZones, &ec2.AvailabilityZone{
+				ZoneName: name,
+				ZoneType: aws.String(zoneType),
+			})
+		}
+	}
+	return output, nil
+}
+
+func buildVPCCluster(subnetIDs ...string) *cmv1.Cluster {
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		AWS(cmv1.NewAWS().SubnetIDs(subnetIDs...)).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+	return cluster
+}
+
+var _ = Describe("Machine pool subnet", func() {
+	var client *fakeZonesClient
+
+	BeforeEach(func() {
+		client = &fakeZonesClient{
+			subnets: map[string]*ec2.Subnet{
+				"subnet-regular": {
+					SubnetId:         aws.String("subnet-regular"),
+					VpcId:            aws.String("vpc-123"),
+					AvailabilityZone: aws.String("us-east-1a"),
+				},
+				"subnet-other": {
+					SubnetId:         aws.String("subnet-other"),
+					VpcId:            aws.String("vpc-456"),
+					AvailabilityZone: aws.String("us-east-1b"),
+				},
+				"subnet-local": {
+					SubnetId:         aws.String("subnet-local"),
+					VpcId:            aws.String("vpc-123"),
+					AvailabilityZone: aws.String("us-east-1-bos-1a"),
+				},
+			},
+			zones: map[string]string{
+				"us-east-1a":       availabilityZoneType,
+				"us-east-1b":       availabilityZoneType,
+				"us-east-1-bos-1a": localZoneType,
+			},
+		}
+	})
+
+	It("Describes a subnet of a regular availability zone", func() {
+		subnet, err := describeSubnet(client, "subnet-regular")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(subnet.vpcID).To(Equal("vpc-123"))
+		Expect(subnet.availabilityZone).To(Equal("us-east-1a"))
+		Expect(subnet.zoneType).To(Equal(availabilityZoneType))
+		Expect(isEdgeZone(subnet.zoneType)).To(BeFalse())
+	})
+
+	It("Describes a subnet of a Local Zone", func() {
+		subnet, err := describeSubnet(client, "subnet-local")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(subnet.availabilityZone).To(Equal("us-east-1-bos-1a"))
+		Expect(subnet.zoneType).To(Equal(localZoneType))
+		Expect(isEdgeZone(subnet.zoneType)).To(BeTrue())
+	})
+
+	It("Fails if the subnet doesn't exist", func() {
+		_, err := describeSubnet(client, "subnet-missing")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("subnet 'subnet-missing' doesn't exist"))
+	})
+
+	It("Accepts a subnet of the VPC of the cluster", func() {
+		subnet, err := findClusterSubnet(client, buildVPCCluster("subnet-regular"), "subnet-local")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(subnet.id).To(Equal("subnet-local"))
+	})
+
+	It("Rejects a subnet of a different VPC", func() {
+		_, err := findClusterSubnet(client, buildVPCCluster("subnet-regular"), "subnet-other")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"subnet 'subnet-other' belongs to VPC 'vpc-456' but cluster '123' is installed in VPC 'vpc-123'",
+		))
+	})
+
+	It("Rejects a subnet if the cluster isn't installed in an existing VPC", func() {
+		_, err := findClusterSubnet(client, buildVPCCluster(), "subnet-regular")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("isn't installed in an existing VPC"))
+	})
+
+	It("Accepts only the availability zones of the cluster", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("123").
+			Nodes(cmv1.NewClusterNodes().AvailabilityZones("us-east-1a", "us-east-1b")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validateAvailabilityZone(cluster, "us-east-1b")).To(Succeed())
+		err = validateAvailabilityZone(cluster, "us-east-1c")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("valid zones are us-east-1a, us-east-1b"))
+	})
+
+	It("Adds the edge label and taint", func() {
+		labels, taints := edgeSettings(map[string]string{"app": "db"}, nil)
+		Expect(labels).To(HaveKeyWithValue("app", "db"))
+		Expect(labels).To(HaveKeyWithValue(edgeNodeRoleKey, ""))
+		Expect(taints).To(HaveLen(1))
+		taint, err := taints[0].Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isEdgeTaint(taint)).To(BeTrue())
+	})
+
+	It("Recognizes only the edge taint", func() {
+		taint, err := cmv1.NewTaint().Key("app").Value("db").Effect(edgeNodeTaintEffect).Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isEdgeTaint(taint)).To(BeFalse())
+	})
+})
@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// isWorkerPool checks if the given machine pool can run regular workloads, that is if it has
+// nodes, or can have them, and they don't have taints that prevent scheduling.
+func isWorkerPool(pool *cmv1.MachinePool) bool {
+	for _, taint := range pool.Taints() {
+		switch taint.Effect() {
+		case "NoSchedule", "NoExecute":
+			return false
+		}
+	}
+	autoscaling, ok := pool.GetAutoscaling()
+	if ok {
+		return autoscaling.MaxReplicas() > 0
+	}
+	return pool.Replicas() > 0
+}
+
+// otherWorkerPools returns the number of machine pools, excluding the one with the given
+// identifier, that can run regular workloads.
+func otherWorkerPools(pools []*cmv1.MachinePool, id string) int {
+	count := 0
+	for _, pool := range pools {
+		if pool.ID() != id && isWorkerPool(pool) {
+			count++
+		}
+	}
+	return count
+}
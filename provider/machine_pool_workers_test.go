@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Machine pool workers", func() {
+	buildPool := func(builder *cmv1.MachinePoolBuilder) *cmv1.MachinePool {
+		pool, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		return pool
+	}
+
+	worker := buildPool(cmv1.NewMachinePool().ID("worker").Replicas(2))
+	autoscaled := buildPool(cmv1.NewMachinePool().ID("autoscaled").Autoscaling(
+		cmv1.NewMachinePoolAutoscaling().MinReplicas(0).MaxReplicas(3),
+	))
+	empty := buildPool(cmv1.NewMachinePool().ID("empty").Replicas(0))
+	tainted := buildPool(cmv1.NewMachinePool().ID("tainted").Replicas(2).Taints(
+		cmv1.NewTaint().Key("dedicated").Value("gpu").Effect("NoSchedule"),
+	))
+	preferred := buildPool(cmv1.NewMachinePool().ID("preferred").Replicas(2).Taints(
+		cmv1.NewTaint().Key("dedicated").Value("gpu").Effect("PreferNoSchedule"),
+	))
+
+	It("Detects the pools that can run workloads", func() {
+		Expect(isWorkerPool(worker)).To(BeTrue())
+		Expect(isWorkerPool(autoscaled)).To(BeTrue())
+		Expect(isWorkerPool(empty)).To(BeFalse())
+		Expect(isWorkerPool(tainted)).To(BeFalse())
+		Expect(isWorkerPool(preferred)).To(BeTrue())
+	})
+
+	It("Counts the other worker pools", func() {
+		pools := []*cmv1.MachinePool{worker, empty, tainted}
+		Expect(otherWorkerPools(pools, "worker")).To(BeZero())
+		Expect(otherWorkerPools(pools, "tainted")).To(Equal(1))
+	})
+})
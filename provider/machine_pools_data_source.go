@@ -0,0 +1,296 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+type MachinePoolsDataSourceType struct {
+}
+
+type MachinePoolsDataSource struct {
+	logger     logging.Logger
+	collection *cmv1.ClustersClient
+}
+
+func (t *MachinePoolsDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "List of the machine pools of a cluster, including the default one.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"items": {
+				Description: "Machine pools of the cluster.",
+				Attributes: tfsdk.ListNestedAttributes(
+					t.itemAttributes(),
+					tfsdk.ListNestedAttributesOptions{},
+				),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *MachinePoolsDataSourceType) itemAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"id": {
+			Description: "Unique identifier of the machine pool, which is also its name.",
+			Type:        types.StringType,
+			Computed:    true,
+		},
+		"machine_type": {
+			Description: "Identifier of the machine type used by the nodes, for " +
+				"example `r5.xlarge`.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"replicas": {
+			Description: "Number of nodes, null when autoscaling is enabled.",
+			Type:        types.Int64Type,
+			Computed:    true,
+		},
+		"autoscaling_enabled": {
+			Description: "Indicates if autoscaling is enabled.",
+			Type:        types.BoolType,
+			Computed:    true,
+		},
+		"min_replicas": {
+			Description: "Minimum number of nodes when autoscaling is enabled.",
+			Type:        types.Int64Type,
+			Computed:    true,
+		},
+		"max_replicas": {
+			Description: "Maximum number of nodes when autoscaling is enabled.",
+			Type:        types.Int64Type,
+			Computed:    true,
+		},
+		"use_spot_instances": {
+			Description: "Indicates if the nodes are Amazon EC2 Spot Instances.",
+			Type:        types.BoolType,
+			Computed:    true,
+		},
+		"max_spot_price": {
+			Description: "Maximum price for Spot Instances, null when it is the " +
+				"on-demand price.",
+			Type:     types.Float64Type,
+			Computed: true,
+		},
+		"taints": {
+			Description: "Taints of the nodes.",
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"key": {
+					Description: "Key of the taint.",
+					Type:        types.StringType,
+					Computed:    true,
+				},
+				"value": {
+					Description: "Value of the taint.",
+					Type:        types.StringType,
+					Computed:    true,
+				},
+				"schedule_type": {
+					Description: "Effect of the taint, 'NoSchedule', " +
+						"'PreferNoSchedule' or 'NoExecute'.",
+					Type:     types.StringType,
+					Computed: true,
+				},
+			}, tfsdk.ListNestedAttributesOptions{}),
+			Computed: true,
+		},
+		"labels": {
+			Description: "Labels of the nodes.",
+			Type: types.MapType{
+				ElemType: types.StringType,
+			},
+			Computed: true,
+		},
+		"availability_zones": {
+			Description: "Availability zones of the nodes.",
+			Type: types.ListType{
+				ElemType: types.StringType,
+			},
+			Computed: true,
+		},
+		"subnet_ids": {
+			Description: "Identifiers of the subnets of the nodes.",
+			Type: types.ListType{
+				ElemType: types.StringType,
+			},
+			Computed: true,
+		},
+	}
+}
+
+func (t *MachinePoolsDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &MachinePoolsDataSource{
+		logger:     parent.logger,
+		collection: parent.connection.ClustersMgmt().V1().Clusters(),
+	}
+	return
+}
+
+func (s *MachinePoolsDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &MachinePoolsState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the machine pools:
+	pools, err := s.listMachinePools(ctx, state.Cluster.Value)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't list machine pools",
+			fmt.Sprintf(
+				"Can't list machine pools for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state:
+	state.Items = make([]*MachinePoolsItemState, len(pools))
+	for i, pool := range pools {
+		state.Items[i] = machinePoolsItemState(pool)
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// listMachinePools retrieves all the pages of machine pools of the given cluster.
+func (s *MachinePoolsDataSource) listMachinePools(ctx context.Context,
+	clusterID string) ([]*cmv1.MachinePool, error) {
+	var result []*cmv1.MachinePool
+	page := 1
+	size := 100
+	for {
+		response, err := s.collection.Cluster(clusterID).MachinePools().List().
+			Page(page).
+			Size(size).
+			SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.Items().Slice()...)
+		if response.Items().Len() == 0 || len(result) >= response.Total() {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
+
+// machinePoolsItemState copies the data from the API object to an item of the Terraform state.
+func machinePoolsItemState(object *cmv1.MachinePool) *MachinePoolsItemState {
+	item := &MachinePoolsItemState{
+		ID: types.String{
+			Value: object.ID(),
+		},
+		MachineType: types.String{
+			Value: object.InstanceType(),
+		},
+		Replicas: types.Int64{
+			Null: true,
+		},
+		AutoScalingEnabled: types.Bool{
+			Value: false,
+		},
+		MinReplicas: types.Int64{
+			Null: true,
+		},
+		MaxReplicas: types.Int64{
+			Null: true,
+		},
+		UseSpotInstances: types.Bool{
+			Value: false,
+		},
+		MaxSpotPrice: types.Float64{
+			Null: true,
+		},
+		Taints: []Taints{},
+		Labels: types.Map{
+			ElemType: types.StringType,
+			Elems:    map[string]attr.Value{},
+		},
+		AvailabilityZones: common.StringArrayToList(object.AvailabilityZones()),
+		SubnetIDs:         common.StringArrayToList(object.Subnets()),
+	}
+	autoscaling, ok := object.GetAutoscaling()
+	if ok {
+		item.AutoScalingEnabled.Value = true
+		item.MinReplicas = types.Int64{
+			Value: int64(autoscaling.MinReplicas()),
+		}
+		item.MaxReplicas = types.Int64{
+			Value: int64(autoscaling.MaxReplicas()),
+		}
+	} else {
+		item.Replicas = types.Int64{
+			Value: int64(object.Replicas()),
+		}
+	}
+	spotMarketOptions, ok := object.AWS().GetSpotMarketOptions()
+	if ok {
+		item.UseSpotInstances.Value = true
+		if spotMarketOptions.MaxPrice() != 0 {
+			item.MaxSpotPrice = types.Float64{
+				Value: spotMarketOptions.MaxPrice(),
+			}
+		}
+	}
+	for _, taint := range object.Taints() {
+		item.Taints = append(item.Taints, Taints{
+			Key:          types.String{Value: taint.Key()},
+			Value:        types.String{Value: taint.Value()},
+			ScheduleType: types.String{Value: taint.Effect()},
+		})
+	}
+	for k, v := range object.Labels() {
+		item.Labels.Elems[k] = types.String{
+			Value: v,
+		}
+	}
+	return item
+}
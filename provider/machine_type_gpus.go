@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// acceleratedComputingCategory is the category that OCM uses for the machine types that have GPUs
+// or other accelerators.
+const acceleratedComputingCategory = "accelerated_computing"
+
+// awsInstanceGPUs contains the number of GPUs of the AWS instance types that have them. The OCM
+// API reports the category of the machine types, but not the number of GPUs.
+var awsInstanceGPUs = map[string]int64{
+	"p3.2xlarge":    1,
+	"p3.8xlarge":    4,
+	"p3.16xlarge":   8,
+	"p3dn.24xlarge": 8,
+	"p4d.24xlarge":  8,
+	"p4de.24xlarge": 8,
+	"p5.48xlarge":   8,
+	"g4dn.xlarge":   1,
+	"g4dn.2xlarge":  1,
+	"g4dn.4xlarge":  1,
+	"g4dn.8xlarge":  1,
+	"g4dn.16xlarge": 1,
+	"g4dn.12xlarge": 4,
+	"g4dn.metal":    8,
+	"g4ad.xlarge":   1,
+	"g4ad.2xlarge":  1,
+	"g4ad.4xlarge":  1,
+	"g4ad.8xlarge":  2,
+	"g4ad.16xlarge": 4,
+	"g5.xlarge":     1,
+	"g5.2xlarge":    1,
+	"g5.4xlarge":    1,
+	"g5.8xlarge":    1,
+	"g5.16xlarge":   1,
+	"g5.12xlarge":   4,
+	"g5.24xlarge":   4,
+	"g5.48xlarge":   8,
+	"g5g.xlarge":    1,
+	"g5g.2xlarge":   1,
+	"g5g.4xlarge":   1,
+	"g5g.8xlarge":   1,
+	"g5g.16xlarge":  1,
+	"g5g.metal":     2,
+}
+
+// machineTypeGPUs returns the number of GPUs of each node of the given machine type. It is zero
+// for the machine types that aren't in the accelerated computing category.
+func machineTypeGPUs(machineType *cmv1.MachineType) int64 {
+	if string(machineType.Category()) != acceleratedComputingCategory {
+		return 0
+	}
+	return awsInstanceGPUs[machineType.ID()]
+}
@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Machine type GPUs", func() {
+	It("Returns the GPUs of accelerated computing machine types", func() {
+		machineType, err := cmv1.NewMachineType().
+			ID("p3.8xlarge").
+			Category(cmv1.MachineTypeCategory(acceleratedComputingCategory)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(machineTypeGPUs(machineType)).To(BeNumerically("==", 4))
+	})
+
+	It("Returns zero for other machine types", func() {
+		machineType, err := cmv1.NewMachineType().
+			ID("r5.xlarge").
+			Category(cmv1.MachineTypeCategory("memory_optimized")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(machineTypeGPUs(machineType)).To(BeZero())
+	})
+})
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// offeredInstanceTypes returns the set of AWS instance types that are offered in all the given
+// availability zones. When no zone is given it returns the instance types offered in the region.
+func offeredInstanceTypes(client ec2iface.EC2API, region string, zones []string) (map[string]bool, error) {
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeRegion),
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("location"),
+			Values: aws.StringSlice([]string{region}),
+		}},
+	}
+	if len(zones) > 0 {
+		input = &ec2.DescribeInstanceTypeOfferingsInput{
+			LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+			Filters: []*ec2.Filter{{
+				Name:   aws.String("location"),
+				Values: aws.StringSlice(zones),
+			}},
+		}
+	}
+	locations := map[string]map[string]bool{}
+	err := client.DescribeInstanceTypeOfferingsPages(
+		input,
+		func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+			for _, offering := range page.InstanceTypeOfferings {
+				instanceType := aws.StringValue(offering.InstanceType)
+				if locations[instanceType] == nil {
+					locations[instanceType] = map[string]bool{}
+				}
+				locations[instanceType][aws.StringValue(offering.Location)] = true
+			}
+			return true
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	required := len(zones)
+	if required == 0 {
+		required = 1
+	}
+	result := map[string]bool{}
+	for instanceType, offered := range locations {
+		if len(offered) >= required {
+			result[instanceType] = true
+		}
+	}
+	return result, nil
+}
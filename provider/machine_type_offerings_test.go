@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+// fakeOfferingsClient returns the instance types offered in each location, filtered by the
+// location type and locations of the request.
+type fakeOfferingsClient struct {
+	ec2iface.EC2API
+	offerings map[string][]string
+}
+
+func (c *fakeOfferingsClient) DescribeInstanceTypeOfferingsPages(
+	input *ec2.DescribeInstanceTypeOfferingsInput,
+	fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool) error {
+	Expect(input.Filters).To(HaveLen(1))
+	output := &ec2.DescribeInstanceTypeOfferingsOutput{}
+	for _, location := range aws.StringValueSlice(input.Filters[0].Values) {
+		for _, instanceType := range c.offerings[location] {
+			output.InstanceTypeOfferings = append(output.InstanceTypeOfferings, &ec2.InstanceTypeOffering{
+				InstanceType: aws.String(instanceType),
+				Location:     aws.String(location),
+				LocationType: input.LocationType,
+			})
+		}
+	}
+	fn(output, true)
+	return nil
+}
+
+var _ = Describe("Machine type offerings", func() {
+	client := &fakeOfferingsClient{
+		offerings: map[string][]string{
+			"us-east-1":  {"m5.xlarge", "r5.xlarge", "p4d.24xlarge"},
+			"us-east-1a": {"m5.xlarge", "r5.xlarge", "p4d.24xlarge"},
+			"us-east-1b": {"m5.xlarge", "r5.xlarge"},
+			"us-east-1c": {"m5.xlarge"},
+		},
+	}
+
+	It("Returns the instance types offered in the region", func() {
+		offered, err := offeredInstanceTypes(client, "us-east-1", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(offered).To(HaveLen(3))
+	})
+
+	It("Returns only the instance types offered in all the zones", func() {
+		offered, err := offeredInstanceTypes(client, "us-east-1", []string{"us-east-1a", "us-east-1b"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(offered).To(Equal(map[string]bool{
+			"m5.xlarge": true,
+			"r5.xlarge": true,
+		}))
+
+		offered, err = offeredInstanceTypes(client, "us-east-1", []string{"us-east-1a", "us-east-1c"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(offered).To(Equal(map[string]bool{
+			"m5.xlarge": true,
+		}))
+	})
+})
@@ -22,4 +22,6 @@ type MachineTypeState struct {
 	Name          string `tfsdk:"name"`
 	CPU           int64  `tfsdk:"cpu"`
 	RAM           int64  `tfsdk:"ram"`
+	Category      string `tfsdk:"category"`
+	GPUs          int64  `tfsdk:"gpus"`
 }
@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// maxMachineTypeSuggestions is the maximum number of similar machine types suggested when the
+// requested one doesn't exist.
+const maxMachineTypeSuggestions = 3
+
+// maxMachineTypeDistance is the maximum edit distance of the machine types that are suggested.
+const maxMachineTypeDistance = 2
+
+// findMachineType returns the machine type with the given identifier, using the cache of the
+// provider.
+func findMachineType(ctx context.Context, cache *lookupCache, collection *cmv1.MachineTypesClient,
+	id string) (*cmv1.MachineType, error) {
+	machineTypes, err := cache.getMachineTypes(func() ([]*cmv1.MachineType, error) {
+		return listMachineTypes(ctx, collection)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lookupMachineType(machineTypes, "", id)
+}
+
+// lookupMachineType returns the machine type with the given identifier. When a cloud provider is
+// given the machine type must belong to it. If there is no such machine type the error suggests
+// the ones with similar names, to help with typos like 'r5xlarge'.
+func lookupMachineType(machineTypes []*cmv1.MachineType, cloudProvider string,
+	id string) (*cmv1.MachineType, error) {
+	candidates := make([]string, 0, len(machineTypes))
+	for _, machineType := range machineTypes {
+		provider := machineType.CloudProvider().ID()
+		if cloudProvider != "" && provider != "" && provider != cloudProvider {
+			if machineType.ID() == id {
+				return nil, fmt.Errorf(
+					"machine type '%s' is for cloud provider '%s', not '%s'",
+					id, provider, cloudProvider,
+				)
+			}
+			continue
+		}
+		if machineType.ID() == id {
+			return machineType, nil
+		}
+		candidates = append(candidates, machineType.ID())
+	}
+	suggestions := machineTypeSuggestions(candidates, id)
+	if len(suggestions) > 0 {
+		return nil, fmt.Errorf(
+			"machine type '%s' doesn't exist, did you mean '%s'?",
+			id, strings.Join(suggestions, "', '"),
+		)
+	}
+	return nil, fmt.Errorf("machine type '%s' doesn't exist", id)
+}
+
+// machineTypeSuggestions returns the candidates that are close to the given identifier, the
+// closest first. Differences in case and in the separators don't count.
+func machineTypeSuggestions(candidates []string, id string) []string {
+	normalize := func(s string) string {
+		return strings.NewReplacer(".", "", "-", "", "_", "").Replace(strings.ToLower(s))
+	}
+	distances := map[string]int{}
+	for _, candidate := range candidates {
+		distance := editDistance(normalize(candidate), normalize(id))
+		if distance <= maxMachineTypeDistance {
+			distances[candidate] = distance
+		}
+	}
+	result := make([]string, 0, len(distances))
+	for candidate := range distances {
+		result = append(result, candidate)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if distances[result[i]] != distances[result[j]] {
+			return distances[result[i]] < distances[result[j]]
+		}
+		return result[i] < result[j]
+	})
+	if len(result) > maxMachineTypeSuggestions {
+		result = result[:maxMachineTypeSuggestions]
+	}
+	return result
+}
+
+// editDistance calculates the Levenshtein distance between the given strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// checkComputeMachineType verifies at plan time that the compute machine type of a cluster exists
+// for the given cloud provider. It is only checked when the cluster is created or the machine type
+// changes.
+func checkComputeMachineType(ctx context.Context, request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse, cache *lookupCache,
+	collection *cmv1.MachineTypesClient, cloudProvider string) {
+	path := tftypes.NewAttributePath().WithAttributeName("compute_machine_type")
+	plan := types.String{}
+	diags := request.Plan.GetAttribute(ctx, path, &plan)
+	if diags.HasError() || plan.Unknown || plan.Null || plan.Value == "" {
+		return
+	}
+	if !request.State.Raw.IsNull() {
+		state := types.String{}
+		diags = request.State.GetAttribute(ctx, path, &state)
+		if diags.HasError() || state.Value == plan.Value {
+			return
+		}
+	}
+	machineTypes, err := cache.getMachineTypes(func() ([]*cmv1.MachineType, error) {
+		return listMachineTypes(ctx, collection)
+	})
+	if err != nil {
+		response.Diagnostics.AddWarning(
+			"Can't check machine type",
+			fmt.Sprintf("Can't retrieve the machine types to check '%s': %v", plan.Value, err),
+		)
+		return
+	}
+	_, err = lookupMachineType(machineTypes, cloudProvider, plan.Value)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path,
+			"Invalid machine type",
+			fmt.Sprintf("Can't use machine type '%s': %v", plan.Value, err),
+		)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Machine type validation", func() {
+	var machineTypes []*cmv1.MachineType
+
+	BeforeEach(func() {
+		machineTypes = nil
+		for id, provider := range map[string]string{
+			"r5.xlarge":      "aws",
+			"r5.2xlarge":     "aws",
+			"m5.xlarge":      "aws",
+			"custom-4-16384": "gcp",
+		} {
+			machineType, err := cmv1.NewMachineType().
+				ID(id).
+				CloudProvider(cmv1.NewCloudProvider().ID(provider)).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			machineTypes = append(machineTypes, machineType)
+		}
+	})
+
+	It("Finds the machine type of the cloud provider", func() {
+		machineType, err := lookupMachineType(machineTypes, "aws", "r5.xlarge")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(machineType.ID()).To(Equal("r5.xlarge"))
+	})
+
+	It("Rejects a machine type of another cloud provider", func() {
+		_, err := lookupMachineType(machineTypes, "aws", "custom-4-16384")
+		Expect(err).To(MatchError(ContainSubstring("is for cloud provider 'gcp'")))
+	})
+
+	It("Suggests the closest machine types", func() {
+		_, err := lookupMachineType(machineTypes, "aws", "r5xlarge")
+		Expect(err).To(MatchError(
+			"machine type 'r5xlarge' doesn't exist, did you mean 'r5.xlarge', 'm5.xlarge', 'r5.2xlarge'?",
+		))
+	})
+
+	It("Doesn't suggest anything for unrelated names", func() {
+		_, err := lookupMachineType(machineTypes, "aws", "huge")
+		Expect(err).To(MatchError("machine type 'huge' doesn't exist"))
+	})
+
+	It("Calculates the edit distance", func() {
+		Expect(editDistance("", "abc")).To(Equal(3))
+		Expect(editDistance("kitten", "sitting")).To(Equal(3))
+		Expect(editDistance("r5xlarge", "r5xlarge")).To(BeZero())
+	})
+})
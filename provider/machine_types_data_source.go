@@ -35,6 +35,7 @@ type MachineTypesDataSourceType struct {
 type MachineTypesDataSource struct {
 	logger     logging.Logger
 	collection *cmv1.MachineTypesClient
+	cache      *lookupCache
 }
 
 func (t *MachineTypesDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
@@ -45,37 +46,7 @@ func (t *MachineTypesDataSourceType) GetSchema(ctx context.Context) (result tfsd
 			"items": {
 				Description: "Items of the list.",
 				Attributes: tfsdk.ListNestedAttributes(
-					map[string]tfsdk.Attribute{
-						"cloud_provider": {
-							Description: "Unique identifier of the " +
-								"cloud provider where the machine " +
-								"type is supported.",
-							Type:     types.StringType,
-							Computed: true,
-						},
-						"id": {
-							Description: "Unique identifier of the " +
-								"machine type.",
-							Type:     types.StringType,
-							Computed: true,
-						},
-						"name": {
-							Description: "Short name of the machine " +
-								"type.",
-							Type:     types.StringType,
-							Computed: true,
-						},
-						"cpu": {
-							Description: "Number of CPU cores.",
-							Type:        types.Int64Type,
-							Computed:    true,
-						},
-						"ram": {
-							Description: "Amount of RAM in bytes.",
-							Type:        types.Int64Type,
-							Computed:    true,
-						},
-					},
+					machineTypeAttributes(),
 					tfsdk.ListNestedAttributesOptions{},
 				),
 				Computed: true,
@@ -85,6 +56,54 @@ func (t *MachineTypesDataSourceType) GetSchema(ctx context.Context) (result tfsd
 	return
 }
 
+// machineTypeAttributes returns the attributes of the items of the lists of machine types.
+func machineTypeAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"cloud_provider": {
+			Description: "Unique identifier of the " +
+				"cloud provider where the machine " +
+				"type is supported.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"id": {
+			Description: "Unique identifier of the " +
+				"machine type.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"name": {
+			Description: "Short name of the machine " +
+				"type.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"cpu": {
+			Description: "Number of CPU cores.",
+			Type:        types.Int64Type,
+			Computed:    true,
+		},
+		"ram": {
+			Description: "Amount of RAM in bytes.",
+			Type:        types.Int64Type,
+			Computed:    true,
+		},
+		"category": {
+			Description: "Category of the machine " +
+				"type, for example " +
+				"'accelerated_computing'.",
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"gpus": {
+			Description: "Number of GPUs, zero for " +
+				"machine types without GPUs.",
+			Type:     types.Int64Type,
+			Computed: true,
+		},
+	}
+}
+
 func (t *MachineTypesDataSourceType) NewDataSource(ctx context.Context,
 	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
 	// Cast the provider interface to the specific implementation:
@@ -97,6 +116,7 @@ func (t *MachineTypesDataSourceType) NewDataSource(ctx context.Context,
 	result = &MachineTypesDataSource{
 		logger:     parent.logger,
 		collection: collection,
+		cache:      parent.cache,
 	}
 	return
 }
@@ -104,31 +124,15 @@ func (t *MachineTypesDataSourceType) NewDataSource(ctx context.Context,
 func (s *MachineTypesDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
 	response *tfsdk.ReadDataSourceResponse) {
 	// Fetch the complete list of machine types:
-	var listItems []*cmv1.MachineType
-	listSize := 10
-	listPage := 1
-	listRequest := s.collection.List().Size(listSize)
-	for {
-		listResponse, err := listRequest.SendContext(ctx)
-		if err != nil {
-			response.Diagnostics.AddError(
-				"Can't list machine types",
-				err.Error(),
-			)
-			return
-		}
-		if listItems == nil {
-			listItems = make([]*cmv1.MachineType, 0, listResponse.Total())
-		}
-		listResponse.Items().Each(func(listItem *cmv1.MachineType) bool {
-			listItems = append(listItems, listItem)
-			return true
-		})
-		if listResponse.Size() < listSize {
-			break
-		}
-		listPage++
-		listRequest.Page(listPage)
+	listItems, err := s.cache.getMachineTypes(func() ([]*cmv1.MachineType, error) {
+		return listMachineTypes(ctx, s.collection)
+	})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't list machine types",
+			err.Error(),
+		)
+		return
 	}
 
 	// Populate the state:
@@ -136,62 +140,70 @@ func (s *MachineTypesDataSource) Read(ctx context.Context, request tfsdk.ReadDat
 		Items: make([]*MachineTypeState, len(listItems)),
 	}
 	for i, listItem := range listItems {
-		cpuObject := listItem.CPU()
-		cpuValue := cpuObject.Value()
-		cpuUnit := cpuObject.Unit()
-		switch cpuUnit {
-		case "vCPU":
-			// Nothing.
-		default:
-			response.Diagnostics.AddError(
-				"Unknown CPU unit",
-				fmt.Sprintf("Don't know how to convert CPU unit '%s'", cpuUnit),
-			)
-			return
-		}
-		ramObject := listItem.Memory()
-		ramValue := ramObject.Value()
-		ramUnit := ramObject.Unit()
-		switch strings.ToLower(ramUnit) {
-		case "b":
-			// Nothing.
-		case "kb":
-			ramValue *= math.Pow10(3)
-		case "mb":
-			ramValue *= math.Pow10(6)
-		case "gb":
-			ramValue *= math.Pow10(9)
-		case "tb":
-			ramValue *= math.Pow10(12)
-		case "pb":
-			ramValue *= math.Pow10(15)
-		case "kib":
-			ramValue *= math.Pow(2, 10)
-		case "mib":
-			ramValue *= math.Pow(2, 20)
-		case "gib":
-			ramValue *= math.Pow(2, 30)
-		case "tib":
-			ramValue *= math.Pow(2, 40)
-		case "pib":
-			ramValue *= math.Pow(2, 50)
-		default:
+		item, err := machineTypeState(listItem)
+		if err != nil {
 			response.Diagnostics.AddError(
-				"Unknown RAM unit",
-				fmt.Sprintf("Don't know how to convert RAM unit '%s'", ramUnit),
+				"Can't convert machine type",
+				err.Error(),
 			)
 			return
 		}
-		state.Items[i] = &MachineTypeState{
-			CloudProvider: listItem.CloudProvider().ID(),
-			ID:            listItem.ID(),
-			Name:          listItem.Name(),
-			CPU:           int64(cpuValue),
-			RAM:           int64(ramValue),
-		}
+		state.Items[i] = item
 	}
 
 	// Save the state:
 	diags := response.State.Set(ctx, state)
 	response.Diagnostics.Append(diags...)
 }
+
+// machineTypeState converts the given machine type into the state used by the data sources,
+// translating the CPU and RAM to vCPUs and bytes.
+func machineTypeState(machineType *cmv1.MachineType) (*MachineTypeState, error) {
+	cpuObject := machineType.CPU()
+	cpuValue := cpuObject.Value()
+	cpuUnit := cpuObject.Unit()
+	switch cpuUnit {
+	case "vCPU":
+		// Nothing.
+	default:
+		return nil, fmt.Errorf("don't know how to convert CPU unit '%s'", cpuUnit)
+	}
+	ramObject := machineType.Memory()
+	ramValue := ramObject.Value()
+	ramUnit := ramObject.Unit()
+	switch strings.ToLower(ramUnit) {
+	case "b":
+		// Nothing.
+	case "kb":
+		ramValue *= math.Pow10(3)
+	case "mb":
+		ramValue *= math.Pow10(6)
+	case "gb":
+		ramValue *= math.Pow10(9)
+	case "tb":
+		ramValue *= math.Pow10(12)
+	case "pb":
+		ramValue *= math.Pow10(15)
+	case "kib":
+		ramValue *= math.Pow(2, 10)
+	case "mib":
+		ramValue *= math.Pow(2, 20)
+	case "gib":
+		ramValue *= math.Pow(2, 30)
+	case "tib":
+		ramValue *= math.Pow(2, 40)
+	case "pib":
+		ramValue *= math.Pow(2, 50)
+	default:
+		return nil, fmt.Errorf("don't know how to convert RAM unit '%s'", ramUnit)
+	}
+	return &MachineTypeState{
+		CloudProvider: machineType.CloudProvider().ID(),
+		ID:            machineType.ID(),
+		Name:          machineType.Name(),
+		CPU:           int64(cpuValue),
+		RAM:           int64(ramValue),
+		Category:      string(machineType.Category()),
+		GPUs:          machineTypeGPUs(machineType),
+	}, nil
+}
@@ -0,0 +1,466 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// mockPrefix is the prefix of the paths of the clusters management API served by the mock
+// server.
+const mockPrefix = "/api/clusters_mgmt/v1/"
+
+// mockBaseDomain is the DNS domain of the clusters created by the mock server.
+const mockBaseDomain = "mock.openshift.com"
+
+// mockClusterStates are the states that a cluster created by the mock server goes through, one
+// for each time that it is retrieved.
+var mockClusterStates = []string{"pending", "installing", "ready"}
+
+// mockCatalog contains the objects of the collections that the mock server doesn't allow to
+// change.
+var mockCatalog = map[string][]map[string]interface{}{
+	"versions": {
+		{
+			"kind":          "Version",
+			"id":            "openshift-v4.13.0",
+			"raw_id":        "4.13.0",
+			"channel_group": "stable",
+			"enabled":       true,
+			"rosa_enabled":  true,
+			"default":       true,
+		},
+		{
+			"kind":               "Version",
+			"id":                 "openshift-v4.12.20",
+			"raw_id":             "4.12.20",
+			"channel_group":      "stable",
+			"enabled":            true,
+			"rosa_enabled":       true,
+			"available_upgrades": []string{"4.13.0"},
+		},
+	},
+	"machine_types": {
+		mockMachineType("m5.xlarge", "aws", "general_purpose", 4, 16),
+		mockMachineType("r5.xlarge", "aws", "memory_optimized", 4, 32),
+		mockMachineType("c5.2xlarge", "aws", "compute_optimized", 8, 16),
+		mockMachineType("custom-4-16384", "gcp", "general_purpose", 4, 16),
+	},
+	"cloud_providers": {
+		{"kind": "CloudProvider", "id": "aws", "name": "aws", "display_name": "AWS"},
+		{"kind": "CloudProvider", "id": "gcp", "name": "gcp", "display_name": "GCP"},
+	},
+}
+
+// mockMachineType creates the object of a machine type of the mock server catalog.
+func mockMachineType(id, cloudProvider, category string, cpu, memoryGiB int) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":     "MachineType",
+		"id":       id,
+		"name":     id,
+		"category": category,
+		"cloud_provider": map[string]interface{}{
+			"kind": "CloudProviderLink",
+			"id":   cloudProvider,
+		},
+		"cpu": map[string]interface{}{
+			"value": cpu,
+			"unit":  "vCPU",
+		},
+		"memory": map[string]interface{}{
+			"value": memoryGiB << 30,
+			"unit":  "B",
+		},
+	}
+}
+
+// mockServer is an in memory implementation of the parts of the clusters management API used by
+// the provider, so that configurations can be tested without real OCM credentials. Clusters go
+// through the 'pending', 'installing' and 'ready' states, one each time that they are retrieved,
+// and disappear once they have been retrieved in the 'uninstalling' state after being deleted.
+// Other objects, like machine pools and identity providers, are stored as they are received.
+//
+// The objects only live in memory, unless a state file is given. In that case they are loaded
+// from the file when the server starts and saved after every change, so that they are preserved
+// between the runs of Terraform.
+type mockServer struct {
+	lock      sync.Mutex
+	stateFile string
+	mockState
+}
+
+// mockState contains the objects of the mock server, in the format used to save them to the
+// state file.
+type mockState struct {
+	Objects map[string]map[string]interface{} `json:"objects"`
+	Order   map[string][]string               `json:"order"`
+	Reads   map[string]int                    `json:"reads"`
+	Deleted map[string]bool                   `json:"deleted"`
+}
+
+// startMockServer starts the mock server in a random port of the loopback interface and returns
+// its URL. The state file is optional.
+func startMockServer(stateFile string) (server *mockServer, url string, err error) {
+	server = &mockServer{
+		stateFile: stateFile,
+		mockState: mockState{
+			Objects: map[string]map[string]interface{}{},
+			Order:   map[string][]string{},
+			Reads:   map[string]int{},
+			Deleted: map[string]bool{},
+		},
+	}
+	if stateFile != "" {
+		var data []byte
+		data, err = os.ReadFile(stateFile)
+		switch {
+		case err == nil:
+			err = json.Unmarshal(data, &server.mockState)
+			if err != nil {
+				err = fmt.Errorf("can't parse mock state file '%s': %v", stateFile, err)
+				return
+			}
+		case os.IsNotExist(err):
+			err = nil
+		default:
+			return
+		}
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return
+	}
+	go func() {
+		_ = http.Serve(listener, server)
+	}()
+	url = "http://" + listener.Addr().String()
+	return
+}
+
+// save writes the objects to the state file, if there is one.
+func (s *mockServer) save() {
+	if s.stateFile == "" {
+		return
+	}
+	data, err := json.MarshalIndent(s.mockState, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(s.stateFile, data, 0600)
+}
+
+// mockToken returns an unsigned access token that the SDK accepts for the mock server. The
+// server doesn't check it.
+func mockToken() string {
+	encode := func(data interface{}) string {
+		text, _ := json.Marshal(data)
+		return base64.RawURLEncoding.EncodeToString(text)
+	}
+	header := encode(map[string]interface{}{
+		"alg": "none",
+		"typ": "JWT",
+	})
+	claims := encode(map[string]interface{}{
+		"typ": "Bearer",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(365 * 24 * time.Hour).Unix(),
+	})
+	return header + "." + claims + "."
+}
+
+func (s *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, mockPrefix) {
+		s.sendError(w, http.StatusNotFound, "path '%s' isn't supported by the mock server", r.URL.Path)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, mockPrefix), "/")
+	segments := strings.Split(path, "/")
+	isCollection := len(segments)%2 == 1
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	defer s.save()
+	switch {
+	case isCollection && r.Method == http.MethodGet:
+		s.list(w, path)
+	case isCollection && r.Method == http.MethodPost:
+		s.add(w, r, path)
+	case !isCollection && r.Method == http.MethodGet:
+		s.get(w, path)
+	case !isCollection && r.Method == http.MethodPatch:
+		s.update(w, r, path)
+	case !isCollection && r.Method == http.MethodDelete:
+		s.delete(w, path)
+	default:
+		s.sendError(w, http.StatusMethodNotAllowed, "method '%s' isn't supported", r.Method)
+	}
+}
+
+func (s *mockServer) list(w http.ResponseWriter, path string) {
+	items, ok := mockCatalog[path]
+	if !ok {
+		items = []map[string]interface{}{}
+		for _, id := range s.Order[path] {
+			if object, ok := s.Objects[path+"/"+id]; ok {
+				items = append(items, object)
+			}
+		}
+	}
+	s.send(w, http.StatusOK, map[string]interface{}{
+		"kind":  mockKind(path) + "List",
+		"page":  1,
+		"size":  len(items),
+		"total": len(items),
+		"items": items,
+	})
+}
+
+func (s *mockServer) add(w http.ResponseWriter, r *http.Request, path string) {
+	if _, ok := mockCatalog[path]; ok {
+		s.sendError(w, http.StatusMethodNotAllowed, "collection '%s' can't be changed", path)
+		return
+	}
+	object := map[string]interface{}{}
+	err := json.NewDecoder(r.Body).Decode(&object)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, "can't parse body: %v", err)
+		return
+	}
+	id, _ := object["id"].(string)
+	if id == "" {
+		id = strings.ToLower(ksuid.New().String())
+	}
+	if _, exists := s.Objects[path+"/"+id]; exists {
+		s.sendError(w, http.StatusConflict, "object '%s' already exists", id)
+		return
+	}
+	object["id"] = id
+	object["kind"] = mockKind(path)
+	object["href"] = mockPrefix + path + "/" + id
+	if path == "clusters" {
+		s.initCluster(object)
+	}
+	s.store(path, id, object)
+	s.send(w, http.StatusCreated, object)
+}
+
+// initCluster adds to a new cluster the attributes that the real server calculates, and creates
+// its default machine pool and ingress.
+func (s *mockServer) initCluster(object map[string]interface{}) {
+	id := object["id"].(string)
+	name, _ := object["name"].(string)
+	object["state"] = mockClusterStates[0]
+	object["external_id"] = ksuid.New().String()
+	suffix := id
+	if len(suffix) > 5 {
+		suffix = suffix[:5]
+	}
+	object["infra_id"] = fmt.Sprintf("%s-%s", name, suffix)
+	object["creation_timestamp"] = time.Now().UTC().Format(time.RFC3339)
+	object["dns"] = map[string]interface{}{
+		"base_domain": mockBaseDomain,
+	}
+	object["api"] = map[string]interface{}{
+		"url": fmt.Sprintf("https://api.%s.%s:6443", name, mockBaseDomain),
+	}
+	object["console"] = map[string]interface{}{
+		"url": fmt.Sprintf("https://console-openshift-console.apps.%s.%s", name, mockBaseDomain),
+	}
+	version, _ := object["version"].(map[string]interface{})
+	if version == nil {
+		version = map[string]interface{}{
+			"id": "openshift-v4.13.0",
+		}
+		object["version"] = version
+	}
+	versionID, _ := version["id"].(string)
+	version["raw_id"] = strings.TrimPrefix(versionID, "openshift-v")
+	if _, ok := version["channel_group"]; !ok {
+		version["channel_group"] = "stable"
+	}
+	nodes, _ := object["nodes"].(map[string]interface{})
+	pool := map[string]interface{}{
+		"kind": "MachinePool",
+		"id":   "worker",
+		"href": mockPrefix + "clusters/" + id + "/machine_pools/worker",
+	}
+	if nodes != nil {
+		for source, target := range map[string]string{
+			"compute":            "replicas",
+			"autoscale_compute":  "autoscaling",
+			"compute_labels":     "labels",
+			"availability_zones": "availability_zones",
+		} {
+			if value, ok := nodes[source]; ok {
+				pool[target] = value
+			}
+		}
+		if machineType, ok := nodes["compute_machine_type"].(map[string]interface{}); ok {
+			pool["instance_type"] = machineType["id"]
+		}
+	}
+	s.store("clusters/"+id+"/machine_pools", "worker", pool)
+	s.store("clusters/"+id+"/ingresses", "default", map[string]interface{}{
+		"kind":      "Ingress",
+		"id":        "default",
+		"href":      mockPrefix + "clusters/" + id + "/ingresses/default",
+		"default":   true,
+		"listening": "external",
+		"dns_name":  fmt.Sprintf("apps.%s.%s", name, mockBaseDomain),
+	})
+}
+
+func (s *mockServer) get(w http.ResponseWriter, path string) {
+	object, ok := s.find(path)
+	if !ok {
+		s.sendError(w, http.StatusNotFound, "object '%s' doesn't exist", path)
+		return
+	}
+	if strings.HasPrefix(path, "clusters/") && strings.Count(path, "/") == 1 {
+		reads := s.Reads[path]
+		s.Reads[path] = reads + 1
+		if s.Deleted[path] {
+			if reads > 0 {
+				s.remove(path)
+				s.sendError(w, http.StatusNotFound, "cluster '%s' doesn't exist", path)
+				return
+			}
+		} else if reads < len(mockClusterStates) {
+			object["state"] = mockClusterStates[reads]
+		}
+	}
+	s.send(w, http.StatusOK, object)
+}
+
+func (s *mockServer) update(w http.ResponseWriter, r *http.Request, path string) {
+	object, ok := s.find(path)
+	if !ok {
+		s.sendError(w, http.StatusNotFound, "object '%s' doesn't exist", path)
+		return
+	}
+	patch := map[string]interface{}{}
+	err := json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, "can't parse body: %v", err)
+		return
+	}
+	mockMerge(object, patch)
+	s.send(w, http.StatusOK, object)
+}
+
+func (s *mockServer) delete(w http.ResponseWriter, path string) {
+	object, ok := s.find(path)
+	if !ok {
+		s.sendError(w, http.StatusNotFound, "object '%s' doesn't exist", path)
+		return
+	}
+	if strings.HasPrefix(path, "clusters/") && strings.Count(path, "/") == 1 {
+		// Clusters are uninstalled, and disappear the next time that they are retrieved:
+		object["state"] = "uninstalling"
+		s.Deleted[path] = true
+		s.Reads[path] = 0
+	} else {
+		s.remove(path)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// find returns the object stored with the given path. Objects of the catalog can also be
+// retrieved.
+func (s *mockServer) find(path string) (map[string]interface{}, bool) {
+	object, ok := s.Objects[path]
+	if ok {
+		return object, true
+	}
+	index := strings.LastIndex(path, "/")
+	for _, item := range mockCatalog[path[:index]] {
+		if item["id"] == path[index+1:] {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func (s *mockServer) store(collection, id string, object map[string]interface{}) {
+	path := collection + "/" + id
+	if _, exists := s.Objects[path]; !exists {
+		s.Order[collection] = append(s.Order[collection], id)
+	}
+	s.Objects[path] = object
+}
+
+// remove deletes the object with the given path and all the objects inside it.
+func (s *mockServer) remove(path string) {
+	for key := range s.Objects {
+		if key == path || strings.HasPrefix(key, path+"/") {
+			delete(s.Objects, key)
+		}
+	}
+	delete(s.Reads, path)
+	delete(s.Deleted, path)
+}
+
+func (s *mockServer) send(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *mockServer) sendError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	s.send(w, status, map[string]interface{}{
+		"kind":   "Error",
+		"id":     fmt.Sprintf("%d", status),
+		"code":   fmt.Sprintf("CLUSTERS-MGMT-%d", status),
+		"reason": fmt.Sprintf(format, args...),
+	})
+}
+
+// mockKind calculates the kind of the objects of the given collection, for example 'MachinePool'
+// for 'clusters/123/machine_pools'.
+func mockKind(path string) string {
+	name := path[strings.LastIndex(path, "/")+1:]
+	name = strings.TrimSuffix(name, "s")
+	var result strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word != "" {
+			result.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return result.String()
+}
+
+// mockMerge applies the given patch to the object, merging nested objects.
+func mockMerge(object, patch map[string]interface{}) {
+	for key, value := range patch {
+		nested, ok := value.(map[string]interface{})
+		current, isObject := object[key].(map[string]interface{})
+		if ok && isObject {
+			mockMerge(current, nested)
+			continue
+		}
+		object[key] = value
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Mock server", func() {
+	var url string
+
+	BeforeEach(func() {
+		var err error
+		_, url, err = startMockServer("")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	send := func(method, path, body string) (status int, href string) {
+		request, err := http.NewRequest(method, url+path, bytes.NewBufferString(body))
+		Expect(err).ToNot(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		defer response.Body.Close()
+		object := map[string]interface{}{}
+		_ = json.NewDecoder(response.Body).Decode(&object)
+		href, _ = object["href"].(string)
+		status = response.StatusCode
+		return
+	}
+
+	It("Keeps clusters until they are retrieved after being deleted", func() {
+		status, href := send(http.MethodPost, mockPrefix+"clusters", `{"name": "my-cluster"}`)
+		Expect(status).To(Equal(http.StatusCreated))
+		Expect(href).ToNot(BeEmpty())
+
+		status, _ = send(http.MethodGet, href, "")
+		Expect(status).To(Equal(http.StatusOK))
+		status, _ = send(http.MethodGet, href+"/machine_pools/worker", "")
+		Expect(status).To(Equal(http.StatusOK))
+		status, _ = send(http.MethodDelete, href, "")
+		Expect(status).To(Equal(http.StatusNoContent))
+		status, _ = send(http.MethodGet, href, "")
+		Expect(status).To(Equal(http.StatusOK))
+		status, _ = send(http.MethodGet, href, "")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+
+	It("Preserves the objects in the state file", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "mock.json")
+		var err error
+		_, url, err = startMockServer(file)
+		Expect(err).ToNot(HaveOccurred())
+		status, href := send(http.MethodPost, mockPrefix+"clusters", `{"name": "my-cluster"}`)
+		Expect(status).To(Equal(http.StatusCreated))
+		Expect(file).To(BeAnExistingFile())
+
+		_, url, err = startMockServer(file)
+		Expect(err).ToNot(HaveOccurred())
+		status, _ = send(http.MethodGet, href, "")
+		Expect(status).To(Equal(http.StatusOK))
+	})
+
+	It("Rejects a state file that can't be parsed", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "mock.json")
+		Expect(os.WriteFile(file, []byte("junk"), 0600)).To(Succeed())
+		_, _, err := startMockServer(file)
+		Expect(err).To(HaveOccurred())
+	})
+})
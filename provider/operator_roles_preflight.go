@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// webIdentityAction is the action that the operator roles need to allow to the OIDC provider of
+// the cluster.
+const webIdentityAction = "sts:AssumeRoleWithWebIdentity"
+
+// webIdentityStatement is the subset of a statement of a trust policy needed to check the trust
+// policies of the operator roles.
+type webIdentityStatement struct {
+	Effect    string                              `json:"Effect"`
+	Action    stringOrSlice                       `json:"Action"`
+	Principal map[string]stringOrSlice            `json:"Principal"`
+	Condition map[string]map[string]stringOrSlice `json:"Condition"`
+}
+
+// checkOperatorRoles verifies, using the AWS API, that the OIDC provider of the cluster is
+// registered in IAM and that all the operator roles exist and trust the service accounts of
+// their operators. It is only possible when the cluster uses an existing OIDC configuration, as
+// otherwise the OIDC provider and the roles are created after the cluster. Problems are reported
+// as errors that contain the names of the missing pieces.
+func (r *ClusterRosaClassicResource) checkOperatorRoles(ctx context.Context,
+	state *ClusterRosaClassicState, diags *diag.Diagnostics) {
+	if state.Sts == nil || common.IsStringAttributeEmpty(state.Sts.OIDCConfigID) ||
+		common.IsStringAttributeEmpty(state.Sts.OperatorRolePrefix) {
+		return
+	}
+
+	// Get the issuer URL of the OIDC configuration:
+	oidcConfig, err := r.oidcConfigCollection.OidcConfig(state.Sts.OIDCConfigID.Value).Get().
+		SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			preflightSummary,
+			fmt.Sprintf(
+				"Can't get OIDC configuration '%s': %v",
+				state.Sts.OIDCConfigID.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	issuer := oidcEndpointHostPath(oidcConfig.Body().IssuerUrl())
+
+	// Get the operators that need roles:
+	list, err := r.awsInquiries.STSCredentialRequests().List().SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			preflightSummary,
+			fmt.Sprintf("Can't get the list of operators that need roles: %v", err),
+			err,
+		)
+		return
+	}
+	version := ""
+	if !common.IsStringAttributeEmpty(state.Version) {
+		version = state.Version.Value
+	}
+	operators := requiredOperators(list.Items().Slice(), version)
+
+	// The partition, account and path of the operator roles are the ones of the installer role:
+	installerRole, err := arn.Parse(state.Sts.RoleARN.Value)
+	if err != nil {
+		diags.AddAttributeError(
+			stsAttributePath("role_arn"),
+			preflightSummary,
+			fmt.Sprintf("Can't parse ARN '%s': %v", state.Sts.RoleARN.Value, err),
+		)
+		return
+	}
+	sess, err := buildSession(state.CloudRegion.Value, r.awsSettings)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			preflightSummary,
+			fmt.Sprintf("Can't verify the operator roles: %v", err),
+			err,
+		)
+		return
+	}
+	iamClient := iam.New(sess)
+	providerARN := fmt.Sprintf(
+		"arn:%s:iam::%s:oidc-provider/%s",
+		installerRole.Partition, installerRole.AccountID, issuer,
+	)
+	r.logger.Debug(ctx, "Verifying OIDC provider '%s'", providerARN)
+	err = checkOIDCProvider(iamClient, providerARN)
+	if err != nil {
+		diags.AddAttributeError(stsAttributePath("oidc_config_id"), preflightSummary, err.Error())
+		return
+	}
+	rolePath := rolePathFromResource(installerRole.Resource)
+	for _, operator := range operators {
+		roleName := getRoleName(state.Sts.OperatorRolePrefix.Value, operator)
+		r.logger.Debug(ctx, "Verifying operator role '%s'", roleName)
+		err = checkOperatorRole(iamClient, roleName, rolePath, providerARN, issuer, operator)
+		if err != nil {
+			diags.AddAttributeError(
+				stsAttributePath("operator_role_prefix"),
+				preflightSummary,
+				err.Error(),
+			)
+		}
+	}
+}
+
+// requiredOperators returns the operators that need roles for clusters of the given version.
+// When the version is empty all the operators are returned.
+func requiredOperators(requests []*cmv1.STSCredentialRequest, version string) []*cmv1.STSOperator {
+	var result []*cmv1.STSOperator
+	for _, request := range requests {
+		operator := request.Operator()
+		if version != "" && operator.MinVersion() != "" {
+			supported, err := common.IsGreaterThanOrEqual(version, operator.MinVersion())
+			if err == nil && !supported {
+				continue
+			}
+		}
+		result = append(result, operator)
+	}
+	return result
+}
+
+// rolePathFromResource returns the path of the role from the resource part of its ARN, for
+// example '/openshift/' for 'role/openshift/my-role'.
+func rolePathFromResource(resource string) string {
+	resource = strings.TrimPrefix(resource, strings.TrimSuffix(roleResourceType, "/"))
+	return resource[:strings.LastIndex(resource, "/")+1]
+}
+
+// checkOIDCProvider verifies that the OIDC provider with the given ARN is registered in IAM.
+func checkOIDCProvider(iamClient iamiface.IAMAPI, providerARN string) error {
+	_, err := iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+	})
+	if err != nil {
+		return fmt.Errorf("can't get OIDC provider '%s': %v", providerARN, err)
+	}
+	return nil
+}
+
+// checkOperatorRole verifies that the role of the given operator exists and that its trust
+// policy allows the service accounts of the operator to assume it using the OIDC provider.
+func checkOperatorRole(iamClient iamiface.IAMAPI, roleName, rolePath, providerARN, issuer string,
+	operator *cmv1.STSOperator) error {
+	output, err := iamClient.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"can't get role '%s' of operator '%s' in namespace '%s': %v",
+			roleName, operator.Name(), operator.Namespace(), err,
+		)
+	}
+	actualPath := aws.StringValue(output.Role.Path)
+	if actualPath != "" && actualPath != rolePath {
+		return fmt.Errorf(
+			"role '%s' has path '%s' but the account roles have path '%s'",
+			roleName, actualPath, rolePath,
+		)
+	}
+	subjects, err := webIdentitySubjects(
+		aws.StringValue(output.Role.AssumeRolePolicyDocument), providerARN, issuer,
+	)
+	if err != nil {
+		return fmt.Errorf("can't parse the trust policy of role '%s': %v", roleName, err)
+	}
+	var missing []string
+	for _, serviceAccount := range operator.ServiceAccounts() {
+		subject := fmt.Sprintf(serviceAccountFmt, operator.Namespace(), serviceAccount)
+		if !subjectAllowed(subjects, subject) {
+			missing = append(missing, subject)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"the trust policy of role '%s' doesn't allow %s to assume it using OIDC "+
+				"provider '%s'",
+			roleName, strings.Join(missing, ", "), providerARN,
+		)
+	}
+	return nil
+}
+
+// webIdentitySubjects returns the subjects, which may contain wildcards, that the given trust
+// policy document, which may be URL encoded as returned by the IAM API, allows to assume the
+// role using the given OIDC provider.
+func webIdentitySubjects(document, providerARN, issuer string) ([]string, error) {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return nil, err
+	}
+	var policy struct {
+		Statement []webIdentityStatement `json:"Statement"`
+	}
+	err = json.Unmarshal([]byte(decoded), &policy)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		allowed := false
+		for _, action := range statement.Action {
+			if action == webIdentityAction || action == "sts:*" || action == "*" {
+				allowed = true
+			}
+		}
+		trusted := false
+		for _, federated := range statement.Principal["Federated"] {
+			if federated == providerARN {
+				trusted = true
+			}
+		}
+		if !allowed || !trusted {
+			continue
+		}
+		for _, values := range statement.Condition {
+			result = append(result, values[issuer+":sub"]...)
+		}
+	}
+	return result, nil
+}
+
+// subjectAllowed checks if the given subject matches any of the given patterns, which use the
+// wildcards of the 'StringLike' condition operator.
+func subjectAllowed(patterns []string, subject string) bool {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, subject)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
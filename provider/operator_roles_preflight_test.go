@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	testIssuer      = "oidc.example.com/abc"
+	testProviderARN = "arn:aws:iam::123456789012:oidc-provider/" + testIssuer
+)
+
+var _ = Describe("Operator roles preflight checks", func() {
+	buildTrustPolicy := func(operator string, subjects string) *string {
+		return aws.String(url.QueryEscape(`{
+		  "Version": "2012-10-17",
+		  "Statement": [{
+		    "Effect": "Allow",
+		    "Principal": {"Federated": "` + testProviderARN + `"},
+		    "Action": "sts:AssumeRoleWithWebIdentity",
+		    "Condition": {"` + operator + `": {"` + testIssuer + `:sub": ` + subjects + `}}
+		  }]
+		}`))
+	}
+
+	iamClient := &fakeIAMClient{
+		roles: map[string]*iam.Role{
+			"prefix-openshift-image-registry-installer-cloud-credentials": {
+				Path: aws.String("/"),
+				AssumeRolePolicyDocument: buildTrustPolicy("StringEquals", `[
+				  "system:serviceaccount:openshift-image-registry:cluster-image-registry-operator",
+				  "system:serviceaccount:openshift-image-registry:registry"
+				]`),
+			},
+			"prefix-openshift-ingress-operator-cloud-credentials": {
+				Path: aws.String("/"),
+				AssumeRolePolicyDocument: buildTrustPolicy("StringLike",
+					`"system:serviceaccount:openshift-ingress-operator:*"`),
+			},
+			"prefix-openshift-machine-api-aws-cloud-credentials": {
+				Path: aws.String("/"),
+				AssumeRolePolicyDocument: buildTrustPolicy("StringEquals",
+					`"system:serviceaccount:openshift-machine-api:other"`),
+			},
+		},
+		providers: map[string]bool{
+			testProviderARN: true,
+		},
+	}
+
+	buildOperator := func(namespace, name, minVersion string, serviceAccounts ...string) *cmv1.STSOperator {
+		operator, err := cmv1.NewSTSOperator().
+			Namespace(namespace).
+			Name(name).
+			MinVersion(minVersion).
+			ServiceAccounts(serviceAccounts...).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return operator
+	}
+
+	It("Accepts a role that trusts all the service accounts", func() {
+		operator := buildOperator("openshift-image-registry", "installer-cloud-credentials", "",
+			"cluster-image-registry-operator", "registry")
+		err := checkOperatorRole(iamClient, getRoleName("prefix", operator), "/",
+			testProviderARN, testIssuer, operator)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Accepts a role that trusts the service accounts with a wildcard", func() {
+		operator := buildOperator("openshift-ingress-operator", "cloud-credentials", "",
+			"ingress-operator")
+		err := checkOperatorRole(iamClient, getRoleName("prefix", operator), "/",
+			testProviderARN, testIssuer, operator)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Reports the service accounts that aren't trusted", func() {
+		operator := buildOperator("openshift-machine-api", "aws-cloud-credentials", "",
+			"machine-api-controllers")
+		err := checkOperatorRole(iamClient, getRoleName("prefix", operator), "/",
+			testProviderARN, testIssuer, operator)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"system:serviceaccount:openshift-machine-api:machine-api-controllers",
+		))
+	})
+
+	It("Reports a missing role by name", func() {
+		operator := buildOperator("openshift-cluster-csi-drivers", "ebs-cloud-credentials", "",
+			"aws-ebs-csi-driver-operator")
+		err := checkOperatorRole(iamClient, getRoleName("prefix", operator), "/",
+			testProviderARN, testIssuer, operator)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"prefix-openshift-cluster-csi-drivers-ebs-cloud-credentials",
+		))
+	})
+
+	It("Reports a role with a different path", func() {
+		operator := buildOperator("openshift-image-registry", "installer-cloud-credentials", "",
+			"cluster-image-registry-operator", "registry")
+		err := checkOperatorRole(iamClient, getRoleName("prefix", operator), "/openshift/",
+			testProviderARN, testIssuer, operator)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Checks that the OIDC provider is registered", func() {
+		Expect(checkOIDCProvider(iamClient, testProviderARN)).To(Succeed())
+		err := checkOIDCProvider(iamClient, "arn:aws:iam::123456789012:oidc-provider/other")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("oidc-provider/other"))
+	})
+
+	It("Calculates the path of the roles", func() {
+		Expect(rolePathFromResource("role/my-role")).To(Equal("/"))
+		Expect(rolePathFromResource("role/openshift/my-role")).To(Equal("/openshift/"))
+	})
+
+	It("Skips the operators that the cluster version doesn't need", func() {
+		request := func(operator *cmv1.STSOperatorBuilder) *cmv1.STSCredentialRequestBuilder {
+			return cmv1.NewSTSCredentialRequest().Operator(operator)
+		}
+		list, err := cmv1.NewSTSCredentialRequestList().Items(
+			request(cmv1.NewSTSOperator().Namespace("a").Name("old")),
+			request(cmv1.NewSTSOperator().Namespace("b").Name("new").MinVersion("4.10")),
+		).Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requiredOperators(list.Slice(), "4.9.0")).To(HaveLen(1))
+		Expect(requiredOperators(list.Slice(), "4.10.3")).To(HaveLen(2))
+		Expect(requiredOperators(list.Slice(), "")).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// Characters used to generate the passwords of the cluster administrator. Characters that are
+// easily confused, like '0' and 'O', are excluded.
+const (
+	passwordLowercase = "abcdefghijkmnopqrstuvwxyz"
+	passwordUppercase = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordDigits    = "23456789"
+)
+
+// passwordLength is the length of the generated passwords. OpenShift requires at least 14
+// characters for the password of the cluster administrator.
+const passwordLength = 23
+
+// generatePassword generates a random password that contains at least one lowercase letter, one
+// uppercase letter and one digit.
+func generatePassword() (string, error) {
+	all := passwordLowercase + passwordUppercase + passwordDigits
+	for {
+		var buffer strings.Builder
+		for i := 0; i < passwordLength; i++ {
+			index, err := rand.Int(rand.Reader, big.NewInt(int64(len(all))))
+			if err != nil {
+				return "", err
+			}
+			buffer.WriteByte(all[index.Int64()])
+		}
+		password := buffer.String()
+		if strings.ContainsAny(password, passwordLowercase) &&
+			strings.ContainsAny(password, passwordUppercase) &&
+			strings.ContainsAny(password, passwordDigits) {
+			return password, nil
+		}
+	}
+}
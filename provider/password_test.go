@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Password generation", func() {
+	It("Generates passwords with all the character classes", func() {
+		for i := 0; i < 100; i++ {
+			password, err := generatePassword()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(password).To(HaveLen(passwordLength))
+			Expect(strings.ContainsAny(password, passwordLowercase)).To(BeTrue())
+			Expect(strings.ContainsAny(password, passwordUppercase)).To(BeTrue())
+			Expect(strings.ContainsAny(password, passwordDigits)).To(BeTrue())
+		}
+	})
+
+	It("Generates different passwords", func() {
+		first, err := generatePassword()
+		Expect(err).ToNot(HaveOccurred())
+		second, err := generatePassword()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first).ToNot(Equal(second))
+	})
+})
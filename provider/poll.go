@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+const (
+	// initialPollInterval is the time to wait after the first check of a wait loop. It doubles
+	// after each check till it reaches the poll interval configured in the provider.
+	initialPollInterval = 5 * time.Second
+
+	// defaultPollIntervalInSeconds is the maximum time between checks of a wait loop when the
+	// 'poll_interval' attribute of the provider isn't set.
+	defaultPollIntervalInSeconds = int64(60)
+)
+
+// pollWithBackoff calls the check function till it reports that it is done, it returns an error
+// or the context finishes. The time between checks starts with a short interval and doubles after
+// each check, up to the given maximum. When it finishes it writes to the debug log the number of
+// checks, the total duration and the part of it spent sleeping between checks.
+func pollWithBackoff(ctx context.Context, maxInterval time.Duration,
+	check func(ctx context.Context) (done bool, err error)) error {
+	interval := initialPollInterval
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	start := time.Now()
+	checks := 0
+	var waited time.Duration
+	defer func() {
+		tflog.Debug(
+			ctx, "Finished waiting",
+			"checks", checks,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"sleep_ms", waited.Milliseconds(),
+		)
+	}()
+	for {
+		checks++
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		timer := time.NewTimer(interval)
+		sleepStart := time.Now()
+		select {
+		case <-timer.C:
+			waited += time.Since(sleepStart)
+		case <-ctx.Done():
+			timer.Stop()
+			waited += time.Since(sleepStart)
+			return ctx.Err()
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// pollCluster retrieves the given cluster, with exponential backoff, till the predicate returns
+// true. It returns the last version of the cluster and the identifier of the operation that
+// returned it. Transient errors of the server or the network don't stop the wait.
+func pollCluster(ctx context.Context, resource *cmv1.ClusterClient, maxInterval time.Duration,
+	predicate func(object *cmv1.Cluster) bool) (object *cmv1.Cluster, operationID string, err error) {
+	err = pollWithBackoff(ctx, maxInterval, tolerateTransientErrors(func(ctx context.Context) (bool, error) {
+		get, err := resource.Get().SendContext(ctx)
+		if err != nil {
+			return false, err
+		}
+		object = get.Body()
+		operationID = get.Header().Get(operationIDHeader)
+		return predicate(object), nil
+	}))
+	return
+}
+
+// pollClusterTillNotFound retrieves the given cluster, with exponential backoff, till the server
+// reports that it doesn't exist. Transient errors of the server or the network don't stop the
+// wait. If the cluster goes to the error state after it started uninstalling the wait stops
+// with a *clusterUninstallError.
+func pollClusterTillNotFound(ctx context.Context, resource *cmv1.ClusterClient,
+	maxInterval time.Duration) error {
+	uninstalling := false
+	return pollWithBackoff(ctx, maxInterval, tolerateTransientErrors(func(ctx context.Context) (bool, error) {
+		get, err := resource.Get().SendContext(ctx)
+		if err != nil {
+			sdkErr, ok := err.(*errors.Error)
+			if ok && sdkErr.Status() == http.StatusNotFound {
+				return true, nil
+			}
+			return false, err
+		}
+		object := get.Body()
+		switch object.State() {
+		case cmv1.ClusterStateUninstalling:
+			uninstalling = true
+		case cmv1.ClusterStateError:
+			if uninstalling {
+				return false, &clusterUninstallError{
+					id:     object.ID(),
+					reason: uninstallErrorReason(object),
+				}
+			}
+		}
+		return false, nil
+	}))
+}
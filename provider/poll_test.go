@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Poll with backoff", func() {
+	It("Checks till done, never waiting more than the maximum interval", func() {
+		var times []time.Time
+		err := pollWithBackoff(context.Background(), 10*time.Millisecond,
+			func(ctx context.Context) (bool, error) {
+				times = append(times, time.Now())
+				return len(times) == 4, nil
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(times).To(HaveLen(4))
+		for i := 1; i < len(times); i++ {
+			Expect(times[i].Sub(times[i-1])).To(BeNumerically("<", time.Second))
+		}
+	})
+
+	It("Stops when the check fails", func() {
+		calls := 0
+		err := pollWithBackoff(context.Background(), time.Millisecond,
+			func(ctx context.Context) (bool, error) {
+				calls++
+				return false, fmt.Errorf("failed")
+			},
+		)
+		Expect(err).To(MatchError("failed"))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("Stops when the context finishes", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := pollWithBackoff(ctx, time.Minute,
+			func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+		)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+})
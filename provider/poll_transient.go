@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// maxTransientPollErrors is the number of consecutive transient errors that a wait loop ignores
+// before giving up. With the default poll interval it tolerates outages of several minutes.
+const maxTransientPollErrors = 5
+
+// transientPollStatuses are the response statuses that indicate that the OCM API, or a proxy in
+// front of it, is temporarily unavailable.
+var transientPollStatuses = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// htmlResponseError is the beginning of the error that the SDK returns when the response isn't
+// JSON but an HTML page. The status isn't part of that error, but HTML pages are what proxies and
+// load balancers in front of the OCM API send when it is temporarily unavailable.
+const htmlResponseError = "expected response content type 'application/json' but received " +
+	"'text/html'"
+
+// isTransientPollError checks if the given error is caused by a temporary unavailability of the
+// server or of the network, so that repeating the request later may succeed. Other network
+// errors, like the ones caused by invalid certificates, won't go away by waiting, so they aren't
+// transient.
+func isTransientPollError(err error) bool {
+	var sdkErr *ocmerrors.Error
+	if errors.As(err, &sdkErr) {
+		return transientPollStatuses[sdkErr.Status()]
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	if err != nil && strings.Contains(err.Error(), htmlResponseError) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// tolerateTransientErrors wraps the given check so that transient errors are treated as a check
+// that isn't done yet, instead of aborting the wait. After maxTransientPollErrors consecutive
+// transient errors the last one is returned.
+func tolerateTransientErrors(check func(ctx context.Context) (bool, error)) func(ctx context.Context) (bool, error) {
+	failures := 0
+	return func(ctx context.Context) (bool, error) {
+		done, err := check(ctx)
+		if err == nil {
+			failures = 0
+			return done, nil
+		}
+		if ctx.Err() == nil && isTransientPollError(err) && failures < maxTransientPollErrors {
+			failures++
+			return false, nil
+		}
+		return done, err
+	}
+}
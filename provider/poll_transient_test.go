@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+var _ = Describe("Transient poll errors", func() {
+	statusError := func(status int) error {
+		err, buildErr := ocmerrors.NewError().Status(status).Reason("failed").Build()
+		Expect(buildErr).ToNot(HaveOccurred())
+		return err
+	}
+
+	It("Classifies the errors", func() {
+		Expect(isTransientPollError(statusError(http.StatusBadGateway))).To(BeTrue())
+		Expect(isTransientPollError(statusError(http.StatusServiceUnavailable))).To(BeTrue())
+		Expect(isTransientPollError(statusError(http.StatusGatewayTimeout))).To(BeTrue())
+		Expect(isTransientPollError(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED})).To(BeTrue())
+		Expect(isTransientPollError(&net.OpError{
+			Op:  "read",
+			Err: os.NewSyscallError("read", syscall.ECONNRESET),
+		})).To(BeTrue())
+		Expect(isTransientPollError(&net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded})).To(BeTrue())
+		Expect(isTransientPollError(&net.OpError{Op: "dial", Err: fmt.Errorf("no such host")})).To(BeFalse())
+		Expect(isTransientPollError(statusError(http.StatusNotFound))).To(BeFalse())
+		Expect(isTransientPollError(statusError(http.StatusInternalServerError))).To(BeFalse())
+		Expect(isTransientPollError(fmt.Errorf("failed"))).To(BeFalse())
+	})
+
+	It("Classifies the HTML pages of unavailable proxies as transient", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("<html><body><h1>503 Service Unavailable</h1></body></html>"))
+		}))
+		defer server.Close()
+		connection, err := sdk.NewConnectionBuilder().
+			URL(server.URL).
+			Tokens(mockToken()).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		defer connection.Close()
+
+		_, err = connection.ClustersMgmt().V1().Clusters().Cluster("123").Get().Send()
+		Expect(err).To(HaveOccurred())
+		Expect(isTransientPollError(err)).To(BeTrue())
+	})
+
+	It("Doesn't classify certificate errors as transient", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"Cluster","id":"123"}`))
+		}))
+		defer server.Close()
+		connection, err := sdk.NewConnectionBuilder().
+			URL(server.URL).
+			Tokens(mockToken()).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		defer connection.Close()
+
+		// The certificate of the test server isn't trusted:
+		_, err = connection.ClustersMgmt().V1().Clusters().Cluster("123").Get().Send()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("certificate"))
+		Expect(isTransientPollError(err)).To(BeFalse())
+	})
+
+	It("Keeps waiting after a few transient errors", func() {
+		calls := 0
+		err := pollWithBackoff(context.Background(), time.Millisecond,
+			tolerateTransientErrors(func(ctx context.Context) (bool, error) {
+				calls++
+				if calls <= 3 {
+					return false, statusError(http.StatusServiceUnavailable)
+				}
+				return true, nil
+			}),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(4))
+	})
+
+	It("Gives up after too many consecutive transient errors", func() {
+		calls := 0
+		err := pollWithBackoff(context.Background(), time.Millisecond,
+			tolerateTransientErrors(func(ctx context.Context) (bool, error) {
+				calls++
+				return false, statusError(http.StatusBadGateway)
+			}),
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(calls).To(Equal(maxTransientPollErrors + 1))
+	})
+
+	It("Stops at the first error that isn't transient", func() {
+		calls := 0
+		err := pollWithBackoff(context.Background(), time.Millisecond,
+			tolerateTransientErrors(func(ctx context.Context) (bool, error) {
+				calls++
+				return false, statusError(http.StatusForbidden)
+			}),
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(calls).To(Equal(1))
+	})
+})
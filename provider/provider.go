@@ -20,8 +20,12 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -33,21 +37,77 @@ import (
 
 // Provider is the implementation of the Provider.
 type Provider struct {
-	logger     logging.Logger
-	connection *sdk.Connection
+	logger       logging.Logger
+	connection   *sdk.Connection
+	awsSettings  awsSettings
+	cache        *lookupCache
+	refresh      *refreshCache
+	pollInterval time.Duration
+
+	// iamPropagationTimeout is the time that the creation of clusters is retried while OCM
+	// reports that the IAM roles aren't visible yet.
+	iamPropagationTimeout time.Duration
+
+	// versionEOLWarning is how long before the end of life of the version of a cluster the
+	// provider starts warning about it. Zero disables the warnings.
+	versionEOLWarning time.Duration
+
+	// installLogLines is the number of lines from the end of the install log that are added to
+	// the error when the provisioning of a cluster fails.
+	installLogLines int
+
+	// defaultClusterProperties are the properties added to every cluster created by the
+	// provider, unless the resource gives a different value.
+	defaultClusterProperties map[string]string
+
+	// endpointTransport is the transport used to send requests to the endpoints of the clusters,
+	// with the same proxy and TLS settings as the connection to the API.
+	endpointTransport http.RoundTripper
+
+	// mockURL is the URL of the embedded mock server, when it has been started.
+	mockURL string
+
+	// fedRAMP indicates if the provider is connected to the FedRAMP environment of OCM, where
+	// clusters can only be created in the GovCloud regions.
+	fedRAMP bool
+}
+
+// awsSettings contains the optional AWS credentials that the provider uses for the checks that it
+// runs directly against AWS. When they aren't given the default AWS credentials chain is used.
+type awsSettings struct {
+	profile         string
+	accessKeyID     string
+	secretAccessKey string
 }
 
 // Config contains the configuration of the provider.
 type Config struct {
-	URL          types.String `tfsdk:"url"`
-	TokenURL     types.String `tfsdk:"token_url"`
-	User         types.String `tfsdk:"user"`
-	Password     types.String `tfsdk:"password"`
-	Token        types.String `tfsdk:"token"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	TrustedCAs   types.String `tfsdk:"trusted_cas"`
-	Insecure     types.Bool   `tfsdk:"insecure"`
+	URL                      types.String `tfsdk:"url"`
+	TokenURL                 types.String `tfsdk:"token_url"`
+	FedRAMP                  types.Bool   `tfsdk:"fedramp"`
+	Mock                     types.Bool   `tfsdk:"mock"`
+	User                     types.String `tfsdk:"user"`
+	Password                 types.String `tfsdk:"password"`
+	Token                    types.String `tfsdk:"token"`
+	ClientID                 types.String `tfsdk:"client_id"`
+	ClientSecret             types.String `tfsdk:"client_secret"`
+	TrustedCAs               types.String `tfsdk:"trusted_cas"`
+	TrustedCAFile            types.String `tfsdk:"trusted_ca_file"`
+	Insecure                 types.Bool   `tfsdk:"insecure"`
+	ProxyURL                 types.String `tfsdk:"proxy_url"`
+	NoProxy                  types.String `tfsdk:"no_proxy"`
+	MaxConcurrentRequests    types.Int64  `tfsdk:"max_concurrent_requests"`
+	MaxRequestsPerSecond     types.Int64  `tfsdk:"max_requests_per_second"`
+	PollInterval             types.Int64  `tfsdk:"poll_interval"`
+	RetryAttempts            types.Int64  `tfsdk:"retry_attempts"`
+	RetryInterval            types.Int64  `tfsdk:"retry_interval"`
+	IAMPropagationTimeout    types.Int64  `tfsdk:"iam_propagation_timeout"`
+	VersionEOLWarningDays    types.Int64  `tfsdk:"version_eol_warning_days"`
+	InstallLogLines          types.Int64  `tfsdk:"install_log_lines"`
+	DefaultClusterProperties types.Map    `tfsdk:"default_cluster_properties"`
+	AWSProfile               types.String `tfsdk:"aws_profile"`
+	AWSAccessKeyID           types.String `tfsdk:"aws_access_key_id"`
+	AWSSecretAccessKey       types.String `tfsdk:"aws_secret_access_key"`
 }
 
 // New creates the provider.
@@ -69,6 +129,29 @@ func (p *Provider) GetSchema(ctx context.Context) (schema tfsdk.Schema, diags di
 				Type:        types.StringType,
 				Optional:    true,
 			},
+			"fedramp": {
+				Description: "Connect to the FedRAMP High environment of OCM. When this " +
+					"is set the default API and token URLs are the ones of that " +
+					"environment, and clusters can only be created in the AWS " +
+					"GovCloud regions with ARNs of the 'aws-us-gov' partition. It is " +
+					"also enabled when the URL of the API server is in the " +
+					"'openshiftusgov.com' domain. Default is false.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"mock": {
+				Description: "Run against an embedded mock of the OCM API instead of a " +
+					"real server, so that modules can be tested without OCM " +
+					"credentials. The mock clusters go through the 'pending', " +
+					"'installing' and 'ready' states, and the other objects are stored " +
+					"as they are sent. Checks that the provider runs directly against " +
+					"AWS aren't mocked. It can also be enabled with the 'OCM_MOCK' " +
+					"environment variable. The objects are kept in memory, set the " +
+					"'OCM_MOCK_STATE_FILE' environment variable to the path of a file " +
+					"to preserve them between runs. Default is false.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
 			"user": {
 				Description: "User name.",
 				Type:        types.StringType,
@@ -105,14 +188,139 @@ func (p *Provider) GetSchema(ctx context.Context) (schema tfsdk.Schema, diags di
 				Type:     types.StringType,
 				Optional: true,
 			},
+			"trusted_ca_file": {
+				Description: "Path of a file containing the PEM encoded certificates " +
+					"of authorities that will be trusted, in addition to the ones " +
+					"given in 'trusted_cas'. This is useful when the connection goes " +
+					"through a proxy that intercepts TLS traffic.",
+				Type:     types.StringType,
+				Optional: true,
+			},
 			"insecure": {
-				Description: "When set to 'true' enables insecure communication " +
-					"with the server. This disables verification of TLS " +
-					"certificates and host names and it isn't recommended " +
-					"for production environments.",
+				Description: "UNSAFE: when set to 'true' the provider doesn't verify " +
+					"the TLS certificate and host name of the API server. This is " +
+					"only intended for testing against self-hosted or staging " +
+					"environments that use private certificates, and must never " +
+					"be used with production environments. To trust a private " +
+					"certificate authority use 'trusted_cas' or 'trusted_ca_file' " +
+					"instead.",
 				Type:     types.BoolType,
 				Optional: true,
 			},
+			"proxy_url": {
+				Description: "URL of the HTTP or HTTPS proxy that will be used to " +
+					"connect to the API server and to the OpenID token server, for " +
+					"example 'http://proxy.example.com:3128'. If this isn't specified " +
+					"the 'HTTPS_PROXY' and 'HTTP_PROXY' environment variables are used.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"no_proxy": {
+				Description: "Comma separated list of host names, domains and CIDR " +
+					"blocks that will be reached without the proxy, for example " +
+					"'.example.com,10.0.0.0/8'. If this isn't specified the " +
+					"'NO_PROXY' environment variable is used.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"max_concurrent_requests": {
+				Description: "Maximum number of requests that will be sent to the " +
+					"API server at the same time. This is useful for large " +
+					"workspaces that would otherwise trigger the rate limits " +
+					"of the API server during refresh. Default is unlimited.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"max_requests_per_second": {
+				Description: "Maximum number of requests per second that will be " +
+					"sent to the API server. Default is unlimited.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"poll_interval": {
+				Description: "Maximum number of seconds between the checks done while " +
+					"waiting for clusters to be created or deleted. The checks start " +
+					"a few seconds apart and the interval doubles after each check " +
+					"till it reaches this value. Default is 60 seconds.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"retry_attempts": {
+				Description: "Number of times that a request is retried when the API " +
+					"server rejects it without processing it, with the 429 and 503 " +
+					"responses that it may return when many clusters are created in " +
+					"parallel, when a read fails with a server error, or when the " +
+					"connection is closed. This is independent of the checks done " +
+					"while waiting, see 'poll_interval'. Zero disables the retries. " +
+					"Default is 2.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"retry_interval": {
+				Description: "Number of seconds to wait before the first retry of a " +
+					"request. The interval doubles after each retry. Default is 1 " +
+					"second.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"iam_propagation_timeout": {
+				Description: "Maximum number of seconds that the creation of a cluster " +
+					"is retried when OCM rejects it because the IAM roles, or their " +
+					"trust policies, aren't visible yet. This happens when the roles " +
+					"are created in the same apply, as IAM changes usually take " +
+					"between 10 and 60 seconds to propagate. Zero disables the " +
+					"retries. Default is 120 seconds.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"version_eol_warning_days": {
+				Description: "Number of days before the end of life of the OpenShift " +
+					"version of a cluster when the plan and the refresh start warning " +
+					"about it, so that upgrades can be scheduled in time. For example " +
+					"60. Zero disables the warnings. Default is zero.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"install_log_lines": {
+				Description: "Number of lines from the end of the install log that are " +
+					"added to the error when the provisioning of a cluster fails, so " +
+					"that the cause can be found without leaving Terraform. Zero " +
+					"disables it. Default is zero.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"default_cluster_properties": {
+				Description: "Properties added to every cluster created by the provider, " +
+					"for example to identify the team, environment or cost center " +
+					"across a fleet. The 'properties' of each cluster resource take " +
+					"precedence over these values.",
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+			},
+			"aws_profile": {
+				Description: "Name of the AWS shared configuration profile used for " +
+					"the checks that the provider runs directly against AWS, like " +
+					"the validation of the account roles. If this isn't specified " +
+					"then the default AWS credentials chain is used.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"aws_access_key_id": {
+				Description: "AWS access key identifier used for the checks that the " +
+					"provider runs directly against AWS. Takes precedence over " +
+					"'aws_profile'.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"aws_secret_access_key": {
+				Description: "AWS secret access key used for the checks that the " +
+					"provider runs directly against AWS.",
+				Type:      types.StringType,
+				Optional:  true,
+				Sensitive: true,
+			},
 		},
 	}
 	return
@@ -136,11 +344,26 @@ func (p *Provider) Configure(ctx context.Context, request tfsdk.ConfigureProvide
 	// The plugin infrastructure redirects the log package output so that it is sent to the main
 	// Terraform process, so if we want to have the logs of the SDK redirected we need to use
 	// the log package as well.
+	debug := strings.EqualFold(level, "DEBUG")
 	logger, err := logging.NewGoLoggerBuilder().
 		Error(true).
 		Warn(true).
 		Info(true).
-		Debug(strings.EqualFold(level, "DEBUG")).
+		Debug(debug).
+		Build()
+	if err != nil {
+		response.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	// The connection gets its own logger with debug disabled, as otherwise the SDK would dump
+	// complete request and response bodies, including secrets. Instead of that the requests and
+	// responses are written to the Terraform log with the sensitive values redacted.
+	connectionLogger, err := logging.NewGoLoggerBuilder().
+		Error(true).
+		Warn(true).
+		Info(true).
+		Debug(false).
 		Build()
 	if err != nil {
 		response.Diagnostics.AddError(err.Error(), "")
@@ -149,20 +372,27 @@ func (p *Provider) Configure(ctx context.Context, request tfsdk.ConfigureProvide
 
 	// Create the builder:
 	builder := sdk.NewConnectionBuilder()
-	builder.Logger(logger)
+	builder.Logger(connectionLogger)
 	builder.Agent(fmt.Sprintf("OCM-TF/%s-%s", build.Version, build.Commit))
 
 	// Copy the settings:
+	fedRAMP := !config.FedRAMP.Null && config.FedRAMP.Value
+	apiURL := ""
 	if !config.URL.Null {
-		builder.URL(config.URL.Value)
-	} else {
-		url, ok := os.LookupEnv("OCM_URL")
-		if ok {
-			builder.URL(url)
-		}
+		apiURL = config.URL.Value
+	} else if value, ok := os.LookupEnv("OCM_URL"); ok {
+		apiURL = value
+	} else if fedRAMP {
+		apiURL = fedRAMPURL
+	}
+	if apiURL != "" {
+		builder.URL(apiURL)
+		fedRAMP = fedRAMP || isFedRAMPURL(apiURL)
 	}
 	if !config.TokenURL.Null {
 		builder.TokenURL(config.TokenURL.Value)
+	} else if fedRAMP {
+		builder.TokenURL(fedRAMPTokenURL)
 	}
 	if !config.User.Null && !config.Password.Null {
 		builder.User(config.User.Value, config.Password.Value)
@@ -178,59 +408,292 @@ func (p *Provider) Configure(ctx context.Context, request tfsdk.ConfigureProvide
 	if !config.ClientID.Null && !config.ClientSecret.Null {
 		builder.Client(config.ClientID.Value, config.ClientSecret.Value)
 	}
-	if !config.Insecure.Null {
-		builder.Insecure(config.Insecure.Value)
+	mock := !config.Mock.Null && config.Mock.Value
+	if value, ok := os.LookupEnv("OCM_MOCK"); ok && config.Mock.Null {
+		mock, _ = strconv.ParseBool(value)
+	}
+	if mock {
+		if p.mockURL == "" {
+			_, p.mockURL, err = startMockServer(os.Getenv("OCM_MOCK_STATE_FILE"))
+			if err != nil {
+				response.Diagnostics.AddError(
+					fmt.Sprintf("can't start mock server: %v", err),
+					"",
+				)
+				return
+			}
+		}
+		response.Diagnostics.AddWarning(
+			"Using the mock OCM server",
+			"The provider is connected to an embedded mock of the OCM API, the "+
+				"clusters and other objects aren't real.",
+		)
+		builder.URL(p.mockURL)
+		builder.Tokens(mockToken())
+	}
+	insecure := !config.Insecure.Null && config.Insecure.Value
+	if insecure {
+		response.Diagnostics.AddWarning(
+			"TLS verification is disabled",
+			"The provider won't verify the TLS certificate and host name of the "+
+				"API server. This is unsafe and must only be used for testing "+
+				"against non-production environments.",
+		)
+		builder.Insecure(true)
 	}
-	if !config.TrustedCAs.Null {
+	var trustedCAs [][]byte
+	if !config.TrustedCAs.Null || !config.TrustedCAFile.Null {
 		pool := x509.NewCertPool()
-		if !pool.AppendCertsFromPEM([]byte(config.TrustedCAs.Value)) {
+		if !config.TrustedCAs.Null {
+			if !pool.AppendCertsFromPEM([]byte(config.TrustedCAs.Value)) {
+				response.Diagnostics.AddError(
+					"the value of 'trusted_cas' doesn't contain any certificate",
+					"",
+				)
+				return
+			}
+			trustedCAs = append(trustedCAs, []byte(config.TrustedCAs.Value))
+		}
+		if !config.TrustedCAFile.Null {
+			data, err := os.ReadFile(config.TrustedCAFile.Value)
+			if err != nil {
+				response.Diagnostics.AddError(
+					fmt.Sprintf(
+						"can't read trusted CA file '%s'",
+						config.TrustedCAFile.Value,
+					),
+					err.Error(),
+				)
+				return
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				response.Diagnostics.AddError(
+					fmt.Sprintf(
+						"the trusted CA file '%s' doesn't contain any certificate",
+						config.TrustedCAFile.Value,
+					),
+					"",
+				)
+				return
+			}
+			trustedCAs = append(trustedCAs, data)
+		}
+		builder.TrustedCAs(pool)
+	}
+	var proxyURL *url.URL
+	if !config.ProxyURL.Null && config.ProxyURL.Value != "" {
+		proxyURL, err = parseProxyURL(config.ProxyURL.Value)
+		if err != nil {
 			response.Diagnostics.AddError(
-				"the value of 'trusted_cas' doesn't contain any certificate",
+				fmt.Sprintf(
+					"the value of 'proxy_url' isn't a valid proxy URL: %v",
+					err,
+				),
 				"",
 			)
 			return
 		}
-		builder.TrustedCAs(pool)
+	}
+	noProxy := ""
+	if !config.NoProxy.Null {
+		noProxy = config.NoProxy.Value
+	}
+
+	var maxConcurrentRequests, maxRequestsPerSecond int64
+	if !config.MaxConcurrentRequests.Null {
+		maxConcurrentRequests = config.MaxConcurrentRequests.Value
+		if maxConcurrentRequests <= 0 {
+			response.Diagnostics.AddError(
+				"the value of 'max_concurrent_requests' must be a positive number",
+				"",
+			)
+			return
+		}
+	}
+	if !config.MaxRequestsPerSecond.Null {
+		maxRequestsPerSecond = config.MaxRequestsPerSecond.Value
+		if maxRequestsPerSecond <= 0 {
+			response.Diagnostics.AddError(
+				"the value of 'max_requests_per_second' must be a positive number",
+				"",
+			)
+			return
+		}
+	}
+	pollInterval := defaultPollIntervalInSeconds
+	if !config.PollInterval.Null {
+		pollInterval = config.PollInterval.Value
+		if pollInterval <= 0 {
+			response.Diagnostics.AddError(
+				"the value of 'poll_interval' must be a positive number",
+				"",
+			)
+			return
+		}
+	}
+	if !config.RetryAttempts.Null {
+		if config.RetryAttempts.Value < 0 {
+			response.Diagnostics.AddError(
+				"the value of 'retry_attempts' can't be negative",
+				"",
+			)
+			return
+		}
+		builder.RetryLimit(int(config.RetryAttempts.Value))
+	}
+	if !config.RetryInterval.Null {
+		if config.RetryInterval.Value <= 0 {
+			response.Diagnostics.AddError(
+				"the value of 'retry_interval' must be a positive number",
+				"",
+			)
+			return
+		}
+		builder.RetryInterval(time.Duration(config.RetryInterval.Value) * time.Second)
+	}
+	iamPropagationTimeout := defaultIAMPropagationTimeoutInSeconds
+	if !config.IAMPropagationTimeout.Null {
+		iamPropagationTimeout = config.IAMPropagationTimeout.Value
+		if iamPropagationTimeout < 0 {
+			response.Diagnostics.AddError(
+				"the value of 'iam_propagation_timeout' can't be negative",
+				"",
+			)
+			return
+		}
+	}
+	versionEOLWarningDays := defaultVersionEOLWarningDays
+	if !config.VersionEOLWarningDays.Null {
+		versionEOLWarningDays = config.VersionEOLWarningDays.Value
+		if versionEOLWarningDays < 0 {
+			response.Diagnostics.AddError(
+				"the value of 'version_eol_warning_days' can't be negative",
+				"",
+			)
+			return
+		}
+	}
+	installLogLines := int64(0)
+	if !config.InstallLogLines.Null {
+		installLogLines = config.InstallLogLines.Value
+		if installLogLines < 0 {
+			response.Diagnostics.AddError(
+				"the value of 'install_log_lines' can't be negative",
+				"",
+			)
+			return
+		}
+	}
+	defaultClusterProperties := map[string]string{}
+	if !config.DefaultClusterProperties.Null && !config.DefaultClusterProperties.Unknown {
+		for k, v := range config.DefaultClusterProperties.Elems {
+			if !propertyKeyRE.MatchString(k) {
+				response.Diagnostics.AddError(
+					fmt.Sprintf(
+						"the key '%s' of 'default_cluster_properties' isn't a valid "+
+							"property name",
+						k,
+					),
+					"",
+				)
+				return
+			}
+			if _, reserved := OCMProperties[k]; reserved {
+				response.Diagnostics.AddError(
+					fmt.Sprintf(
+						"the key '%s' of 'default_cluster_properties' is reserved",
+						k,
+					),
+					"",
+				)
+				return
+			}
+			defaultClusterProperties[k] = v.(types.String).Value
+		}
+	}
+	wrappers := transportWrappers(transportSettings{
+		proxy:       proxyConfig(proxyURL, noProxy),
+		rateLimiter: newRateLimiter(maxConcurrentRequests, maxRequestsPerSecond),
+		debug:       debug,
+	})
+	for _, wrapper := range wrappers {
+		builder.TransportWrapper(wrapper)
 	}
 
 	// Create the connection:
 	connection, err := builder.BuildContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(err.Error(), "")
+		// The connection usually fails to build because the tokens or the credentials can't be
+		// used:
+		addCodedError(&response.Diagnostics, authDiagnosticCode, err.Error(), "")
 		return
 	}
 
 	// Save the connection:
 	p.logger = logger
 	p.connection = connection
+	p.cache = newLookupCache()
+	p.refresh = newRefreshCache()
+	p.endpointTransport = newEndpointTransport(proxyConfig(proxyURL, noProxy), trustedCAs, insecure)
+	p.pollInterval = time.Duration(pollInterval) * time.Second
+	p.iamPropagationTimeout = time.Duration(iamPropagationTimeout) * time.Second
+	p.versionEOLWarning = time.Duration(versionEOLWarningDays) * 24 * time.Hour
+	p.installLogLines = int(installLogLines)
+	p.defaultClusterProperties = defaultClusterProperties
+	p.fedRAMP = fedRAMP
+
+	// Save the AWS settings:
+	if !config.AWSProfile.Null {
+		p.awsSettings.profile = config.AWSProfile.Value
+	}
+	if !config.AWSAccessKeyID.Null && !config.AWSSecretAccessKey.Null {
+		p.awsSettings.accessKeyID = config.AWSAccessKeyID.Value
+		p.awsSettings.secretAccessKey = config.AWSSecretAccessKey.Value
+	}
 }
 
 // GetResources returns the resources supported by the provider.
 func (p *Provider) GetResources(ctx context.Context) (result map[string]tfsdk.ResourceType,
 	diags diag.Diagnostics) {
-	result = map[string]tfsdk.ResourceType{
-		"ocm_cluster":                &ClusterResourceType{},
-		"ocm_cluster_rosa_classic":   &ClusterRosaClassicResourceType{p.logger},
-		"ocm_group_membership":       &GroupMembershipResourceType{},
-		"ocm_identity_provider":      &IdentityProviderResourceType{},
-		"ocm_machine_pool":           &MachinePoolResourceType{p.logger},
-		"ocm_cluster_wait":           &ClusterWaiterResourceType{},
-		"ocm_rosa_oidc_config_input": &RosaOidcConfigInputResourceType{},
-		"ocm_rosa_oidc_config":       &RosaOidcConfigResourceType{},
-	}
+	result = withLegacyResourceNames(map[string]tfsdk.ResourceType{
+		"rhcs_cluster":                &ClusterResourceType{p.logger},
+		"rhcs_cluster_admin_password": &ClusterAdminPasswordResourceType{},
+		"rhcs_cluster_osd_aws":        &ClusterOsdAwsResourceType{p.logger},
+		"rhcs_cluster_osd_gcp":        &ClusterOsdGcpResourceType{p.logger},
+		"rhcs_cluster_rosa_classic":   &ClusterRosaClassicResourceType{p.logger},
+		"rhcs_default_ingress":        &DefaultIngressResourceType{},
+		"rhcs_default_machine_pool":   &DefaultMachinePoolResourceType{p.logger},
+		"rhcs_group_membership":       &GroupMembershipResourceType{},
+		"rhcs_identity_provider":      &IdentityProviderResourceType{},
+		"rhcs_machine_pool":           &MachinePoolResourceType{p.logger},
+		"rhcs_upgrade_policy":         &UpgradePolicyResourceType{},
+		"rhcs_cluster_wait":           &ClusterWaiterResourceType{},
+		"rhcs_rosa_oidc_config_input": &RosaOidcConfigInputResourceType{},
+		"rhcs_rosa_oidc_config":       &RosaOidcConfigResourceType{},
+	})
 	return
 }
 
 // GetDataSources returns the data sources supported by the provider.
 func (p *Provider) GetDataSources(ctx context.Context) (result map[string]tfsdk.DataSourceType,
 	diags diag.Diagnostics) {
-	result = map[string]tfsdk.DataSourceType{
-		"ocm_cloud_providers":     &CloudProvidersDataSourceType{},
-		"ocm_rosa_operator_roles": &RosaOperatorRolesDataSourceType{},
-		"ocm_policies":            &OcmPoliciesDataSourceType{},
-		"ocm_groups":              &GroupsDataSourceType{},
-		"ocm_machine_types":       &MachineTypesDataSourceType{},
-		"ocm_versions":            &VersionsDataSourceType{},
-	}
+	result = withLegacyDataSourceNames(map[string]tfsdk.DataSourceType{
+		"rhcs_cloud_providers":         &CloudProvidersDataSourceType{},
+		"rhcs_cluster_credentials":     &ClusterCredentialsDataSourceType{},
+		"rhcs_cluster_logs":            &ClusterLogsDataSourceType{},
+		"rhcs_cluster_subscription":    &ClusterSubscriptionDataSourceType{},
+		"rhcs_clusters":                &ClustersDataSourceType{},
+		"rhcs_rosa_operator_roles":     &RosaOperatorRolesDataSourceType{},
+		"rhcs_policies":                &OcmPoliciesDataSourceType{},
+		"rhcs_groups":                  &GroupsDataSourceType{},
+		"rhcs_ingresses":               &IngressesDataSourceType{},
+		"rhcs_machine_pools":           &MachinePoolsDataSourceType{},
+		"rhcs_machine_types":           &MachineTypesDataSourceType{},
+		"rhcs_versions":                &VersionsDataSourceType{},
+		"rhcs_available_upgrades":      &AvailableUpgradesDataSourceType{},
+		"rhcs_quota_cost":              &QuotaCostDataSourceType{},
+		"rhcs_available_machine_types": &AvailableMachineTypesDataSourceType{},
+		"rhcs_vpc_subnets":             &VPCSubnetsDataSourceType{},
+	})
 	return
 }
@@ -0,0 +1,354 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// Values of the fields of the resources related to quota costs:
+const (
+	quotaResourceCluster     = "cluster"
+	quotaResourceComputeNode = "compute.node"
+	quotaAny                 = "any"
+	quotaBYOC                = "byoc"
+	quotaRedHatInfra         = "rhinfra"
+	quotaSingleZone          = "single"
+	quotaMultiZone           = "multi"
+)
+
+// quotaProducts are the products that can be used to calculate the quota cost.
+var quotaProducts = []string{"rosa", "osd"}
+
+type QuotaCostDataSourceType struct {
+}
+
+type QuotaCostDataSource struct {
+	logger                logging.Logger
+	currentAccount        *amv1.CurrentAccountClient
+	organizations         *amv1.OrganizationsClient
+	machineTypeCollection *cmv1.MachineTypesClient
+	cache                 *lookupCache
+}
+
+func (t *QuotaCostDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "OCM quota needed by a cluster with the given shape, and quota " +
+			"available in the organization of the current account.",
+		Attributes: map[string]tfsdk.Attribute{
+			"product": {
+				Description: "Product of the cluster, 'rosa' or 'osd'. The default " +
+					"is 'rosa'.",
+				Type:       types.StringType,
+				Optional:   true,
+				Validators: EnumValueValidator(quotaProducts),
+			},
+			"billing_model": {
+				Description: "Billing model of the cluster. The default is 'standard'.",
+				Type:        types.StringType,
+				Optional:    true,
+				Validators:  EnumValueValidator(billingModels),
+			},
+			"ccs": {
+				Description: "Indicates if the cluster is deployed in the cloud " +
+					"account of the customer. The default is 'true'.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"multi_az": {
+				Description: "Indicates if the cluster is deployed in multiple " +
+					"availability zones. The default is 'false'.",
+				Type:     types.BoolType,
+				Optional: true,
+			},
+			"compute_machine_type": {
+				Description: "Identifier of the machine type used by the compute " +
+					"nodes, for example 'r5.xlarge'.",
+				Type:     types.StringType,
+				Required: true,
+			},
+			"replicas": {
+				Description: "Number of compute nodes. The default is 2, or 3 for " +
+					"multiple availability zones.",
+				Type:     types.Int64Type,
+				Optional: true,
+			},
+			"sufficient": {
+				Description: "Indicates if the organization has enough quota for " +
+					"the cluster.",
+				Type:     types.BoolType,
+				Computed: true,
+			},
+			"items": {
+				Description: "Quotas consumed by the cluster.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"quota_id": {
+							Description: "Identifier of the quota.",
+							Type:        types.StringType,
+							Computed:    true,
+						},
+						"required": {
+							Description: "Amount of the quota needed " +
+								"by the cluster.",
+							Type:     types.Int64Type,
+							Computed: true,
+						},
+						"allowed": {
+							Description: "Amount of the quota " +
+								"allowed for the organization.",
+							Type:     types.Int64Type,
+							Computed: true,
+						},
+						"consumed": {
+							Description: "Amount of the quota " +
+								"already consumed by the " +
+								"organization.",
+							Type:     types.Int64Type,
+							Computed: true,
+						},
+						"remaining": {
+							Description: "Amount of the quota " +
+								"that is still available.",
+							Type:     types.Int64Type,
+							Computed: true,
+						},
+					},
+					tfsdk.ListNestedAttributesOptions{},
+				),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *QuotaCostDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &QuotaCostDataSource{
+		logger:                parent.logger,
+		currentAccount:        parent.connection.AccountsMgmt().V1().CurrentAccount(),
+		organizations:         parent.connection.AccountsMgmt().V1().Organizations(),
+		machineTypeCollection: parent.connection.ClustersMgmt().V1().MachineTypes(),
+		cache:                 parent.cache,
+	}
+	return
+}
+
+// quotaShape describes the properties of a cluster that determine the quota that it consumes.
+type quotaShape struct {
+	product      string
+	billingModel string
+	byoc         string
+	zoneType     string
+	machineType  string
+	replicas     int
+}
+
+// shapeFromState calculates the shape of the cluster from the configuration of the data source,
+// applying the defaults. The machine type is the generic name used by the quota costs.
+func shapeFromState(state *QuotaCostState, machineType string) quotaShape {
+	shape := quotaShape{
+		product:      "rosa",
+		billingModel: string(cmv1.BillingModelStandard),
+		byoc:         quotaBYOC,
+		zoneType:     quotaSingleZone,
+		machineType:  machineType,
+		replicas:     2,
+	}
+	if !common.IsStringAttributeEmpty(state.Product) {
+		shape.product = state.Product.Value
+	}
+	if !common.IsStringAttributeEmpty(state.BillingModel) {
+		shape.billingModel = state.BillingModel.Value
+	}
+	if !state.CCS.Unknown && !state.CCS.Null && !state.CCS.Value {
+		shape.byoc = quotaRedHatInfra
+	}
+	if !state.MultiAZ.Unknown && !state.MultiAZ.Null && state.MultiAZ.Value {
+		shape.zoneType = quotaMultiZone
+		shape.replicas = 3
+	}
+	if !state.Replicas.Unknown && !state.Replicas.Null {
+		shape.replicas = int(state.Replicas.Value)
+	}
+	return shape
+}
+
+// requiredQuota calculates the amount of the given quota that a cluster with the given shape
+// consumes.
+func requiredQuota(quotaCost *amv1.QuotaCost, shape quotaShape) int {
+	required := 0
+	for _, resource := range quotaCost.RelatedResources() {
+		if !quotaFieldMatches(resource.Product(), shape.product) ||
+			!quotaFieldMatches(resource.BillingModel(), shape.billingModel) ||
+			!quotaFieldMatches(resource.BYOC(), shape.byoc) ||
+			!quotaFieldMatches(resource.AvailabilityZoneType(), shape.zoneType) {
+			continue
+		}
+		switch resource.ResourceType() {
+		case quotaResourceCluster:
+			required += resource.Cost()
+		case quotaResourceComputeNode:
+			if quotaFieldMatches(resource.ResourceName(), shape.machineType) {
+				required += resource.Cost() * shape.replicas
+			}
+		}
+	}
+	return required
+}
+
+// quotaFieldMatches checks if a field of a related resource matches the given value. The
+// comparison ignores case, and the 'any' value matches everything.
+func quotaFieldMatches(field, value string) bool {
+	return field == "" || strings.EqualFold(field, quotaAny) || strings.EqualFold(field, value)
+}
+
+func (s *QuotaCostDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &QuotaCostState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// The quota costs use the generic name of the machine type:
+	machineTypes, err := s.cache.getMachineTypes(func() ([]*cmv1.MachineType, error) {
+		return listMachineTypes(ctx, s.machineTypeCollection)
+	})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't list machine types",
+			err.Error(),
+		)
+		return
+	}
+	var machineType *cmv1.MachineType
+	for _, item := range machineTypes {
+		if item.ID() == state.ComputeMachineType.Value {
+			machineType = item
+			break
+		}
+	}
+	if machineType == nil {
+		response.Diagnostics.AddError(
+			"Can't find machine type",
+			fmt.Sprintf(
+				"Machine type '%s' doesn't exist",
+				state.ComputeMachineType.Value,
+			),
+		)
+		return
+	}
+	shape := shapeFromState(state, machineType.GenericName())
+
+	// Fetch the quota costs of the organization of the current account:
+	account, err := s.currentAccount.Get().SendContext(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't get current account",
+			err.Error(),
+		)
+		return
+	}
+	organizationID := account.Body().Organization().ID()
+	quotaCosts, err := s.listQuotaCosts(ctx, organizationID)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't list quota cost",
+			fmt.Sprintf(
+				"Can't list quota cost of organization '%s': %v",
+				organizationID, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state:
+	state.Sufficient = types.Bool{
+		Value: true,
+	}
+	state.Items = []*QuotaCostItemState{}
+	for _, quotaCost := range quotaCosts {
+		required := requiredQuota(quotaCost, shape)
+		if required == 0 {
+			continue
+		}
+		remaining := quotaCost.Allowed() - quotaCost.Consumed()
+		if remaining < 0 {
+			remaining = 0
+		}
+		if remaining < required {
+			state.Sufficient.Value = false
+		}
+		state.Items = append(state.Items, &QuotaCostItemState{
+			QuotaID:   quotaCost.QuotaID(),
+			Required:  int64(required),
+			Allowed:   int64(quotaCost.Allowed()),
+			Consumed:  int64(quotaCost.Consumed()),
+			Remaining: int64(remaining),
+		})
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// listQuotaCosts retrieves all the pages of quota costs of the given organization, including
+// the related resources.
+func (s *QuotaCostDataSource) listQuotaCosts(ctx context.Context,
+	organizationID string) ([]*amv1.QuotaCost, error) {
+	var result []*amv1.QuotaCost
+	page := 1
+	size := 100
+	for {
+		response, err := s.organizations.Organization(organizationID).QuotaCost().List().
+			Parameter("fetchRelatedResources", true).
+			Page(page).
+			Size(size).
+			SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.Items().Slice()...)
+		if response.Items().Len() == 0 || len(result) >= response.Total() {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
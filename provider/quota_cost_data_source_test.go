@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+var _ = Describe("Quota cost", func() {
+	buildQuotaCost := func(resources ...*amv1.RelatedResourceBuilder) *amv1.QuotaCost {
+		quotaCost, err := amv1.NewQuotaCost().
+			QuotaID("cluster|byoc|moa").
+			Allowed(10).
+			RelatedResources(resources...).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return quotaCost
+	}
+
+	// emptyState returns the state of a configuration that doesn't set any of the attributes:
+	emptyState := func() *QuotaCostState {
+		return &QuotaCostState{
+			Product:      types.String{Null: true},
+			BillingModel: types.String{Null: true},
+			CCS:          types.Bool{Null: true},
+			MultiAZ:      types.Bool{Null: true},
+			Replicas:     types.Int64{Null: true},
+		}
+	}
+
+	It("Applies the defaults to the shape", func() {
+		state := emptyState()
+		state.MultiAZ = types.Bool{Value: true}
+		shape := shapeFromState(state, "standard-4")
+		Expect(shape).To(Equal(quotaShape{
+			product:      "rosa",
+			billingModel: "standard",
+			byoc:         quotaBYOC,
+			zoneType:     quotaMultiZone,
+			machineType:  "standard-4",
+			replicas:     3,
+		}))
+	})
+
+	It("Adds the cost of the cluster and of each compute node", func() {
+		quotaCost := buildQuotaCost(
+			amv1.NewRelatedResource().
+				ResourceType(quotaResourceCluster).
+				Product("ROSA").
+				BYOC(quotaBYOC).
+				AvailabilityZoneType(quotaAny).
+				BillingModel("standard").
+				Cost(1),
+			amv1.NewRelatedResource().
+				ResourceType(quotaResourceComputeNode).
+				ResourceName("standard-4").
+				Product("ROSA").
+				BYOC(quotaBYOC).
+				AvailabilityZoneType(quotaAny).
+				BillingModel("standard").
+				Cost(4),
+			amv1.NewRelatedResource().
+				ResourceType(quotaResourceComputeNode).
+				ResourceName("standard-8").
+				Product("ROSA").
+				BYOC(quotaBYOC).
+				AvailabilityZoneType(quotaAny).
+				BillingModel("standard").
+				Cost(8),
+		)
+		shape := shapeFromState(emptyState(), "standard-4")
+		Expect(requiredQuota(quotaCost, shape)).To(Equal(9))
+	})
+
+	It("Ignores resources of other products and billing models", func() {
+		quotaCost := buildQuotaCost(
+			amv1.NewRelatedResource().
+				ResourceType(quotaResourceCluster).
+				Product("OSD").
+				BYOC(quotaBYOC).
+				AvailabilityZoneType(quotaAny).
+				BillingModel("standard").
+				Cost(1),
+			amv1.NewRelatedResource().
+				ResourceType(quotaResourceCluster).
+				Product("ROSA").
+				BYOC(quotaBYOC).
+				AvailabilityZoneType(quotaAny).
+				BillingModel("marketplace-aws").
+				Cost(1),
+		)
+		shape := shapeFromState(emptyState(), "standard-4")
+		Expect(requiredQuota(quotaCost, shape)).To(BeZero())
+	})
+})
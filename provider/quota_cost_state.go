@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type QuotaCostState struct {
+	Product            types.String          `tfsdk:"product"`
+	BillingModel       types.String          `tfsdk:"billing_model"`
+	CCS                types.Bool            `tfsdk:"ccs"`
+	MultiAZ            types.Bool            `tfsdk:"multi_az"`
+	ComputeMachineType types.String          `tfsdk:"compute_machine_type"`
+	Replicas           types.Int64           `tfsdk:"replicas"`
+	Sufficient         types.Bool            `tfsdk:"sufficient"`
+	Items              []*QuotaCostItemState `tfsdk:"items"`
+}
+
+type QuotaCostItemState struct {
+	QuotaID   string `tfsdk:"quota_id"`
+	Required  int64  `tfsdk:"required"`
+	Allowed   int64  `tfsdk:"allowed"`
+	Consumed  int64  `tfsdk:"consumed"`
+	Remaining int64  `tfsdk:"remaining"`
+}
@@ -0,0 +1,284 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// The shape of the nodes that are always created for a cluster, in addition to the compute nodes
+// requested by the user:
+const (
+	controlPlaneNodes     = 3
+	controlPlaneNodeVCPUs = 8 // m5.2xlarge
+	infraNodeVCPUs        = 4 // r5.xlarge
+	bootstrapNodeVCPUs    = 4 // m5.xlarge, only during installation
+	defaultComputeVCPUs   = 4 // m5.xlarge
+)
+
+// Codes of the AWS service quotas checked before creating a cluster:
+const (
+	standardVCPUsQuotaCode = "L-1216C47A"
+	elasticIPsQuotaCode    = "L-0263D0A3"
+	vpcsQuotaCode          = "L-F678F1CE"
+)
+
+// standardInstanceFamilies are the first letters of the instance types counted by the quota for
+// running on-demand standard instances.
+const standardInstanceFamilies = "acdhimrtz"
+
+// quotaRequirement is the amount of an AWS service quota that a cluster needs.
+type quotaRequirement struct {
+	serviceCode string
+	quotaCode   string
+	description string
+	required    float64
+}
+
+// quotaRequirements calculates the AWS service quotas needed by the given cluster. The number of
+// vCPUs of the compute machine type is passed explicitly because it comes from the OCM API.
+func quotaRequirements(state *ClusterRosaClassicState, computeVCPUs int) []quotaRequirement {
+	multiAZ := !state.MultiAZ.Unknown && !state.MultiAZ.Null && state.MultiAZ.Value
+	zones := 1
+	if multiAZ {
+		zones = 3
+	}
+
+	// Compute nodes, using the maximum when autoscaling is enabled:
+	computeNodes := 2
+	if multiAZ {
+		computeNodes = 3
+	}
+	if !state.Replicas.Unknown && !state.Replicas.Null {
+		computeNodes = int(state.Replicas.Value)
+	}
+	if !state.AutoScalingEnabled.Unknown && !state.AutoScalingEnabled.Null &&
+		state.AutoScalingEnabled.Value &&
+		!state.MaxReplicas.Unknown && !state.MaxReplicas.Null {
+		computeNodes = int(state.MaxReplicas.Value)
+	}
+	infraNodes := 2
+	if multiAZ {
+		infraNodes = 3
+	}
+	vcpus := controlPlaneNodes*controlPlaneNodeVCPUs + infraNodes*infraNodeVCPUs +
+		bootstrapNodeVCPUs + computeNodes*computeVCPUs
+
+	result := []quotaRequirement{
+		{
+			serviceCode: "ec2",
+			quotaCode:   standardVCPUsQuotaCode,
+			description: "vCPUs for running on-demand standard instances",
+			required:    float64(vcpus),
+		},
+	}
+
+	// When the user doesn't provide the subnets the installer creates a VPC with a NAT gateway,
+	// and an elastic IP, per availability zone:
+	if state.AWSSubnetIDs.Unknown || state.AWSSubnetIDs.Null || len(state.AWSSubnetIDs.Elems) == 0 {
+		result = append(result,
+			quotaRequirement{
+				serviceCode: "ec2",
+				quotaCode:   elasticIPsQuotaCode,
+				description: "elastic IP addresses",
+				required:    float64(zones),
+			},
+			quotaRequirement{
+				serviceCode: "vpc",
+				quotaCode:   vpcsQuotaCode,
+				description: "VPCs",
+				required:    1,
+			},
+		)
+	}
+	return result
+}
+
+// checkQuotas verifies that the free amount of each of the given quotas is enough. It returns one
+// error for each quota that isn't.
+func checkQuotas(ec2Client ec2iface.EC2API, quotasClient servicequotasiface.ServiceQuotasAPI,
+	requirements []quotaRequirement) []error {
+	var result []error
+	for _, requirement := range requirements {
+		limit, err := getQuotaValue(quotasClient, requirement.serviceCode, requirement.quotaCode)
+		if err != nil {
+			result = append(result, fmt.Errorf(
+				"can't get the AWS quota for %s: %v", requirement.description, err,
+			))
+			continue
+		}
+		used, err := getQuotaUsage(ec2Client, requirement.quotaCode)
+		if err != nil {
+			result = append(result, fmt.Errorf(
+				"can't get the current usage of %s: %v", requirement.description, err,
+			))
+			continue
+		}
+		if limit-used < requirement.required {
+			result = append(result, fmt.Errorf(
+				"the cluster needs %v %s but only %v of the quota of %v are available, "+
+					"request an increase of quota '%s' of service '%s'",
+				requirement.required, requirement.description, limit-used, limit,
+				requirement.quotaCode, requirement.serviceCode,
+			))
+		}
+	}
+	return result
+}
+
+// getQuotaValue returns the applied value of the given quota, or the AWS default when there is
+// no applied value.
+func getQuotaValue(client servicequotasiface.ServiceQuotasAPI, serviceCode, quotaCode string) (float64, error) {
+	output, err := client.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err == nil && output.Quota != nil && output.Quota.Value != nil {
+		return aws.Float64Value(output.Quota.Value), nil
+	}
+	defaultOutput, err := client.GetAWSDefaultServiceQuota(&servicequotas.GetAWSDefaultServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return aws.Float64Value(defaultOutput.Quota.Value), nil
+}
+
+// getQuotaUsage returns the amount of the given quota that is currently in use in the region.
+func getQuotaUsage(client ec2iface.EC2API, quotaCode string) (float64, error) {
+	switch quotaCode {
+	case standardVCPUsQuotaCode:
+		var vcpus float64
+		err := client.DescribeInstancesPages(
+			&ec2.DescribeInstancesInput{
+				Filters: []*ec2.Filter{{
+					Name:   aws.String("instance-state-name"),
+					Values: aws.StringSlice([]string{"pending", "running"}),
+				}},
+			},
+			func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+				for _, reservation := range page.Reservations {
+					for _, instance := range reservation.Instances {
+						instanceType := aws.StringValue(instance.InstanceType)
+						if instanceType == "" ||
+							!strings.ContainsRune(standardInstanceFamilies, rune(instanceType[0])) {
+							continue
+						}
+						if instance.CpuOptions != nil {
+							vcpus += float64(aws.Int64Value(instance.CpuOptions.CoreCount) *
+								aws.Int64Value(instance.CpuOptions.ThreadsPerCore))
+						}
+					}
+				}
+				return true
+			},
+		)
+		return vcpus, err
+	case elasticIPsQuotaCode:
+		output, err := client.DescribeAddresses(&ec2.DescribeAddressesInput{})
+		if err != nil {
+			return 0, err
+		}
+		return float64(len(output.Addresses)), nil
+	case vpcsQuotaCode:
+		var vpcs float64
+		err := client.DescribeVpcsPages(
+			&ec2.DescribeVpcsInput{},
+			func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+				vpcs += float64(len(page.Vpcs))
+				return true
+			},
+		)
+		return vpcs, err
+	}
+	return 0, fmt.Errorf("unknown quota code '%s'", quotaCode)
+}
+
+// computeMachineTypeVCPUs returns the number of vCPUs of the compute machine type of the cluster,
+// as reported by the OCM API.
+func (r *ClusterRosaClassicResource) computeMachineTypeVCPUs(ctx context.Context,
+	state *ClusterRosaClassicState) (int, error) {
+	if state.ComputeMachineType.Unknown || state.ComputeMachineType.Null {
+		return defaultComputeVCPUs, nil
+	}
+	machineTypes, err := r.cache.getMachineTypes(func() ([]*cmv1.MachineType, error) {
+		return listMachineTypes(ctx, r.machineTypeCollection)
+	})
+	if err != nil {
+		return 0, err
+	}
+	var result int
+	found := false
+	for _, machineType := range machineTypes {
+		if machineType.ID() == state.ComputeMachineType.Value {
+			result = int(machineType.CPU().Value())
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("machine type '%s' doesn't exist", state.ComputeMachineType.Value)
+	}
+	return result, nil
+}
+
+// checkQuotas verifies that the AWS service quotas of the region of the cluster are enough for
+// the requested shape of the cluster. Shortfalls are reported as errors.
+func (r *ClusterRosaClassicResource) checkQuotas(ctx context.Context, state *ClusterRosaClassicState,
+	diags *diag.Diagnostics) {
+	if state.CloudRegion.Unknown || state.CloudRegion.Null {
+		return
+	}
+	computeVCPUs, err := r.computeMachineTypeVCPUs(ctx, state)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			preflightSummary,
+			fmt.Sprintf("Can't determine the shape of the compute nodes: %v", err),
+			err,
+		)
+		return
+	}
+	sess, err := buildSession(state.CloudRegion.Value, r.awsSettings)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			preflightSummary,
+			fmt.Sprintf("Can't verify the AWS service quotas: %v", err),
+			err,
+		)
+		return
+	}
+	requirements := quotaRequirements(state, computeVCPUs)
+	for _, err := range checkQuotas(ec2.New(sess), servicequotas.New(sess), requirements) {
+		addCodedError(diags, quotaDiagnosticCode, preflightSummary, fmt.Sprintf(
+			"Insufficient AWS quota in region '%s': %v", state.CloudRegion.Value, err,
+		))
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+// fakeQuotasClient returns the applied quotas stored in a map, and the AWS default of 5 for any
+// other quota.
+type fakeQuotasClient struct {
+	servicequotasiface.ServiceQuotasAPI
+	applied map[string]float64
+}
+
+func (c *fakeQuotasClient) GetServiceQuota(
+	input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	value, ok := c.applied[aws.StringValue(input.QuotaCode)]
+	if !ok {
+		return nil, fmt.Errorf("NoSuchResourceException")
+	}
+	return &servicequotas.GetServiceQuotaOutput{
+		Quota: &servicequotas.ServiceQuota{Value: aws.Float64(value)},
+	}, nil
+}
+
+func (c *fakeQuotasClient) GetAWSDefaultServiceQuota(
+	input *servicequotas.GetAWSDefaultServiceQuotaInput) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error) {
+	return &servicequotas.GetAWSDefaultServiceQuotaOutput{
+		Quota: &servicequotas.ServiceQuota{Value: aws.Float64(5)},
+	}, nil
+}
+
+// fakeEC2Client returns a fixed set of instances, addresses and VPCs.
+type fakeEC2Client struct {
+	ec2iface.EC2API
+	instances []*ec2.Instance
+	addresses int
+	vpcs      int
+}
+
+func (c *fakeEC2Client) DescribeInstancesPages(input *ec2.DescribeInstancesInput,
+	fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	fn(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: c.instances}},
+	}, true)
+	return nil
+}
+
+func (c *fakeEC2Client) DescribeAddresses(input *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	return &ec2.DescribeAddressesOutput{
+		Addresses: make([]*ec2.Address, c.addresses),
+	}, nil
+}
+
+func (c *fakeEC2Client) DescribeVpcsPages(input *ec2.DescribeVpcsInput,
+	fn func(*ec2.DescribeVpcsOutput, bool) bool) error {
+	fn(&ec2.DescribeVpcsOutput{
+		Vpcs: make([]*ec2.Vpc, c.vpcs),
+	}, true)
+	return nil
+}
+
+func fakeInstance(instanceType string, cores int64) *ec2.Instance {
+	return &ec2.Instance{
+		InstanceType: aws.String(instanceType),
+		CpuOptions: &ec2.CpuOptions{
+			CoreCount:      aws.Int64(cores),
+			ThreadsPerCore: aws.Int64(2),
+		},
+	}
+}
+
+var _ = Describe("Quota preflight checks", func() {
+	It("Calculates the requirements of a single zone cluster", func() {
+		state := &ClusterRosaClassicState{
+			MultiAZ:  types.Bool{Value: false},
+			Replicas: types.Int64{Value: 4},
+			AWSSubnetIDs: types.List{
+				ElemType: types.StringType,
+				Null:     true,
+			},
+		}
+		requirements := quotaRequirements(state, 4)
+		Expect(requirements).To(HaveLen(3))
+		Expect(requirements[0].quotaCode).To(Equal(standardVCPUsQuotaCode))
+		Expect(requirements[0].required).To(BeNumerically("==", 3*8+2*4+4+4*4))
+		Expect(requirements[1].quotaCode).To(Equal(elasticIPsQuotaCode))
+		Expect(requirements[1].required).To(BeNumerically("==", 1))
+	})
+
+	It("Uses the maximum replicas when autoscaling is enabled", func() {
+		state := &ClusterRosaClassicState{
+			MultiAZ:            types.Bool{Value: true},
+			AutoScalingEnabled: types.Bool{Value: true},
+			MaxReplicas:        types.Int64{Value: 6},
+			Replicas:           types.Int64{Null: true},
+			AWSSubnetIDs: types.List{
+				ElemType: types.StringType,
+				Elems:    []attr.Value{types.String{Value: "subnet-1"}},
+			},
+		}
+		requirements := quotaRequirements(state, 8)
+		Expect(requirements).To(HaveLen(1))
+		Expect(requirements[0].required).To(BeNumerically("==", 3*8+3*4+4+6*8))
+	})
+
+	It("Accepts quotas with enough free capacity", func() {
+		errs := checkQuotas(
+			&fakeEC2Client{
+				instances: []*ec2.Instance{fakeInstance("m5.xlarge", 2)},
+				addresses: 1,
+				vpcs:      1,
+			},
+			&fakeQuotasClient{
+				applied: map[string]float64{standardVCPUsQuotaCode: 100},
+			},
+			[]quotaRequirement{
+				{serviceCode: "ec2", quotaCode: standardVCPUsQuotaCode, required: 96},
+				{serviceCode: "ec2", quotaCode: elasticIPsQuotaCode, required: 3},
+				{serviceCode: "vpc", quotaCode: vpcsQuotaCode, required: 1},
+			},
+		)
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("Reports the quotas that aren't enough", func() {
+		errs := checkQuotas(
+			&fakeEC2Client{
+				instances: []*ec2.Instance{
+					fakeInstance("m5.4xlarge", 8),
+					fakeInstance("p3.2xlarge", 4),
+				},
+				vpcs: 5,
+			},
+			&fakeQuotasClient{
+				applied: map[string]float64{standardVCPUsQuotaCode: 64},
+			},
+			[]quotaRequirement{
+				{serviceCode: "ec2", quotaCode: standardVCPUsQuotaCode, required: 52, description: "vCPUs"},
+				{serviceCode: "vpc", quotaCode: vpcsQuotaCode, required: 1, description: "VPCs"},
+			},
+		)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Error()).To(ContainSubstring("needs 52 vCPUs but only 48"))
+		Expect(errs[1].Error()).To(ContainSubstring("'L-F678F1CE'"))
+	})
+})
@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// refreshCacheTTL is the time that the objects are kept in the refresh cache. It is long
+	// enough for a refresh of a large workspace, and short enough for the objects read by an
+	// apply to reflect the changes made by other tools in the meantime.
+	refreshCacheTTL = 30 * time.Second
+
+	// refreshFetchTimeout is the maximum time that the requests to fetch an entry of the refresh
+	// cache can take. The fetch doesn't use the context of the reader that started it, as other
+	// readers may be waiting for it, so this limits it instead.
+	refreshFetchTimeout = 5 * time.Minute
+)
+
+// Kinds of objects stored in the refresh cache:
+const (
+	refreshCluster           = "cluster"
+	refreshMachinePools      = "machine_pools"
+	refreshIdentityProviders = "identity_providers"
+)
+
+// refreshCache stores the objects retrieved while reading the state of the resources, so that
+// refreshing a workspace with a cluster and dozens of machine pools or identity providers sends
+// one request to get the cluster and one request to list each kind of sub-resource, instead of
+// one request per resource. Concurrent readers of the same entry wait for the first request.
+// Entries expire after a short time, errors aren't cached, and the entries of a cluster are
+// discarded when the provider changes it or any of its sub-resources.
+type refreshCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	entries map[refreshKey]*refreshEntry
+}
+
+// refreshKey identifies an entry of the refresh cache.
+type refreshKey struct {
+	cluster string
+	kind    string
+}
+
+// refreshEntry contains the result of fetching an entry of the refresh cache. The done channel
+// is closed when the fetch finishes. The expiration time is zero till then.
+type refreshEntry struct {
+	done    chan struct{}
+	expires time.Time
+	value   interface{}
+	err     error
+}
+
+func newRefreshCache() *refreshCache {
+	return &refreshCache{
+		ttl:     refreshCacheTTL,
+		entries: map[refreshKey]*refreshEntry{},
+	}
+}
+
+// get returns the value stored with the given key, calling the fetch function to retrieve it the
+// first time or when it has expired. The fetch runs in the background with a context that keeps
+// the values of the given one but isn't cancelled with it, so that a reader that gives up doesn't
+// make the other readers waiting for the same entry fail.
+func (c *refreshCache) get(ctx context.Context, key refreshKey,
+	fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	if !ok || (!entry.expires.IsZero() && time.Now().After(entry.expires)) {
+		entry = &refreshEntry{
+			done: make(chan struct{}),
+		}
+		c.entries[key] = entry
+		go c.fetch(detachedContext{parent: ctx}, key, entry, fetch)
+	}
+	c.lock.Unlock()
+	select {
+	case <-entry.done:
+		return entry.value, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch calls the fetch function to fill the given entry, and then sets its expiration time, or
+// removes it from the cache if it failed.
+func (c *refreshCache) fetch(ctx context.Context, key refreshKey, entry *refreshEntry,
+	fetch func(ctx context.Context) (interface{}, error)) {
+	ctx, cancel := context.WithTimeout(ctx, refreshFetchTimeout)
+	defer cancel()
+	value, err := fetch(ctx)
+	c.lock.Lock()
+	entry.value, entry.err = value, err
+	if err != nil {
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+	} else {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+	c.lock.Unlock()
+	close(entry.done)
+}
+
+// invalidate discards all the entries of the given cluster.
+func (c *refreshCache) invalidate(clusterID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key := range c.entries {
+		if key.cluster == clusterID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// getCluster returns the cluster with the given identifier.
+func (c *refreshCache) getCluster(ctx context.Context, collection *cmv1.ClustersClient,
+	clusterID string) (*cmv1.Cluster, error) {
+	key := refreshKey{cluster: clusterID, kind: refreshCluster}
+	value, err := c.get(ctx, key, func(ctx context.Context) (interface{}, error) {
+		get, err := collection.Cluster(clusterID).Get().SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return get.Body(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*cmv1.Cluster), nil
+}
+
+// getMachinePool returns the machine pool with the given identifier. All the machine pools of
+// the cluster are retrieved the first time.
+func (c *refreshCache) getMachinePool(ctx context.Context, collection *cmv1.ClustersClient,
+	clusterID, poolID string) (*cmv1.MachinePool, error) {
+	key := refreshKey{cluster: clusterID, kind: refreshMachinePools}
+	value, err := c.get(ctx, key, func(ctx context.Context) (interface{}, error) {
+		pools := map[string]*cmv1.MachinePool{}
+		page := 1
+		size := 100
+		for {
+			list, err := collection.Cluster(clusterID).MachinePools().List().
+				Page(page).
+				Size(size).
+				SendContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			list.Items().Each(func(item *cmv1.MachinePool) bool {
+				pools[item.ID()] = item
+				return true
+			})
+			if list.Items().Len() == 0 || len(pools) >= list.Total() {
+				break
+			}
+			page++
+		}
+		return pools, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	pool, ok := value.(map[string]*cmv1.MachinePool)[poolID]
+	if !ok {
+		return nil, fmt.Errorf("machine pool '%s' doesn't exist", poolID)
+	}
+	return pool, nil
+}
+
+// getIdentityProvider returns the identity provider with the given identifier. All the identity
+// providers of the cluster are retrieved the first time.
+func (c *refreshCache) getIdentityProvider(ctx context.Context, collection *cmv1.ClustersClient,
+	clusterID, idpID string) (*cmv1.IdentityProvider, error) {
+	key := refreshKey{cluster: clusterID, kind: refreshIdentityProviders}
+	value, err := c.get(ctx, key, func(ctx context.Context) (interface{}, error) {
+		idps := map[string]*cmv1.IdentityProvider{}
+		page := 1
+		size := 100
+		for {
+			list, err := collection.Cluster(clusterID).IdentityProviders().List().
+				Page(page).
+				Size(size).
+				SendContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			list.Items().Each(func(item *cmv1.IdentityProvider) bool {
+				idps[item.ID()] = item
+				return true
+			})
+			if list.Items().Len() == 0 || len(idps) >= list.Total() {
+				break
+			}
+			page++
+		}
+		return idps, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	idp, ok := value.(map[string]*cmv1.IdentityProvider)[idpID]
+	if !ok {
+		return nil, fmt.Errorf("identity provider '%s' doesn't exist", idpID)
+	}
+	return idp, nil
+}
+
+// detachedContext is a context that returns the values of its parent but is never cancelled and
+// has no deadline.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (c detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (c detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Refresh cache", func() {
+	key := refreshKey{cluster: "123", kind: refreshMachinePools}
+
+	It("Fetches each entry only once for concurrent readers", func() {
+		cache := newRefreshCache()
+		var calls int32
+		fetch := func(ctx context.Context) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return "pools", nil
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				value, err := cache.get(context.Background(), key, fetch)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal("pools"))
+			}()
+		}
+		wg.Wait()
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(1))
+	})
+
+	It("Doesn't cache errors", func() {
+		cache := newRefreshCache()
+		calls := 0
+		fetch := func(ctx context.Context) (interface{}, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("fail")
+			}
+			return "pools", nil
+		}
+		_, err := cache.get(context.Background(), key, fetch)
+		Expect(err).To(HaveOccurred())
+		value, err := cache.get(context.Background(), key, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("pools"))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("Discards the entries of a cluster when invalidated", func() {
+		cache := newRefreshCache()
+		other := refreshKey{cluster: "456", kind: refreshMachinePools}
+		calls := 0
+		fetch := func(ctx context.Context) (interface{}, error) {
+			calls++
+			return calls, nil
+		}
+		_, err := cache.get(context.Background(), key, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = cache.get(context.Background(), other, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		cache.invalidate("123")
+		value, err := cache.get(context.Background(), key, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(3))
+		value, err = cache.get(context.Background(), other, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(2))
+	})
+
+	It("Fetches the entry again when it has expired", func() {
+		cache := newRefreshCache()
+		cache.ttl = 10 * time.Millisecond
+		calls := 0
+		fetch := func(ctx context.Context) (interface{}, error) {
+			calls++
+			return calls, nil
+		}
+		value, err := cache.get(context.Background(), key, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(1))
+		value, err = cache.get(context.Background(), key, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(1))
+		time.Sleep(20 * time.Millisecond)
+		value, err = cache.get(context.Background(), key, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(2))
+	})
+
+	It("Doesn't fail other readers when the first one is cancelled", func() {
+		cache := newRefreshCache()
+		release := make(chan struct{})
+		fetch := func(ctx context.Context) (interface{}, error) {
+			select {
+			case <-release:
+				return "pools", nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		first, cancel := context.WithCancel(context.Background())
+		errs := make(chan error)
+		go func() {
+			_, err := cache.get(first, key, fetch)
+			errs <- err
+		}()
+		values := make(chan interface{})
+		go func() {
+			defer GinkgoRecover()
+			value, err := cache.get(context.Background(), key, fetch)
+			Expect(err).ToNot(HaveOccurred())
+			values <- value
+		}()
+		cancel()
+		Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+		close(release)
+		Eventually(values).Should(Receive(Equal("pools")))
+	})
+})
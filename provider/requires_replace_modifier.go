@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+)
+
+type requiresReplaceModifier struct {
+	logger logging.Logger
+	reason string
+}
+
+// RequiresReplaceModifier marks the resource for replacement when the value of the attribute
+// changes, so that Terraform shows that the change forces replacement at plan time. The reason
+// explains why the attribute can't be updated in place and is reported as a warning.
+func RequiresReplaceModifier(logger logging.Logger, reason string) tfsdk.AttributePlanModifier {
+	return requiresReplaceModifier{
+		logger: logger,
+		reason: reason,
+	}
+}
+
+func (m requiresReplaceModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Changing the value forces replacement of the resource: %s.", m.reason)
+}
+
+func (m requiresReplaceModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m requiresReplaceModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+
+	if req.AttributeConfig == nil || req.AttributeState == nil || req.AttributePlan == nil {
+		// shouldn't happen, but let's not panic if it does
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, no need to delete and
+		// recreate it
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and
+		// recreate it
+		return
+	}
+
+	attrSchema, err := req.State.Schema.AttributeAtPath(req.AttributePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath,
+			"Error finding attribute schema",
+			fmt.Sprintf("An unexpected error was encountered retrieving the schema for this attribute. This is always a bug in the provider.\n\nError: %s", err),
+		)
+		return
+	}
+
+	configRaw, err := req.AttributeConfig.ToTerraformValue(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath,
+			"Error converting config value",
+			fmt.Sprintf("An unexpected error was encountered converting a %s to its equivalent Terraform representation. This is always a bug in the provider.\n\nError: %s", req.AttributeConfig.Type(ctx), err),
+		)
+		return
+	}
+
+	if configRaw == nil && attrSchema.Computed {
+		// if the config is null and the attribute is computed, this
+		// could be an out-of-band change, don't require replacement
+		return
+	}
+
+	if req.AttributeState.Equal(req.AttributePlan) {
+		m.logger.Debug(ctx, "attribute state and attribute plan have the same value")
+		return
+	}
+
+	// the attribute value was changed
+	m.logger.Debug(ctx, "attribute plan was changed, the resource will be replaced")
+	resp.RequiresReplace = true
+	resp.Diagnostics.AddAttributeWarning(req.AttributePath, "Value change forces replacement",
+		fmt.Sprintf("This attribute can't be updated in place, %s. "+
+			"The resource will be destroyed and created again.", m.reason))
+}
@@ -109,12 +109,14 @@ func (r *RosaOidcConfigInputResource) Create(ctx context.Context,
 	region := state.Region.Value
 	oidcConfigInput, err := rosaoidcconfig.BuildOidcConfigInput("", region)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't generate oidc config input object",
 			fmt.Sprintf(
 				"Can't generate oidc config input object: %v",
 				err,
 			),
+			err,
 		)
 		return
 	}
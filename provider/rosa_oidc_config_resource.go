@@ -25,7 +25,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift-online/ocm-sdk-go/logging"
-	"strings"
 )
 
 type RosaOidcConfigResourceType struct {
@@ -51,6 +50,7 @@ func (t *RosaOidcConfigResourceType) GetSchema(ctx context.Context) (result tfsd
 			"secret_arn": {
 				Description: "Indicates for unmanaged OIDC config, the secret ARN",
 				Type:        types.StringType,
+				Validators:  ARNValidator(secretsManagerService, secretResourceType),
 				Optional:    true,
 			},
 			"issuer_url": {
@@ -62,6 +62,7 @@ func (t *RosaOidcConfigResourceType) GetSchema(ctx context.Context) (result tfsd
 			"installer_role_arn": {
 				Description: "STS Role ARN with get secrets permission",
 				Type:        types.StringType,
+				Validators:  ARNValidator(iamService, roleResourceType),
 				Optional:    true,
 			},
 			"id": {
@@ -75,9 +76,10 @@ func (t *RosaOidcConfigResourceType) GetSchema(ctx context.Context) (result tfsd
 				Computed:    true,
 			},
 			"oidc_endpoint_url": {
-				Description: "OIDC Endpoint URL",
-				Type:        types.StringType,
-				Computed:    true,
+				Description: "OIDC Endpoint URL without the scheme, that is the issuer " +
+					"URL without the `https://` prefix.",
+				Type:     types.StringType,
+				Computed: true,
 			},
 		},
 	}
@@ -132,11 +134,13 @@ func (r *RosaOidcConfigResource) Create(ctx context.Context,
 		}
 		oidcConfig, err = cmv1.NewOidcConfig().Managed(true).Build()
 		if err != nil {
-			response.Diagnostics.AddError(
+			addClassifiedError(
+				&response.Diagnostics,
 				"There was a problem building the managed OIDC Configuration",
 				fmt.Sprintf(
 					"There was a problem building the managed OIDC Configuration: %v", err,
 				),
+				err,
 			)
 			return
 		}
@@ -161,11 +165,13 @@ func (r *RosaOidcConfigResource) Create(ctx context.Context,
 			Build()
 
 		if err != nil {
-			response.Diagnostics.AddError(
+			addClassifiedError(
+				&response.Diagnostics,
 				"There was a problem building the unmanaged OIDC Configuration",
 				fmt.Sprintf(
 					"There was a problem building the unmanaged OIDC Configuration: %v", err,
 				),
+				err,
 			)
 			return
 		}
@@ -173,11 +179,13 @@ func (r *RosaOidcConfigResource) Create(ctx context.Context,
 
 	object, err := r.oidcConfigClient.Add().Body(oidcConfig).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"There was a problem registering the OIDC Configuration",
 			fmt.Sprintf(
 				"There was a problem registering the OIDC Configuration: %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -203,12 +211,14 @@ func (r *RosaOidcConfigResource) Read(ctx context.Context, request tfsdk.ReadRes
 	// Find the oidc config:
 	get, err := r.oidcConfigClient.OidcConfig(state.ID.Value).Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find OIDC config",
 			fmt.Sprintf(
 				"Can't find OIDC config with ID %s, %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -245,12 +255,14 @@ func (r *RosaOidcConfigResource) Delete(ctx context.Context, request tfsdk.Delet
 	// Find the oidc config:
 	get, err := r.oidcConfigClient.OidcConfig(state.ID.Value).Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"Can't find OIDC config",
 			fmt.Sprintf(
 				"Can't find OIDC config with ID %s, %v",
 				state.ID.Value, err,
 			),
+			err,
 		)
 		return
 	}
@@ -260,12 +272,14 @@ func (r *RosaOidcConfigResource) Delete(ctx context.Context, request tfsdk.Delet
 	// check if there is a cluster using the oidc endpoint:
 	hasClusterUsingOidcConfig, err := r.hasAClusterUsingOidcEndpointUrl(ctx, oidcConfig.IssuerUrl())
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"There was a problem checking if any clusters are using OIDC config",
 			fmt.Sprintf(
 				"There was a problem checking if any clusters are using OIDC config '%s' : %v",
 				oidcConfig.IssuerUrl(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -282,12 +296,14 @@ func (r *RosaOidcConfigResource) Delete(ctx context.Context, request tfsdk.Delet
 
 	err = r.deleteOidcConfig(ctx, state.ID.Value)
 	if err != nil {
-		response.Diagnostics.AddError(
+		addClassifiedError(
+			&response.Diagnostics,
 			"There was a problem deleting the OIDC config",
 			fmt.Sprintf(
 				"There was a problem deleting the OIDC config '%s' : %v",
 				oidcConfig.IssuerUrl(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -359,12 +375,8 @@ func (r *RosaOidcConfigResource) populateState(ctx context.Context, object *cmv1
 			Value: secretArn,
 		}
 	}
-	oidcEndpointURL := issuerUrl
-	if strings.HasPrefix(oidcEndpointURL, "https://") {
-		oidcEndpointURL = strings.TrimPrefix(oidcEndpointURL, "https://")
-	}
 	state.OIDCEndpointURL = types.String{
-		Value: oidcEndpointURL,
+		Value: oidcEndpointHostPath(issuerUrl),
 	}
 
 	thumbprint, err := getThumbprint(issuerUrl, DefaultHttpClient{})
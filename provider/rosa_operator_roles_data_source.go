@@ -19,7 +19,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
 )
 
 type RosaOperatorRolesDataSourceType struct {
@@ -58,6 +61,27 @@ func (t *RosaOperatorRolesDataSourceType) GetSchema(ctx context.Context) (result
 				Type:        types.StringType,
 				Optional:    true,
 			},
+			"aws_account_id": {
+				Description: "Identifier of the AWS account of the operator roles. When " +
+					"set the ARNs of the roles are calculated.",
+				Type:       types.StringType,
+				Optional:   true,
+				Validators: awsAccountIDValidators(),
+			},
+			"path": {
+				Description: "Path of the operator roles, for example '/openshift/'. " +
+					"Default value is '/'.",
+				Type:       types.StringType,
+				Optional:   true,
+				Validators: iamPathValidators(),
+			},
+			"aws_partition": {
+				Description: "AWS partition of the account, one of " +
+					"'" + strings.Join(awsPartitions, "', '") + "'. Default value is 'aws'.",
+				Type:       types.StringType,
+				Optional:   true,
+				Validators: EnumValueValidator(awsPartitions),
+			},
 			"operator_iam_roles": {
 				Description: "Operator IAM Roles.",
 				Attributes: tfsdk.ListNestedAttributes(
@@ -93,6 +117,12 @@ func (t *RosaOperatorRolesDataSourceType) itemAttributes() map[string]tfsdk.Attr
 			Type:        types.StringType,
 			Computed:    true,
 		},
+		"role_arn": {
+			Description: "ARN of the role, only calculated when 'aws_account_id' is set. " +
+				"This is the ARN that the cluster will use for the operator.",
+			Type:     types.StringType,
+			Computed: true,
+		},
 		"service_accounts": {
 			Description: "service accounts",
 			Type: types.ListType{
@@ -157,10 +187,30 @@ func (t *RosaOperatorRolesDataSource) Read(ctx context.Context, request tfsdk.Re
 		accountRolePrefix = state.AccountRolePrefix.Value
 	}
 
+	accountID := ""
+	if !common.IsStringAttributeEmpty(state.AWSAccountID) {
+		accountID = state.AWSAccountID.Value
+	}
+	path := "/"
+	if !common.IsStringAttributeEmpty(state.Path) {
+		path = state.Path.Value
+	}
+	partition := "aws"
+	if !common.IsStringAttributeEmpty(state.AWSPartition) {
+		partition = state.AWSPartition.Value
+	}
+
 	// TODO: use the sts.OperatorRolePrefix() if not empty
 	// There is a bug in the return value of sts.OperatorRolePrefix() - it's always empty string
 	sort.Strings(roleNameSpaces)
 	for _, key := range roleNameSpaces {
+		roleName := getRoleName(state.OperatorRolePrefix.Value, stsOperatorMap[key])
+		roleARN := types.String{Null: true}
+		if accountID != "" {
+			roleARN = types.String{
+				Value: getRoleARN(partition, accountID, path, roleName),
+			}
+		}
 		r := OperatorIAMRole{
 			Name: types.String{
 				Value: stsOperatorMap[key].Name(),
@@ -169,8 +219,9 @@ func (t *RosaOperatorRolesDataSource) Read(ctx context.Context, request tfsdk.Re
 				Value: stsOperatorMap[key].Namespace(),
 			},
 			RoleName: types.String{
-				Value: getRoleName(state.OperatorRolePrefix.Value, stsOperatorMap[key]),
+				Value: roleName,
 			},
+			RoleARN: roleARN,
 			PolicyName: types.String{
 				Value: getPolicyName(accountRolePrefix, stsOperatorMap[key].Namespace(), stsOperatorMap[key].Name()),
 			},
@@ -193,6 +244,11 @@ func getRoleName(rolePrefix string, operatorRole *cmv1.STSOperator) string {
 	return role
 }
 
+// getRoleARN returns the ARN of the role with the given name and path.
+func getRoleARN(partition, accountID, path, roleName string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role%s%s", partition, accountID, path, roleName)
+}
+
 // TODO: should be in a separate repo
 func getPolicyName(prefix string, namespace string, name string) string {
 	policy := fmt.Sprintf("%s-%s-%s", prefix, namespace, name)
@@ -215,3 +271,59 @@ func buildServiceAccountsArray(serviceAccountArr []string, operatorNamespace str
 
 	return serviceAccounts
 }
+
+// iamPathRE is the syntax of the paths of AWS IAM objects.
+var iamPathRE = regexp.MustCompile(`^/([\x21-\x7E]{0,510}/)?$`)
+
+func iamPathValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate IAM path",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				path := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, path)
+				if diag.HasError() || common.IsStringAttributeEmpty(*path) {
+					// No attribute to validate
+					return
+				}
+				if !iamPathRE.MatchString(path.Value) {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid IAM path",
+						fmt.Sprintf(
+							"Expected a path that starts and ends with '/', for example "+
+								"'/openshift/', but got '%s'",
+							path.Value,
+						),
+					)
+				}
+			},
+		},
+	}
+}
+
+func awsAccountIDValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate AWS account identifier",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				accountID := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, accountID)
+				if diag.HasError() || common.IsStringAttributeEmpty(*accountID) {
+					// No attribute to validate
+					return
+				}
+				if !awsAccountRE.MatchString(accountID.Value) {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid AWS account identifier",
+						fmt.Sprintf(
+							"Expected a 12 digit AWS account identifier but got '%s'",
+							accountID.Value,
+						),
+					)
+				}
+			},
+		},
+	}
+}
@@ -21,6 +21,9 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 type RosaOperatorRolesState struct {
 	OperatorRolePrefix types.String       `tfsdk:"operator_role_prefix"`
 	AccountRolePrefix  types.String       `tfsdk:"account_role_prefix"`
+	AWSAccountID       types.String       `tfsdk:"aws_account_id"`
+	Path               types.String       `tfsdk:"path"`
+	AWSPartition       types.String       `tfsdk:"aws_partition"`
 	OperatorIAMRoles   []*OperatorIAMRole `tfsdk:"operator_iam_roles"`
 }
 
@@ -29,5 +32,6 @@ type OperatorIAMRole struct {
 	Namespace       types.String `tfsdk:"operator_namespace"`
 	RoleName        types.String `tfsdk:"role_name"`
 	PolicyName      types.String `tfsdk:"policy_name"`
+	RoleARN         types.String `tfsdk:"role_arn"`
 	ServiceAccounts types.List   `tfsdk:"service_accounts"`
 }
@@ -1,22 +1,40 @@
 package provider
 
 import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/openshift-online/ocm-sdk-go/logging"
 )
 
-func stsResource() tfsdk.NestedAttributes {
+// stsReplaceReason explains why changing the STS configuration forces replacement of the cluster.
+const stsReplaceReason = "the STS roles and the OIDC configuration are bound to the cluster at install time"
+
+func stsResource(logger logging.Logger) tfsdk.NestedAttributes {
 	return tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
 		"oidc_endpoint_url": {
-			Description: "OIDC Endpoint URL",
-			Type:        types.StringType,
-			Optional:    true,
-			Computed:    true,
+			Description: "OIDC Endpoint URL without the scheme, for example " +
+				"`oidc.example.com/abc`. This is the form used in the ARN of the AWS OIDC " +
+				"provider and in the conditions of the trust policies of the operator roles.",
+			Type:     types.StringType,
+			Optional: true,
+			Computed: true,
+		},
+		"oidc_issuer_url": {
+			Description: "OIDC Endpoint URL including the `https://` scheme, as required " +
+				"by the `url` attribute of the `aws_iam_openid_connect_provider` resource.",
+			Type:     types.StringType,
+			Computed: true,
 		},
 		"oidc_config_id": {
 			Description: "OIDC Configuration ID",
 			Type:        types.StringType,
 			Optional:    true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				RequiresReplaceModifier(logger, stsReplaceReason),
+			},
 		},
 		"thumbprint": {
 			Description: "SHA1-hash value of the root CA of the issuer URL",
@@ -26,12 +44,20 @@ func stsResource() tfsdk.NestedAttributes {
 		"role_arn": {
 			Description: "Installer Role",
 			Type:        types.StringType,
+			Validators:  ARNValidator(iamService, roleResourceType),
 			Required:    true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				RequiresReplaceModifier(logger, stsReplaceReason),
+			},
 		},
 		"support_role_arn": {
 			Description: "Support Role",
 			Type:        types.StringType,
+			Validators:  ARNValidator(iamService, roleResourceType),
 			Required:    true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				RequiresReplaceModifier(logger, stsReplaceReason),
+			},
 		},
 		"instance_iam_roles": {
 			Description: "Instance IAM Roles",
@@ -39,21 +65,68 @@ func stsResource() tfsdk.NestedAttributes {
 				"master_role_arn": {
 					Description: "Master/Controller Plane Role ARN",
 					Type:        types.StringType,
+					Validators:  ARNValidator(iamService, roleResourceType),
 					Required:    true,
+					PlanModifiers: []tfsdk.AttributePlanModifier{
+						RequiresReplaceModifier(logger, stsReplaceReason),
+					},
 				},
 				"worker_role_arn": {
 					Description: "Worker Node Role ARN",
 					Type:        types.StringType,
+					Validators:  ARNValidator(iamService, roleResourceType),
 					Required:    true,
+					PlanModifiers: []tfsdk.AttributePlanModifier{
+						RequiresReplaceModifier(logger, stsReplaceReason),
+					},
 				},
 			}),
 			Required: true,
 		},
+		"operator_role_arns": {
+			Description: "ARNs of the operator roles that the cluster actually uses, as " +
+				"reported by OCM. The keys are the namespace and the name of the " +
+				"credentials of the operator separated by a slash, for example " +
+				"'openshift-ingress-operator/cloud-credentials'.",
+			Type: types.MapType{
+				ElemType: types.StringType,
+			},
+			Computed: true,
+		},
 		"operator_role_prefix": {
 			Description: "Operator IAM Role prefix",
 			Type:        types.StringType,
 			Required:    true,
+			PlanModifiers: []tfsdk.AttributePlanModifier{
+				RequiresReplaceModifier(logger, stsReplaceReason),
+			},
 		},
 	})
 
 }
+
+// oidcEndpointHostPath returns the given OIDC endpoint URL without the scheme.
+func oidcEndpointHostPath(url string) string {
+	url = strings.TrimPrefix(url, "https://")
+	url = strings.TrimPrefix(url, "http://")
+	return strings.TrimSuffix(url, "/")
+}
+
+// oidcIssuerURL returns the given OIDC endpoint URL with the 'https' scheme, adding it if it
+// isn't already there.
+func oidcIssuerURL(url string) string {
+	if url == "" || strings.Contains(url, "://") {
+		return url
+	}
+	return "https://" + url
+}
+
+// arnAccountID returns the AWS account identifier contained in the given ARN, or an empty string
+// if it can't be parsed.
+func arnAccountID(text string) string {
+	parsed, err := arn.Parse(text)
+	if err != nil {
+		return ""
+	}
+	return parsed.AccountID
+}
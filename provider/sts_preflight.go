@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	preflightSummary = "Preflight check failed"
+	ec2Service       = "ec2.amazonaws.com"
+)
+
+// accountRole describes one of the account roles referenced by the 'sts' attribute of a cluster.
+type accountRole struct {
+	// path is the path of the attribute that contains the ARN of the role.
+	path *tftypes.AttributePath
+
+	// arn is the ARN of the role.
+	arn string
+
+	// service is the AWS service that the role should trust. When empty the role is expected to
+	// trust an AWS principal instead.
+	service string
+}
+
+// accountRoles returns the account roles of the given cluster that are known and not empty.
+func accountRoles(state *ClusterRosaClassicState) []accountRole {
+	if state.Sts == nil {
+		return nil
+	}
+	candidates := []accountRole{
+		{
+			path: stsAttributePath("role_arn"),
+			arn:  state.Sts.RoleARN.Value,
+		},
+		{
+			path: stsAttributePath("support_role_arn"),
+			arn:  state.Sts.SupportRoleArn.Value,
+		},
+		{
+			path:    stsAttributePath("instance_iam_roles", "master_role_arn"),
+			arn:     state.Sts.InstanceIAMRoles.MasterRoleARN.Value,
+			service: ec2Service,
+		},
+		{
+			path:    stsAttributePath("instance_iam_roles", "worker_role_arn"),
+			arn:     state.Sts.InstanceIAMRoles.WorkerRoleARN.Value,
+			service: ec2Service,
+		},
+	}
+	var result []accountRole
+	for _, candidate := range candidates {
+		if candidate.arn != "" {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// stsAttributePath returns the path of the given nested attribute of the 'sts' attribute.
+func stsAttributePath(names ...string) *tftypes.AttributePath {
+	path := tftypes.NewAttributePath().WithAttributeName("sts")
+	for _, name := range names {
+		path = path.WithAttributeName(name)
+	}
+	return path
+}
+
+// checkAccountRoles verifies, using the AWS API, that the account roles of the cluster exist,
+// belong to the AWS account of the cluster and have the expected trust policy. Problems are
+// reported as attribute errors.
+func (r *ClusterRosaClassicResource) checkAccountRoles(ctx context.Context, state *ClusterRosaClassicState,
+	diags *diag.Diagnostics) {
+	roles := accountRoles(state)
+	if len(roles) == 0 {
+		return
+	}
+	accountID := ""
+	if !state.AWSAccountID.Unknown && !state.AWSAccountID.Null {
+		accountID = state.AWSAccountID.Value
+	}
+
+	sess, err := buildSession(state.CloudRegion.Value, r.awsSettings)
+	if err != nil {
+		addClassifiedError(
+			diags,
+			preflightSummary,
+			fmt.Sprintf("Can't verify the account roles: %v", err),
+			err,
+		)
+		return
+	}
+	iamClient := iam.New(sess)
+	for _, role := range roles {
+		r.logger.Debug(ctx, "Verifying account role '%s'", role.arn)
+		err = checkAccountRole(iamClient, role, accountID)
+		if err != nil {
+			diags.AddAttributeError(role.path, preflightSummary, err.Error())
+		}
+	}
+}
+
+// checkAccountRole verifies that the given role exists, belongs to the given AWS account and
+// has the expected trust policy.
+func checkAccountRole(iamClient iamiface.IAMAPI, role accountRole, accountID string) error {
+	roleName, err := roleNameFromARN(role.arn, accountID)
+	if err != nil {
+		return err
+	}
+	output, err := iamClient.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil {
+		return fmt.Errorf("can't get role '%s': %v", role.arn, err)
+	}
+	document := aws.StringValue(output.Role.AssumeRolePolicyDocument)
+	trusted, err := trustPolicyAllows(document, role.service)
+	if err != nil {
+		return fmt.Errorf("can't parse the trust policy of role '%s': %v", role.arn, err)
+	}
+	if !trusted {
+		if role.service != "" {
+			return fmt.Errorf(
+				"the trust policy of role '%s' doesn't allow the '%s' service to assume it",
+				role.arn, role.service,
+			)
+		}
+		return fmt.Errorf(
+			"the trust policy of role '%s' doesn't allow any AWS principal to assume it",
+			role.arn,
+		)
+	}
+	return nil
+}
+
+// roleNameFromARN checks that the given text is the ARN of an IAM role that belongs to the given
+// AWS account and returns the name of the role. The account isn't checked if it is empty.
+func roleNameFromARN(text, accountID string) (string, error) {
+	err := validateARN(text, iamService, roleResourceType, accountID)
+	if err != nil {
+		return "", err
+	}
+	parsed, err := arn.Parse(text)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Resource[strings.LastIndex(parsed.Resource, "/")+1:], nil
+}
+
+// policyDocument is the subset of an IAM policy document needed to check trust policies.
+type policyDocument struct {
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Effect    string          `json:"Effect"`
+	Action    stringOrSlice   `json:"Action"`
+	Principal json.RawMessage `json:"Principal"`
+}
+
+// stringOrSlice is a JSON value that can be written either as a single string or as an array of
+// strings, like most of the values of IAM policy documents.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*s = []string{single}
+		return nil
+	}
+	var multiple []string
+	err := json.Unmarshal(data, &multiple)
+	if err != nil {
+		return err
+	}
+	*s = multiple
+	return nil
+}
+
+// trustPolicyAllows checks if the given trust policy document, which may be URL encoded as
+// returned by the IAM API, allows the given service to assume the role. If the service is empty
+// it checks that the policy allows some AWS principal to assume the role.
+func trustPolicyAllows(document, service string) (bool, error) {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return false, err
+	}
+	var policy policyDocument
+	err = json.Unmarshal([]byte(decoded), &policy)
+	if err != nil {
+		return false, err
+	}
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || !containsAssumeRole(statement.Action) {
+			continue
+		}
+		principals := map[string]stringOrSlice{}
+		if json.Unmarshal(statement.Principal, &principals) != nil {
+			continue
+		}
+		if service == "" {
+			if len(principals["AWS"]) > 0 {
+				return true, nil
+			}
+			continue
+		}
+		for _, trusted := range principals["Service"] {
+			if trusted == service {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func containsAssumeRole(actions stringOrSlice) bool {
+	for _, action := range actions {
+		if action == "sts:AssumeRole" || action == "sts:*" || action == "*" {
+			return true
+		}
+	}
+	return false
+}
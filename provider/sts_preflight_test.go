@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+// fakeIAMClient returns the roles and OIDC providers stored in maps, and an error for any other
+// role or provider.
+type fakeIAMClient struct {
+	iamiface.IAMAPI
+	roles     map[string]*iam.Role
+	providers map[string]bool
+}
+
+func (c *fakeIAMClient) GetOpenIDConnectProvider(input *iam.GetOpenIDConnectProviderInput) (
+	*iam.GetOpenIDConnectProviderOutput, error) {
+	providerARN := aws.StringValue(input.OpenIDConnectProviderArn)
+	if !c.providers[providerARN] {
+		return nil, fmt.Errorf("NoSuchEntity: OIDC provider '%s' not found", providerARN)
+	}
+	return &iam.GetOpenIDConnectProviderOutput{}, nil
+}
+
+func (c *fakeIAMClient) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	role, ok := c.roles[aws.StringValue(input.RoleName)]
+	if !ok {
+		return nil, fmt.Errorf("NoSuchEntity: role '%s' not found", aws.StringValue(input.RoleName))
+	}
+	return &iam.GetRoleOutput{Role: role}, nil
+}
+
+const (
+	installerTrustPolicy = `{
+	  "Version": "2012-10-17",
+	  "Statement": [{
+	    "Effect": "Allow",
+	    "Principal": {"AWS": "arn:aws:iam::710019948333:role/RH-Managed-OpenShift-Installer"},
+	    "Action": "sts:AssumeRole"
+	  }]
+	}`
+	workerTrustPolicy = `{
+	  "Version": "2012-10-17",
+	  "Statement": [{
+	    "Effect": "Allow",
+	    "Principal": {"Service": ["ec2.amazonaws.com"]},
+	    "Action": ["sts:AssumeRole"]
+	  }]
+	}`
+)
+
+var _ = Describe("STS preflight checks", func() {
+	iamClient := &fakeIAMClient{
+		roles: map[string]*iam.Role{
+			"ManagedOpenShift-Installer-Role": {
+				AssumeRolePolicyDocument: aws.String(url.QueryEscape(installerTrustPolicy)),
+			},
+			"ManagedOpenShift-Worker-Role": {
+				AssumeRolePolicyDocument: aws.String(url.QueryEscape(workerTrustPolicy)),
+			},
+		},
+	}
+
+	It("Accepts an installer role trusted by an AWS principal", func() {
+		err := checkAccountRole(iamClient, accountRole{
+			arn: "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+		}, "123456789012")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Accepts a worker role trusted by the EC2 service", func() {
+		err := checkAccountRole(iamClient, accountRole{
+			arn:     "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role",
+			service: ec2Service,
+		}, "123456789012")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Rejects a role with the wrong trust policy", func() {
+		err := checkAccountRole(iamClient, accountRole{
+			arn:     "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+			service: ec2Service,
+		}, "123456789012")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("doesn't allow the 'ec2.amazonaws.com' service"))
+	})
+
+	It("Rejects a role that doesn't exist", func() {
+		err := checkAccountRole(iamClient, accountRole{
+			arn: "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
+		}, "123456789012")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("NoSuchEntity"))
+	})
+
+	It("Rejects a role from a different account", func() {
+		err := checkAccountRole(iamClient, accountRole{
+			arn: "arn:aws:iam::210987654321:role/ManagedOpenShift-Installer-Role",
+		}, "123456789012")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("belongs to AWS account '210987654321'"))
+	})
+
+	It("Rejects an ARN that isn't a role", func() {
+		_, err := roleNameFromARN("arn:aws:iam::123456789012:user/my-user", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
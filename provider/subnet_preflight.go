@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/thoas/go-funk"
+)
+
+// Tags that the installer uses to find the subnets where the load balancers are created:
+const (
+	publicSubnetRoleTag  = "kubernetes.io/role/elb"
+	privateSubnetRoleTag = "kubernetes.io/role/internal-elb"
+)
+
+// defaultMachineCIDR is the machine CIDR used by OCM when the cluster doesn't specify one.
+const defaultMachineCIDR = "10.0.0.0/16"
+
+// checkSubnets verifies that the given subnets exist, that all of them belong to the same VPC,
+// that their CIDR blocks are inside the machine CIDR of the cluster, and that they have the role
+// tag that corresponds to them being public or private. It returns one error for each problem
+// found.
+func checkSubnets(client ec2iface.EC2API, subnetIDs []string, machineCIDR string) []error {
+	_, machineNet, err := net.ParseCIDR(machineCIDR)
+	if err != nil {
+		return []error{fmt.Errorf("machine CIDR '%s' isn't valid: %v", machineCIDR, err)}
+	}
+	output, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return []error{fmt.Errorf("can't describe subnets '%s': %v",
+			strings.Join(subnetIDs, "', '"), err)}
+	}
+	subnets := map[string]*ec2.Subnet{}
+	for _, subnet := range output.Subnets {
+		subnets[aws.StringValue(subnet.SubnetId)] = subnet
+	}
+
+	var result []error
+	vpcs := map[string]bool{}
+	for _, id := range subnetIDs {
+		subnet, ok := subnets[id]
+		if !ok {
+			result = append(result, fmt.Errorf("subnet '%s' doesn't exist", id))
+			continue
+		}
+		vpcs[aws.StringValue(subnet.VpcId)] = true
+		_, subnetNet, err := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+		if err != nil || !cidrContains(machineNet, subnetNet) {
+			result = append(result, fmt.Errorf(
+				"CIDR block '%s' of subnet '%s' isn't inside the machine CIDR '%s'",
+				aws.StringValue(subnet.CidrBlock), id, machineCIDR,
+			))
+		}
+	}
+	if len(vpcs) > 1 {
+		names := make([]string, 0, len(vpcs))
+		for vpc := range vpcs {
+			names = append(names, vpc)
+		}
+		sort.Strings(names)
+		return append(result, fmt.Errorf(
+			"subnets must belong to the same VPC, but they belong to '%s'",
+			strings.Join(names, "', '"),
+		))
+	}
+	if len(vpcs) == 0 {
+		return result
+	}
+
+	// The subnets that are routed to an internet gateway are public, the rest are private:
+	public, err := publicSubnets(client, output.Subnets)
+	if err != nil {
+		return append(result, err)
+	}
+	for _, id := range subnetIDs {
+		subnet, ok := subnets[id]
+		if !ok {
+			continue
+		}
+		tag := privateSubnetRoleTag
+		kind := "private"
+		if public[id] {
+			tag = publicSubnetRoleTag
+			kind = "public"
+		}
+		if !hasTag(subnet.Tags, tag) {
+			result = append(result, fmt.Errorf(
+				"%s subnet '%s' doesn't have the '%s' tag", kind, id, tag,
+			))
+		}
+	}
+	return result
+}
+
+// publicSubnets returns the set of the given subnets whose route table, or the main route table
+// of the VPC when they don't have one, has a route to an internet gateway.
+func publicSubnets(client ec2iface.EC2API, subnets []*ec2.Subnet) (map[string]bool, error) {
+	var vpcs []string
+	for _, subnet := range subnets {
+		vpc := aws.StringValue(subnet.VpcId)
+		if !funk.ContainsString(vpcs, vpc) {
+			vpcs = append(vpcs, vpc)
+		}
+	}
+	var tables []*ec2.RouteTable
+	err := client.DescribeRouteTablesPages(
+		&ec2.DescribeRouteTablesInput{
+			Filters: []*ec2.Filter{{
+				Name:   aws.String("vpc-id"),
+				Values: aws.StringSlice(vpcs),
+			}},
+		},
+		func(page *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+			tables = append(tables, page.RouteTables...)
+			return true
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't describe route tables of VPC '%s': %v",
+			strings.Join(vpcs, "', '"), err)
+	}
+	main := map[string]*ec2.RouteTable{}
+	explicit := map[string]*ec2.RouteTable{}
+	for _, table := range tables {
+		for _, association := range table.Associations {
+			if aws.BoolValue(association.Main) {
+				main[aws.StringValue(table.VpcId)] = table
+			}
+			if association.SubnetId != nil {
+				explicit[aws.StringValue(association.SubnetId)] = table
+			}
+		}
+	}
+	result := map[string]bool{}
+	for _, subnet := range subnets {
+		id := aws.StringValue(subnet.SubnetId)
+		table, ok := explicit[id]
+		if !ok {
+			table = main[aws.StringValue(subnet.VpcId)]
+		}
+		if table == nil {
+			continue
+		}
+		for _, route := range table.Routes {
+			if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-") {
+				result[id] = true
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// cidrContains checks if the inner network is completely inside the outer one.
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerSize, _ := outer.Mask.Size()
+	innerSize, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && innerSize >= outerSize
+}
+
+// hasTag checks if the given tags contain the given key.
+func hasTag(tags []*ec2.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSubnets verifies, using the AWS API, the subnets given in the 'aws_subnet_ids' attribute.
+// The check is skipped with a warning when there are no AWS credentials.
+func (r *ClusterRosaClassicResource) checkSubnets(ctx context.Context, state *ClusterRosaClassicState,
+	diags *diag.Diagnostics) {
+	if state.AWSSubnetIDs.Unknown || state.AWSSubnetIDs.Null || len(state.AWSSubnetIDs.Elems) == 0 ||
+		state.CloudRegion.Unknown || state.CloudRegion.Null || state.MachineCIDR.Unknown {
+		return
+	}
+	var subnetIDs []string
+	for _, elem := range state.AWSSubnetIDs.Elems {
+		subnet, ok := elem.(types.String)
+		if !ok || subnet.Unknown {
+			return
+		}
+		subnetIDs = append(subnetIDs, subnet.Value)
+	}
+	machineCIDR := defaultMachineCIDR
+	if !state.MachineCIDR.Null && state.MachineCIDR.Value != "" {
+		machineCIDR = state.MachineCIDR.Value
+	}
+	sess, err := buildSession(state.CloudRegion.Value, r.awsSettings)
+	if err != nil {
+		diags.AddWarning(
+			"Subnets not verified",
+			fmt.Sprintf("Can't verify the subnets without AWS credentials: %v", err),
+		)
+		return
+	}
+	path := tftypes.NewAttributePath().WithAttributeName("aws_subnet_ids")
+	for _, err := range checkSubnets(ec2.New(sess), subnetIDs, machineCIDR) {
+		diags.AddAttributeError(path, preflightSummary, err.Error())
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+// fakeSubnetsClient returns a fixed set of subnets and route tables.
+type fakeSubnetsClient struct {
+	ec2iface.EC2API
+	subnets []*ec2.Subnet
+	tables  []*ec2.RouteTable
+}
+
+func (c *fakeSubnetsClient) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	output := &ec2.DescribeSubnetsOutput{}
+	for _, id := range input.SubnetIds {
+		for _, subnet := range c.subnets {
+			if aws.StringValue(subnet.SubnetId) == aws.StringValue(id) {
+				output.Subnets = append(output.Subnets, subnet)
+			}
+		}
+	}
+	return output, nil
+}
+
+// DescribeSubnetsPages supports only the 'vpc-id' filter.
+func (c *fakeSubnetsClient) DescribeSubnetsPages(input *ec2.DescribeSubnetsInput,
+	fn func(*ec2.DescribeSubnetsOutput, bool) bool) error {
+	output := &ec2.DescribeSubnetsOutput{}
+	for _, subnet := range c.subnets {
+		matches := true
+		for _, filter := range input.Filters {
+			if aws.StringValue(filter.Name) == "vpc-id" &&
+				aws.StringValue(filter.Values[0]) != aws.StringValue(subnet.VpcId) {
+				matches = false
+			}
+		}
+		if matches {
+			output.Subnets = append(output.Subnets, subnet)
+		}
+	}
+	fn(output, true)
+	return nil
+}
+
+func (c *fakeSubnetsClient) DescribeRouteTablesPages(input *ec2.DescribeRouteTablesInput,
+	fn func(*ec2.DescribeRouteTablesOutput, bool) bool) error {
+	fn(&ec2.DescribeRouteTablesOutput{RouteTables: c.tables}, true)
+	return nil
+}
+
+func fakeSubnet(id, vpc, cidr string, tags ...string) *ec2.Subnet {
+	subnet := &ec2.Subnet{
+		SubnetId:  aws.String(id),
+		VpcId:     aws.String(vpc),
+		CidrBlock: aws.String(cidr),
+	}
+	for _, tag := range tags {
+		subnet.Tags = append(subnet.Tags, &ec2.Tag{Key: aws.String(tag), Value: aws.String("1")})
+	}
+	return subnet
+}
+
+var _ = Describe("Subnet preflight checks", func() {
+	var client *fakeSubnetsClient
+
+	BeforeEach(func() {
+		client = &fakeSubnetsClient{
+			subnets: []*ec2.Subnet{
+				fakeSubnet("subnet-public", "vpc-1", "10.0.0.0/24", publicSubnetRoleTag),
+				fakeSubnet("subnet-private", "vpc-1", "10.0.1.0/24", privateSubnetRoleTag),
+				fakeSubnet("subnet-untagged", "vpc-1", "10.0.2.0/24"),
+				fakeSubnet("subnet-outside", "vpc-1", "192.168.0.0/24", privateSubnetRoleTag),
+				fakeSubnet("subnet-other", "vpc-2", "10.0.3.0/24", privateSubnetRoleTag),
+			},
+			tables: []*ec2.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+					Routes: []*ec2.Route{{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						NatGatewayId:         aws.String("nat-1"),
+					}},
+				},
+				{
+					VpcId: aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{
+						SubnetId: aws.String("subnet-public"),
+					}},
+					Routes: []*ec2.Route{{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						GatewayId:            aws.String("igw-1"),
+					}},
+				},
+			},
+		}
+	})
+
+	It("Accepts tagged subnets of one VPC inside the machine CIDR", func() {
+		errs := checkSubnets(client, []string{"subnet-public", "subnet-private"}, "10.0.0.0/16")
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("Rejects subnets that don't exist", func() {
+		errs := checkSubnets(client, []string{"subnet-private", "subnet-missing"}, "10.0.0.0/16")
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError("subnet 'subnet-missing' doesn't exist"))
+	})
+
+	It("Rejects subnets of different VPCs", func() {
+		errs := checkSubnets(client, []string{"subnet-private", "subnet-other"}, "10.0.0.0/16")
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(
+			"subnets must belong to the same VPC, but they belong to 'vpc-1', 'vpc-2'",
+		))
+	})
+
+	It("Rejects subnets outside the machine CIDR", func() {
+		errs := checkSubnets(client, []string{"subnet-outside"}, "10.0.0.0/16")
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("isn't inside the machine CIDR"))
+	})
+
+	It("Rejects subnets without the role tag", func() {
+		errs := checkSubnets(client, []string{"subnet-untagged"}, "10.0.0.0/16")
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(
+			"private subnet 'subnet-untagged' doesn't have the '" + privateSubnetRoleTag + "' tag",
+		))
+	})
+})
@@ -0,0 +1,590 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// redactedValue replaces the values of sensitive fields in the log.
+	redactedValue = "***"
+
+	// maxLoggedBodySize is the maximum number of bytes of a request or response body that will
+	// be written to the log.
+	maxLoggedBodySize = 4096
+)
+
+// sensitiveFields contains the names of the JSON and form fields whose values are never written
+// to the log.
+var sensitiveFields = map[string]bool{
+	"access_token":            true,
+	"refresh_token":           true,
+	"id_token":                true,
+	"token":                   true,
+	"password":                true,
+	"hashed_password":         true,
+	"bind_password":           true,
+	"client_secret":           true,
+	"secret_access_key":       true,
+	"additional_trust_bundle": true,
+	"ca":                      true,
+	"kubeconfig":              true,
+}
+
+// transportSettings contains the settings of the provider that decide the round trippers that are
+// added to the connection.
+type transportSettings struct {
+	proxy       *httpproxy.Config
+	rateLimiter *rateLimiter
+	debug       bool
+}
+
+// transportWrappers returns the transport wrappers for the given settings, in the order that they
+// have to be added to the connection builder. The SDK calls the round trippers in that same order,
+// so the first one is the outermost and the last one wraps directly the HTTP transport created by
+// the SDK.
+func transportWrappers(settings transportSettings) []func(http.RoundTripper) http.RoundTripper {
+	var result []func(http.RoundTripper) http.RoundTripper
+	if settings.rateLimiter != nil {
+		result = append(result, rateLimitTransportWrapper(settings.rateLimiter))
+	}
+
+	// The conditional requests are added before the logging wrapper, so that the log shows the
+	// real responses of the server, including the '304 Not Modified' ones, and not the ones
+	// built from the kept responses:
+	result = append(result, etagTransportWrapper)
+	if settings.debug {
+		result = append(result, loggingTransportWrapper)
+	}
+
+	// The proxy wrapper replaces the HTTP transport created by the SDK with a copy that uses the
+	// proxy, so it only works when it is the innermost one:
+	result = append(result, proxyTransportWrapper(settings.proxy))
+
+	return result
+}
+
+// proxyTransportWrapper returns a transport wrapper that selects the proxy for each request of
+// the connection using the given configuration. The TLS configuration of the wrapped transport,
+// including the trusted certificate authorities, is preserved. It needs to wrap directly the HTTP
+// transport created by the SDK, so it must be the last wrapper added to the connection.
+func proxyTransportWrapper(config *httpproxy.Config) func(http.RoundTripper) http.RoundTripper {
+	proxyFunc := config.ProxyFunc()
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		transport, ok := wrapped.(*http.Transport)
+		if !ok {
+			return wrapped
+		}
+		transport = transport.Clone()
+		transport.Proxy = func(request *http.Request) (*url.URL, error) {
+			return proxyFunc(request.URL)
+		}
+		return transport
+	}
+}
+
+// proxyConfig returns the proxy configuration of the connection. It starts from the standard
+// 'HTTPS_PROXY', 'HTTP_PROXY' and 'NO_PROXY' environment variables, and the given proxy URL and
+// list of excluded hosts replace them when they aren't empty.
+func proxyConfig(proxyURL *url.URL, noProxy string) *httpproxy.Config {
+	result := httpproxy.FromEnvironment()
+	if proxyURL != nil {
+		result.HTTPProxy = proxyURL.String()
+		result.HTTPSProxy = proxyURL.String()
+	}
+	if noProxy != "" {
+		result.NoProxy = noProxy
+	}
+	return result
+}
+
+// parseProxyURL checks that the given text is a valid proxy URL, with an 'http' or 'https'
+// scheme and a host.
+func parseProxyURL(text string) (*url.URL, error) {
+	result, err := url.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if result.Scheme != "http" && result.Scheme != "https" {
+		return nil, fmt.Errorf(
+			"expected scheme 'http' or 'https' but got '%s'",
+			result.Scheme,
+		)
+	}
+	if result.Host == "" {
+		return nil, fmt.Errorf("expected a host name")
+	}
+	return result, nil
+}
+
+// loggingTransport is a round tripper that writes a summary of each request and response to the
+// Terraform log, removing the values of the sensitive fields. The summary of the response
+// includes the time that the server took to answer, so that slow calls to the API can be told
+// apart from the time spent by the provider waiting between checks.
+type loggingTransport struct {
+	wrapped http.RoundTripper
+}
+
+// loggingTransportWrapper wraps the given transport so that requests and responses are written
+// to the Terraform log with sensitive values redacted.
+func loggingTransportWrapper(wrapped http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{
+		wrapped: wrapped,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(request *http.Request) (response *http.Response, err error) {
+	ctx := request.Context()
+
+	// Read a copy of the request body, leaving the original untouched:
+	var requestBody []byte
+	if request.Body != nil && request.GetBody != nil {
+		body, err := request.GetBody()
+		if err == nil {
+			requestBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+	tflog.Debug(
+		ctx, "Sending OCM API request",
+		"method", request.Method,
+		"url", request.URL.String(),
+		"body", redactBody(request.Header.Get("Content-Type"), requestBody),
+	)
+
+	start := time.Now()
+	response, err = t.wrapped.RoundTrip(request)
+	duration := time.Since(start)
+	if err != nil {
+		tflog.Debug(
+			ctx, "OCM API request failed",
+			"method", request.Method,
+			"url", request.URL.String(),
+			"duration_ms", duration.Milliseconds(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	// Read the response body and replace it with a copy so that the caller can still use it:
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	tflog.Debug(
+		ctx, "Received OCM API response",
+		"method", request.Method,
+		"url", request.URL.String(),
+		"status", response.StatusCode,
+		"operation_id", response.Header.Get(operationIDHeader),
+		"duration_ms", duration.Milliseconds(),
+		"body", redactBody(response.Header.Get("Content-Type"), responseBody),
+	)
+	return
+}
+
+// redactBody returns a representation of the given body that is safe to write to the log. JSON
+// and form bodies are written with the values of the sensitive fields replaced, other bodies are
+// replaced by their size and type.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var result string
+	switch mediaType {
+	case "application/json":
+		var data interface{}
+		err := json.Unmarshal(body, &data)
+		if err != nil {
+			return fmt.Sprintf("<%d bytes of invalid JSON>", len(body))
+		}
+		redacted, err := json.Marshal(redactValue(data))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes of JSON>", len(body))
+		}
+		result = string(redacted)
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes of invalid form data>", len(body))
+		}
+		for name := range values {
+			if sensitiveFields[name] {
+				values.Set(name, redactedValue)
+			}
+		}
+		result = values.Encode()
+	default:
+		return fmt.Sprintf("<%d bytes of '%s'>", len(body), contentType)
+	}
+	if len(result) > maxLoggedBodySize {
+		result = result[:maxLoggedBodySize] + "..."
+	}
+	return result
+}
+
+// redactValue replaces, recursively, the values of the sensitive fields of the given JSON value.
+func redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for name, field := range typed {
+			if sensitiveFields[name] {
+				typed[name] = redactedValue
+			} else {
+				typed[name] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// rateLimiter limits the number of requests that are in flight at the same time and the rate at
+// which new requests are sent. It is created once, when the provider is configured, so that the
+// limits apply to all the requests of the provider even if the SDK wraps the transport more than
+// once.
+type rateLimiter struct {
+	semaphore chan struct{}
+	bucket    *tokenBucket
+}
+
+// newRateLimiter creates a rate limiter that allows at most maxConcurrent requests in flight and at
+// most maxPerSecond new requests per second. A zero value disables the corresponding limit. It
+// returns nil if both limits are disabled.
+func newRateLimiter(maxConcurrent, maxPerSecond int64) *rateLimiter {
+	if maxConcurrent <= 0 && maxPerSecond <= 0 {
+		return nil
+	}
+	result := &rateLimiter{}
+	if maxConcurrent > 0 {
+		result.semaphore = make(chan struct{}, maxConcurrent)
+	}
+	if maxPerSecond > 0 {
+		result.bucket = newTokenBucket(float64(maxPerSecond), float64(maxPerSecond))
+	}
+	return result
+}
+
+// acquire blocks till the request can be sent or the context is cancelled. When it succeeds the
+// caller must call the returned function once the request has finished.
+func (l *rateLimiter) acquire(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if l.semaphore != nil {
+		select {
+		case l.semaphore <- struct{}{}:
+			release = func() {
+				<-l.semaphore
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if l.bucket != nil {
+		err = l.bucket.wait(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// rateLimitTransport is a round tripper that waits for the rate limiter before sending each
+// request.
+type rateLimitTransport struct {
+	wrapped http.RoundTripper
+	limiter *rateLimiter
+}
+
+// rateLimitTransportWrapper returns a transport wrapper that uses the given rate limiter. All the
+// transports created by the wrapper share the limiter.
+func rateLimitTransportWrapper(limiter *rateLimiter) func(http.RoundTripper) http.RoundTripper {
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		return &rateLimitTransport{
+			wrapped: wrapped,
+			limiter: limiter,
+		}
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.wrapped.RoundTrip(request)
+}
+
+// tokenBucket is a simple token bucket rate limiter: tokens are added at a fixed rate up to the
+// size of the bucket, and each request consumes one token.
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks till a token is available or the context is cancelled.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// take consumes a token if there is one available and returns zero. Otherwise it returns the time
+// to wait till the next token will be available.
+func (b *tokenBucket) take() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+const (
+	// maxETagBodySize is the maximum size of a response body that is kept to answer conditional
+	// requests. Larger responses are always retrieved again.
+	maxETagBodySize = 1 << 20
+
+	// maxETagEntries is the maximum number of responses that are kept to answer conditional
+	// requests. When it is reached the least recently used response is discarded.
+	maxETagEntries = 256
+
+	// maxETagTotalSize is the maximum total size of the bodies of the responses that are kept
+	// to answer conditional requests. When it is reached the least recently used responses are
+	// discarded.
+	maxETagTotalSize = 16 << 20
+)
+
+// etagTransport is a round tripper that keeps the last response of each GET request that
+// contained an 'ETag' header, and sends that tag in the 'If-None-Match' header of the next
+// request to the same URL. When the server answers with '304 Not Modified' the kept response is
+// returned instead, so repeated reads of objects that haven't changed, like the checks of the
+// wait loops, don't transfer the object again and are cheaper for the server. Each connection
+// has its own transport, and the number and size of the kept responses are limited.
+type etagTransport struct {
+	wrapped http.RoundTripper
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	size    int
+}
+
+// etagEntry is a response kept by the ETag transport.
+type etagEntry struct {
+	key    string
+	path   string
+	tag    string
+	header http.Header
+	body   []byte
+}
+
+// etagTransportWrapper wraps the given transport so that GET requests are sent as conditional
+// requests when a previous response contained an ETag.
+func etagTransportWrapper(wrapped http.RoundTripper) http.RoundTripper {
+	return &etagTransport{
+		wrapped: wrapped,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (t *etagTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet {
+		// A successful change to an object makes the kept responses of that object, and of
+		// the collections that contain it, useless:
+		response, err := t.wrapped.RoundTrip(request)
+		if err == nil && request.Method != http.MethodHead &&
+			response.StatusCode < http.StatusBadRequest {
+			t.forgetRelated(request.URL.Path)
+		}
+		return response, err
+	}
+	if request.Header.Get("If-None-Match") != "" {
+		return t.wrapped.RoundTrip(request)
+	}
+	key := request.URL.String()
+	entry := t.lookup(key)
+	current := request
+	if entry != nil {
+		current = request.Clone(request.Context())
+		current.Header.Set("If-None-Match", entry.tag)
+	}
+	response, err := t.wrapped.RoundTrip(current)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case response.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         response.Proto,
+			ProtoMajor:    response.ProtoMajor,
+			ProtoMinor:    response.ProtoMinor,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       request,
+		}, nil
+	case response.StatusCode == http.StatusOK && response.Header.Get("ETag") != "":
+		body, err := io.ReadAll(io.LimitReader(response.Body, maxETagBodySize+1))
+		if err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+		if len(body) > maxETagBodySize {
+			// Too large to keep, return it without storing it:
+			response.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), response.Body), response.Body}
+			t.forget(key)
+			return response, nil
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+		t.store(&etagEntry{
+			key:    key,
+			path:   request.URL.Path,
+			tag:    response.Header.Get("ETag"),
+			header: response.Header.Clone(),
+			body:   body,
+		})
+	default:
+		t.forget(key)
+	}
+	return response, nil
+}
+
+// lookup returns the response kept for the given URL, or nil if there is no such response.
+func (t *etagTransport) lookup(key string) *etagEntry {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	element, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	t.order.MoveToFront(element)
+	return element.Value.(*etagEntry)
+}
+
+// store keeps the given response, replacing the previous one for the same URL and discarding the
+// least recently used ones when the limits are exceeded.
+func (t *etagTransport) store(entry *etagEntry) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	element, ok := t.entries[entry.key]
+	if ok {
+		t.remove(element)
+	}
+	t.entries[entry.key] = t.order.PushFront(entry)
+	t.size += len(entry.body)
+	for t.order.Len() > maxETagEntries || t.size > maxETagTotalSize {
+		t.remove(t.order.Back())
+	}
+}
+
+// forget removes the response kept for the given URL.
+func (t *etagTransport) forget(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	element, ok := t.entries[key]
+	if ok {
+		t.remove(element)
+	}
+}
+
+// forgetRelated removes the responses kept for the object with the given path, for the objects
+// inside it and for the collections that contain it.
+func (t *etagTransport) forgetRelated(path string) {
+	path = strings.TrimSuffix(path, "/")
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	element := t.order.Front()
+	for element != nil {
+		next := element.Next()
+		entry := element.Value.(*etagEntry)
+		if pathContains(path, entry.path) || pathContains(entry.path, path) {
+			t.remove(element)
+		}
+		element = next
+	}
+}
+
+// remove removes the given element from the kept responses. The caller must hold the lock.
+func (t *etagTransport) remove(element *list.Element) {
+	entry := t.order.Remove(element).(*etagEntry)
+	delete(t.entries, entry.key)
+	t.size -= len(entry.body)
+}
+
+// pathContains checks if the given child path is the given parent path or is inside it.
+func pathContains(parent, child string) bool {
+	child = strings.TrimSuffix(child, "/")
+	return child == parent || strings.HasPrefix(child, parent+"/")
+}
@@ -0,0 +1,310 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+var _ = Describe("Connection transport", func() {
+	Context("parseProxyURL", func() {
+		It("Accepts an HTTP proxy URL", func() {
+			proxyURL, err := parseProxyURL("http://proxy.example.com:3128")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(proxyURL.Host).To(Equal("proxy.example.com:3128"))
+		})
+
+		It("Rejects an unsupported scheme", func() {
+			_, err := parseProxyURL("socks5://proxy.example.com:1080")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("socks5"))
+		})
+
+		It("Rejects a URL without host", func() {
+			_, err := parseProxyURL("http://")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("proxyTransportWrapper", func() {
+		It("Sets the proxy and preserves the TLS configuration", func() {
+			proxyURL, err := parseProxyURL("https://proxy.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			base := &http.Transport{
+				TLSClientConfig: &tls.Config{
+					ServerName: "api.openshift.com",
+				},
+			}
+			wrapped := proxyTransportWrapper(proxyConfig(proxyURL, ""))(base)
+			transport, ok := wrapped.(*http.Transport)
+			Expect(ok).To(BeTrue())
+			Expect(transport.TLSClientConfig.ServerName).To(Equal("api.openshift.com"))
+			request, err := http.NewRequest(http.MethodGet, "https://api.openshift.com", nil)
+			Expect(err).ToNot(HaveOccurred())
+			used, err := transport.Proxy(request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(used.String()).To(Equal("https://proxy.example.com"))
+			Expect(base.Proxy).To(BeNil())
+		})
+
+		It("Skips the proxy for the excluded hosts", func() {
+			proxyURL, err := parseProxyURL("http://proxy.example.com:3128")
+			Expect(err).ToNot(HaveOccurred())
+			wrapped := proxyTransportWrapper(proxyConfig(proxyURL, ".openshift.com"))(&http.Transport{})
+			transport := wrapped.(*http.Transport)
+			request, err := http.NewRequest(http.MethodGet, "https://api.openshift.com", nil)
+			Expect(err).ToNot(HaveOccurred())
+			used, err := transport.Proxy(request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(used).To(BeNil())
+			request, err = http.NewRequest(http.MethodGet, "https://sso.redhat.com", nil)
+			Expect(err).ToNot(HaveOccurred())
+			used, err = transport.Proxy(request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(used.String()).To(Equal("http://proxy.example.com:3128"))
+		})
+
+		It("Sends the requests of the connection through the proxy", func() {
+			var hosts []string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hosts = append(hosts, r.Host)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"kind":"ClusterList","page":1,"size":0,"total":0,"items":[]}`))
+			}))
+			defer proxy.Close()
+			proxyURL, err := parseProxyURL(proxy.URL)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Build the connection with all the wrappers, like the provider does:
+			builder := sdk.NewConnectionBuilder().
+				URL("http://api.example.com").
+				Tokens(mockToken())
+			wrappers := transportWrappers(transportSettings{
+				proxy:       proxyConfig(proxyURL, ""),
+				rateLimiter: newRateLimiter(1, 10),
+				debug:       true,
+			})
+			for _, wrapper := range wrappers {
+				builder.TransportWrapper(wrapper)
+			}
+			connection, err := builder.Build()
+			Expect(err).ToNot(HaveOccurred())
+			defer connection.Close()
+
+			_, err = connection.ClustersMgmt().V1().Clusters().List().Send()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hosts).To(Equal([]string{"api.example.com"}))
+		})
+	})
+
+	Context("redactBody", func() {
+		It("Redacts sensitive JSON fields at any depth", func() {
+			body := `{
+			  "kind": "IdentityProvider",
+			  "htpasswd": {
+			    "users": {
+			      "items": [
+			        {"username": "admin", "password": "my-password"}
+			      ]
+			    }
+			  },
+			  "additional_trust_bundle": "-----BEGIN CERTIFICATE-----"
+			}`
+			result := redactBody("application/json; charset=utf-8", []byte(body))
+			Expect(result).ToNot(ContainSubstring("my-password"))
+			Expect(result).ToNot(ContainSubstring("BEGIN CERTIFICATE"))
+			Expect(result).To(ContainSubstring(`"username":"admin"`))
+			Expect(result).To(ContainSubstring(`"password":"***"`))
+		})
+
+		It("Redacts sensitive form fields", func() {
+			body := "grant_type=refresh_token&refresh_token=my-token&client_id=cloud-services"
+			result := redactBody("application/x-www-form-urlencoded", []byte(body))
+			Expect(result).ToNot(ContainSubstring("my-token"))
+			Expect(result).To(ContainSubstring("client_id=cloud-services"))
+		})
+
+		It("Doesn't write bodies of other types", func() {
+			result := redactBody("text/plain", []byte("my-secret"))
+			Expect(result).To(Equal("<9 bytes of 'text/plain'>"))
+		})
+	})
+
+	Context("rateLimiter", func() {
+		It("Shares the limits between the transports created by the wrapper", func() {
+			limiter := newRateLimiter(1, 0)
+			wrapper := rateLimitTransportWrapper(limiter)
+			blocked := make(chan struct{})
+			release := make(chan struct{})
+			first := wrapper(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				close(blocked)
+				<-release
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			}))
+			second := wrapper(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			}))
+			go func() {
+				defer GinkgoRecover()
+				request, err := http.NewRequest(http.MethodGet, "http://api.example.com", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = first.RoundTrip(request)
+				Expect(err).ToNot(HaveOccurred())
+			}()
+			<-blocked
+
+			// The second transport has to wait for the request of the first one:
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.example.com", nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = second.RoundTrip(request)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+
+			close(release)
+			Eventually(func() error {
+				request, err := http.NewRequest(http.MethodGet, "http://api.example.com", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = second.RoundTrip(request)
+				return err
+			}).ShouldNot(HaveOccurred())
+		})
+
+		It("Isn't created when there are no limits", func() {
+			Expect(newRateLimiter(0, 0)).To(BeNil())
+		})
+	})
+
+	Context("tokenBucket", func() {
+		It("Allows a burst and then waits for new tokens", func() {
+			bucket := newTokenBucket(2, 2)
+			Expect(bucket.take()).To(BeZero())
+			Expect(bucket.take()).To(BeZero())
+			delay := bucket.take()
+			Expect(delay).To(BeNumerically(">", 0))
+			Expect(delay).To(BeNumerically("<=", 500*time.Millisecond))
+		})
+	})
+
+	Context("etagTransport", func() {
+		var requests []string
+		var tag string
+		var server *httptest.Server
+		var client *http.Client
+
+		BeforeEach(func() {
+			requests = nil
+			tag = `"1"`
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Header.Get("If-None-Match"))
+				if r.Header.Get("If-None-Match") == tag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", tag)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"123","version":` + tag + `}`))
+			}))
+			client = &http.Client{
+				Transport: etagTransportWrapper(http.DefaultTransport),
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		get := func() (int, string) {
+			response, err := client.Get(server.URL + "/api/clusters_mgmt/v1/clusters/123")
+			Expect(err).ToNot(HaveOccurred())
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			return response.StatusCode, string(body)
+		}
+
+		It("Returns the kept response when the object hasn't changed", func() {
+			status, body := get()
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal(`{"id":"123","version":"1"}`))
+			status, body = get()
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal(`{"id":"123","version":"1"}`))
+			Expect(requests).To(Equal([]string{"", `"1"`}))
+		})
+
+		It("Returns the new response when the object has changed", func() {
+			get()
+			tag = `"2"`
+			status, body := get()
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal(`{"id":"123","version":"2"}`))
+			get()
+			Expect(requests).To(Equal([]string{"", `"1"`, `"2"`}))
+		})
+
+		It("Forgets the kept response when the object is changed", func() {
+			get()
+			request, err := http.NewRequest(
+				http.MethodPatch,
+				server.URL+"/api/clusters_mgmt/v1/clusters/123",
+				strings.NewReader(`{"name":"my-cluster"}`),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			response, err := client.Do(request)
+			Expect(err).ToNot(HaveOccurred())
+			response.Body.Close()
+			get()
+			Expect(requests).To(Equal([]string{"", "", ""}))
+		})
+
+		It("Discards the least recently used responses", func() {
+			transport := etagTransportWrapper(http.DefaultTransport).(*etagTransport)
+			client.Transport = transport
+			url := func(i int) string {
+				return fmt.Sprintf("%s/api/clusters_mgmt/v1/clusters/%d", server.URL, i)
+			}
+			for i := 0; i <= maxETagEntries; i++ {
+				response, err := client.Get(url(i))
+				Expect(err).ToNot(HaveOccurred())
+				_, _ = io.Copy(io.Discard, response.Body)
+				response.Body.Close()
+			}
+			Expect(transport.order.Len()).To(Equal(maxETagEntries))
+			Expect(transport.lookup(url(0))).To(BeNil())
+			Expect(transport.lookup(url(maxETagEntries))).ToNot(BeNil())
+		})
+	})
+})
+
+// roundTripperFunc is an adapter to use ordinary functions as round trippers in tests.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// redactedTrustBundle is the value that OCM may return instead of the additional trust bundle.
+const redactedTrustBundle = "REDACTED"
+
+// normalizeTrustBundle removes the differences in line endings, indentation and blank lines that
+// don't change the meaning of a PEM bundle, so that bundles can be compared.
+func normalizeTrustBundle(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// sameTrustBundle checks if the given bundles only differ in the details removed by the
+// normalization. A redacted bundle is considered equal to any other.
+func sameTrustBundle(a, b string) bool {
+	if a == redactedTrustBundle || b == redactedTrustBundle {
+		return true
+	}
+	return normalizeTrustBundle(a) == normalizeTrustBundle(b)
+}
+
+// validateTrustBundle checks that the given text contains one or more PEM encoded X.509
+// certificates, and nothing else.
+func validateTrustBundle(text string) error {
+	rest := []byte(normalizeTrustBundle(text))
+	count := 0
+	for len(bytes.TrimSpace(rest)) > 0 {
+		if !bytes.HasPrefix(bytes.TrimSpace(rest), []byte("-----BEGIN ")) {
+			return errors.New("the bundle contains text that isn't a PEM encoded certificate")
+		}
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return fmt.Errorf("block %d isn't a valid PEM block", count+1)
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf(
+				"expected only 'CERTIFICATE' blocks but block %d is of type '%s'",
+				count+1, block.Type,
+			)
+		}
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("certificate %d can't be parsed: %v", count+1, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return errors.New("the bundle doesn't contain any certificate")
+	}
+	return nil
+}
+
+func trustBundleValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate additional trust bundle",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				bundle := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, bundle)
+				if diag.HasError() || common.IsStringAttributeEmpty(*bundle) {
+					// No attribute to validate
+					return
+				}
+				err := validateTrustBundle(bundle.Value)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid additional trust bundle",
+						fmt.Sprintf("Expected a PEM encoded X.509 certificate bundle: %v", err),
+					)
+				}
+			},
+		},
+	}
+}
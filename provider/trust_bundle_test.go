@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Trust bundle", func() {
+	const certificate = `-----BEGIN CERTIFICATE-----
+MIIBjDCCATOgAwIBAgIUEjg8wCeZncDlPBL/6GdbV2H9xDkwCgYIKoZIzj0EAwIw
+HDEaMBgGA1UEAwwRcHJveHkuZXhhbXBsZS5jb20wHhcNMjYxMDE2MDgxODQ3WhcN
+MzYxMDEzMDgxODQ3WjAcMRowGAYDVQQDDBFwcm94eS5leGFtcGxlLmNvbTBZMBMG
+ByqGSM49AgEGCCqGSM49AwEHA0IABEL0ZmCLJG1DvaSU6orjPNihr689JpfvEtgf
+bQKO0tm+UPwj97CwbVHachdr3sj4N09+/lMJq+ApcQr/3VvAc+2jUzBRMB0GA1Ud
+DgQWBBT5fnaFHsLqgS/5fdjY3CFmY8USSzAfBgNVHSMEGDAWgBT5fnaFHsLqgS/5
+fdjY3CFmY8USSzAPBgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0cAMEQCIGXx
+86aFkE7v6yVihiqcnkCxbzNzCpIeI7dYTsDVMkBaAiBE2JEeAAIFhLgE+JJYsXCn
+SV+F9EJMcjittdBu8zEfQw==
+-----END CERTIFICATE-----
+`
+
+	It("Accepts a bundle with several certificates", func() {
+		Expect(validateTrustBundle(certificate + certificate)).To(Succeed())
+	})
+
+	It("Accepts a bundle with Windows line endings and indentation", func() {
+		bundle := strings.ReplaceAll("  "+certificate, "\n", "\r\n\t")
+		Expect(validateTrustBundle(bundle)).To(Succeed())
+		Expect(normalizeTrustBundle(bundle)).To(Equal(certificate))
+		Expect(sameTrustBundle(bundle, certificate)).To(BeTrue())
+	})
+
+	It("Rejects a bundle without certificates", func() {
+		Expect(validateTrustBundle("123")).ToNot(Succeed())
+		Expect(validateTrustBundle("\n\n")).ToNot(Succeed())
+	})
+
+	It("Rejects text outside of the PEM blocks", func() {
+		Expect(validateTrustBundle(certificate + "junk\n")).ToNot(Succeed())
+	})
+
+	It("Rejects blocks that aren't certificates", func() {
+		bundle := strings.ReplaceAll(certificate, "CERTIFICATE", "PRIVATE KEY")
+		err := validateTrustBundle(bundle)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("'PRIVATE KEY'"))
+	})
+
+	It("Considers a redacted bundle equal to any other", func() {
+		Expect(sameTrustBundle(redactedTrustBundle, certificate)).To(BeTrue())
+		Expect(sameTrustBundle(certificate, certificate+certificate)).To(BeFalse())
+	})
+})
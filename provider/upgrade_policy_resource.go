@@ -0,0 +1,828 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// Types of the schedules of upgrade policies:
+const (
+	automaticScheduleType = "automatic"
+	manualScheduleType    = "manual"
+)
+
+// osdUpgradeType is the type of the upgrade policies that upgrade the whole cluster.
+const osdUpgradeType = "OSD"
+
+// defaultUpgradeDelay is the time from now when manual upgrades without an explicit start time
+// are scheduled, as OCM rejects upgrades that are scheduled too close to the current time.
+const defaultUpgradeDelay = 10 * time.Minute
+
+type UpgradePolicyResourceType struct {
+}
+
+var _ tfsdk.ResourceWithModifyPlan = &UpgradePolicyResource{}
+
+type UpgradePolicyResource struct {
+	logger       logging.Logger
+	collection   *cmv1.ClustersClient
+	pollInterval time.Duration
+}
+
+func (t *UpgradePolicyResourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Upgrade policy of a cluster. With automatic upgrades the cluster " +
+			"applies the new patch versions of its current minor version following a " +
+			"recurring schedule, otherwise the cluster is upgraded once to the given version. " +
+			"Destroying or replacing a policy whose upgrade hasn't started cancels the upgrade.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster": {
+				Description: "Identifier of the cluster.",
+				Type:        types.StringType,
+				Required:    true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				Description: "Unique identifier of the upgrade policy.",
+				Type:        types.StringType,
+				Computed:    true,
+			},
+			"automatic_upgrades": {
+				Description: "Enables the automatic upgrades of the cluster to the new " +
+					"patch versions, following the `schedule`. The default is to upgrade " +
+					"once to `version`.",
+				Type:     types.BoolType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"schedule": {
+				Description: "Cron expression, in UTC, of the times when automatic " +
+					"upgrades can start, for example '0 2 * * 6' for Saturdays at 02:00. " +
+					"Required with automatic upgrades.",
+				Type:       types.StringType,
+				Optional:   true,
+				Validators: upgradeScheduleValidators(),
+			},
+			"version": {
+				Description: "Version that the cluster will be upgraded to, for " +
+					"example '4.12.5'. It must be one of the available upgrades of the " +
+					"cluster, see the `ocm_available_upgrades` data source. Required " +
+					"without automatic upgrades.",
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"next_run": {
+				Description: "Time when the upgrade will start, in RFC3339 format, " +
+					"for example '2023-06-01T02:00:00Z'. By default upgrades without " +
+					"automatic upgrades start ten minutes after the creation of the " +
+					"policy, or at the next start of the `maintenance_window`.",
+				Type:       types.StringType,
+				Optional:   true,
+				Computed:   true,
+				Validators: nextRunValidators(),
+			},
+			"maintenance_window": {
+				Description: "Weekly maintenance window, in UTC, for upgrades without " +
+					"automatic upgrades. The upgrade starts at the next start of the " +
+					"window that is at least ten minutes away. It can't be used " +
+					"together with `next_run`.",
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"day": {
+						Description: "Day of the week, for example 'Saturday'.",
+						Type:        types.StringType,
+						Required:    true,
+					},
+					"hour": {
+						Description: "Hour of the day, from 0 to 23.",
+						Type:        types.Int64Type,
+						Required:    true,
+					},
+				}),
+				Optional:   true,
+				Validators: maintenanceWindowValidators(),
+			},
+			"state": {
+				Description: "State of the upgrade, for example 'scheduled', " +
+					"'started' or 'completed'.",
+				Type:     types.StringType,
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *UpgradePolicyResourceType) NewResource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.Resource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation: use it directly when needed.
+	parent := p.(*Provider)
+
+	// Get the collection of clusters:
+	collection := parent.connection.ClustersMgmt().V1().Clusters()
+
+	// Create the resource:
+	result = &UpgradePolicyResource{
+		logger:       parent.logger,
+		collection:   collection,
+		pollInterval: parent.pollInterval,
+	}
+
+	return
+}
+
+func upgradeScheduleValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate upgrade schedule",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				schedule := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, schedule)
+				if diag.HasError() || common.IsStringAttributeEmpty(*schedule) {
+					// No attribute to validate
+					return
+				}
+				if len(strings.Fields(schedule.Value)) != 5 {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid upgrade schedule",
+						fmt.Sprintf(
+							"Expected a cron expression with five fields but got '%s'",
+							schedule.Value,
+						),
+					)
+				}
+			},
+		},
+	}
+}
+
+func nextRunValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate next run",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				nextRun := &types.String{}
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, nextRun)
+				if diag.HasError() || common.IsStringAttributeEmpty(*nextRun) {
+					// No attribute to validate
+					return
+				}
+				_, err := time.Parse(time.RFC3339, nextRun.Value)
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid next run",
+						fmt.Sprintf("Expected a time in RFC3339 format: %v", err),
+					)
+				}
+			},
+		},
+	}
+}
+
+// validateUpgradePolicy checks that the settings of the plan are consistent with the type of
+// upgrade, and that the version is one of the available upgrades of the cluster.
+func validateUpgradePolicy(cluster *cmv1.Cluster, plan *UpgradePolicyState) error {
+	if plan.AutomaticUpgrades.Value {
+		if common.IsStringAttributeEmpty(plan.Schedule) {
+			return errors.New("automatic upgrades require a 'schedule'")
+		}
+		if !common.IsStringAttributeEmpty(plan.Version) {
+			return errors.New("automatic upgrades don't support a 'version', the " +
+				"latest patch version is always used")
+		}
+		if !common.IsStringAttributeEmpty(plan.NextRun) {
+			return errors.New("automatic upgrades don't support a 'next_run', it is " +
+				"calculated from the 'schedule'")
+		}
+		if plan.MaintenanceWindow != nil {
+			return errors.New("automatic upgrades don't support a 'maintenance_window', " +
+				"use the 'schedule' instead")
+		}
+		return nil
+	}
+	if common.IsStringAttributeEmpty(plan.Version) {
+		return errors.New("upgrades without automatic upgrades require a 'version'")
+	}
+	if !common.IsStringAttributeEmpty(plan.Schedule) {
+		return errors.New("a 'schedule' can only be used with automatic upgrades")
+	}
+	if plan.MaintenanceWindow != nil && !common.IsStringAttributeEmpty(plan.NextRun) {
+		return errors.New("'next_run' and 'maintenance_window' can't be used together")
+	}
+	for _, available := range cluster.Version().AvailableUpgrades() {
+		if available == plan.Version.Value {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"version '%s' isn't an available upgrade for cluster '%s', the available "+
+			"upgrades are: %s",
+		plan.Version.Value, cluster.ID(),
+		strings.Join(cluster.Version().AvailableUpgrades(), ", "),
+	)
+}
+
+func (r *UpgradePolicyResource) Create(ctx context.Context,
+	request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	// Get the plan:
+	state := &UpgradePolicyState{}
+	diags := request.Plan.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if state.AutomaticUpgrades.Unknown || state.AutomaticUpgrades.Null {
+		state.AutomaticUpgrades = types.Bool{Value: false}
+	}
+
+	// Wait till the cluster is ready, as only ready clusters can be upgraded:
+	resource := r.collection.Cluster(state.Cluster.Value)
+	pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+	defer cancel()
+	cluster, _, err := pollCluster(pollCtx, resource, r.pollInterval, func(object *cmv1.Cluster) bool {
+		return object.State() == cmv1.ClusterStateReady
+	})
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't poll cluster state",
+			fmt.Sprintf(
+				"Can't poll state of cluster with identifier '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	err = validateUpgradePolicy(cluster, state)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't create upgrade policy",
+			fmt.Sprintf(
+				"Can't create upgrade policy for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Create the upgrade policy:
+	builder := cmv1.NewUpgradePolicy().UpgradeType(osdUpgradeType)
+	if state.AutomaticUpgrades.Value {
+		builder.ScheduleType(automaticScheduleType).Schedule(state.Schedule.Value)
+	} else {
+		nextRun := time.Now().UTC().Add(defaultUpgradeDelay)
+		if state.MaintenanceWindow != nil {
+			nextRun = maintenanceWindowStart(state.MaintenanceWindow)
+		} else if !common.IsStringAttributeEmpty(state.NextRun) {
+			nextRun, err = time.Parse(time.RFC3339, state.NextRun.Value)
+			if err != nil {
+				addClassifiedError(
+					&response.Diagnostics,
+					"Invalid next run",
+					fmt.Sprintf("Can't parse next run '%s': %v", state.NextRun.Value, err),
+					err,
+				)
+				return
+			}
+		}
+		builder.ScheduleType(manualScheduleType).Version(state.Version.Value).NextRun(nextRun)
+	}
+	object, err := builder.Build()
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't build upgrade policy",
+			fmt.Sprintf(
+				"Can't build upgrade policy for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	add, err := resource.UpgradePolicies().Add().Body(object).SendContext(ctx)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't create upgrade policy",
+			fmt.Sprintf(
+				"Can't create upgrade policy for cluster '%s': %v",
+				state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	object = add.Body()
+
+	// Save the state:
+	r.populateState(object, state)
+	response.Diagnostics.Append(r.populatePolicyState(ctx, state)...)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *UpgradePolicyResource) Read(ctx context.Context, request tfsdk.ReadResourceRequest,
+	response *tfsdk.ReadResourceResponse) {
+	// Get the current state:
+	state := &UpgradePolicyState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the upgrade policy:
+	resource := r.collection.Cluster(state.Cluster.Value).
+		UpgradePolicies().
+		UpgradePolicy(state.ID.Value)
+	get, err := resource.Get().SendContext(ctx)
+	if err != nil {
+		sdkErr, ok := err.(*ocmerrors.Error)
+		if ok && sdkErr.Status() == http.StatusNotFound {
+			// OCM removes the manual policies once the upgrade is completed. Removing them
+			// from the state would schedule the same upgrade again, so they are kept as
+			// completed if the cluster already runs the version. Otherwise the policy was
+			// cancelled or failed, and it is created again like the automatic ones.
+			if !state.AutomaticUpgrades.Value {
+				completed, err := r.upgradeCompleted(ctx, state)
+				if err != nil {
+					addClassifiedError(
+						&response.Diagnostics,
+						"Can't find cluster",
+						fmt.Sprintf(
+							"Can't find cluster with identifier '%s': %v",
+							state.Cluster.Value, err,
+						),
+						err,
+					)
+					return
+				}
+				if completed {
+					state.State = types.String{
+						Value: string(cmv1.UpgradePolicyStateValueCompleted),
+					}
+					diags = response.State.Set(ctx, state)
+					response.Diagnostics.Append(diags...)
+					return
+				}
+			}
+			r.logger.Warn(ctx, "Upgrade policy '%s' for cluster '%s' not found, removing from state",
+				state.ID.Value, state.Cluster.Value,
+			)
+			response.State.RemoveResource(ctx)
+			return
+		}
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't find upgrade policy",
+			fmt.Sprintf(
+				"Can't find upgrade policy with identifier '%s' for "+
+					"cluster '%s': %v",
+				state.ID.Value, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	object := get.Body()
+
+	// Save the state:
+	r.populateState(object, state)
+	response.Diagnostics.Append(r.populatePolicyState(ctx, state)...)
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// upgradeCompleted checks if the cluster of the given manual upgrade policy already runs the
+// version of the policy, or a later one. A cluster that doesn't exist any more can't be upgraded,
+// so it is reported as not completed.
+func (r *UpgradePolicyResource) upgradeCompleted(ctx context.Context,
+	state *UpgradePolicyState) (bool, error) {
+	get, err := r.collection.Cluster(state.Cluster.Value).Get().SendContext(ctx)
+	if err != nil {
+		sdkErr, ok := err.(*ocmerrors.Error)
+		if ok && sdkErr.Status() == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return clusterVersionReached(get.Body(), state.Version.Value), nil
+}
+
+// clusterVersionReached checks if the given cluster runs the given version or a later one.
+func clusterVersionReached(cluster *cmv1.Cluster, version string) bool {
+	current := cluster.Version().RawID()
+	if current == "" || version == "" {
+		return false
+	}
+	reached, err := common.IsGreaterThanOrEqual(current, version)
+	return err == nil && reached
+}
+
+// ModifyPlan warns the user when the plan removes or replaces a policy whose upgrade hasn't
+// started yet, as applying it cancels that upgrade.
+func (r *UpgradePolicyResource) ModifyPlan(ctx context.Context, request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse) {
+	if request.State.Raw.IsNull() {
+		return
+	}
+	state := &UpgradePolicyState{}
+	diags := request.State.Get(ctx, state)
+	if diags.HasError() {
+		return
+	}
+	if !upgradePolicyPending(state.State.Value) {
+		return
+	}
+	if !request.Plan.Raw.IsNull() {
+		plan := &UpgradePolicyState{}
+		diags = request.Plan.Get(ctx, plan)
+		if diags.HasError() {
+			return
+		}
+		replaced := plan.Cluster.Value != state.Cluster.Value ||
+			!plan.AutomaticUpgrades.Unknown &&
+				plan.AutomaticUpgrades.Value != state.AutomaticUpgrades.Value ||
+			!plan.Version.Unknown && plan.Version.Value != state.Version.Value
+		if !replaced {
+			return
+		}
+	}
+	if state.AutomaticUpgrades.Value {
+		response.Diagnostics.AddWarning(
+			"Automatic upgrades will be cancelled",
+			fmt.Sprintf(
+				"Applying this plan removes the automatic upgrade policy '%s' of "+
+					"cluster '%s'",
+				state.ID.Value, state.Cluster.Value,
+			),
+		)
+		return
+	}
+	response.Diagnostics.AddWarning(
+		"Upgrade will be cancelled",
+		fmt.Sprintf(
+			"Applying this plan cancels the upgrade of cluster '%s' to version '%s' "+
+				"scheduled for '%s'",
+			state.Cluster.Value, state.Version.Value, state.NextRun.Value,
+		),
+	)
+}
+
+// upgradePolicyPending checks if the given state of an upgrade policy means that the upgrade
+// hasn't started yet, so it can still be cancelled.
+func upgradePolicyPending(value string) bool {
+	switch cmv1.UpgradePolicyStateValue(value) {
+	case cmv1.UpgradePolicyStateValuePending,
+		cmv1.UpgradePolicyStateValueScheduled,
+		cmv1.UpgradePolicyStateValueDelayed:
+		return true
+	}
+	return false
+}
+
+func (r *UpgradePolicyResource) Update(ctx context.Context, request tfsdk.UpdateResourceRequest,
+	response *tfsdk.UpdateResourceResponse) {
+	// Get the state:
+	state := &UpgradePolicyState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the plan:
+	plan := &UpgradePolicyState{}
+	diags = request.Plan.Get(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the schedule of automatic upgrades and the start time or maintenance window of the
+	// other upgrades can be changed, the rest of the changes replace the policy:
+	builder := cmv1.NewUpgradePolicy()
+	changed := false
+	if state.AutomaticUpgrades.Value {
+		if common.IsStringAttributeEmpty(plan.Schedule) {
+			response.Diagnostics.AddError(
+				"Can't update upgrade policy",
+				fmt.Sprintf(
+					"Can't update upgrade policy '%s' for cluster '%s': automatic "+
+						"upgrades require a 'schedule'",
+					state.ID.Value, state.Cluster.Value,
+				),
+			)
+			return
+		}
+		schedule, ok := common.ShouldPatchString(state.Schedule, plan.Schedule)
+		if ok {
+			builder.Schedule(schedule)
+			changed = true
+		}
+	} else if !common.IsStringAttributeEmpty(plan.Schedule) {
+		response.Diagnostics.AddError(
+			"Can't update upgrade policy",
+			fmt.Sprintf(
+				"Can't update upgrade policy '%s' for cluster '%s': a 'schedule' "+
+					"can only be used with automatic upgrades",
+				state.ID.Value, state.Cluster.Value,
+			),
+		)
+		return
+	} else if plan.MaintenanceWindow != nil &&
+		!sameMaintenanceWindow(state.MaintenanceWindow, plan.MaintenanceWindow) {
+		builder.NextRun(maintenanceWindowStart(plan.MaintenanceWindow))
+		changed = true
+	} else if !plan.NextRun.Unknown && !plan.NextRun.Null &&
+		plan.NextRun.Value != state.NextRun.Value {
+		nextRun, err := time.Parse(time.RFC3339, plan.NextRun.Value)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Invalid next run",
+				fmt.Sprintf("Can't parse next run '%s': %v", plan.NextRun.Value, err),
+				err,
+			)
+			return
+		}
+		builder.NextRun(nextRun)
+		changed = true
+	}
+	if changed {
+		patch, err := builder.Build()
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't build upgrade policy",
+				fmt.Sprintf(
+					"Can't build upgrade policy '%s' for cluster '%s': %v",
+					state.ID.Value, state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+		update, err := r.collection.Cluster(state.Cluster.Value).
+			UpgradePolicies().
+			UpgradePolicy(state.ID.Value).
+			Update().
+			Body(patch).
+			SendContext(ctx)
+		if err != nil {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't update upgrade policy",
+				fmt.Sprintf(
+					"Can't update upgrade policy '%s' for cluster '%s': %v",
+					state.ID.Value, state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+		r.populateState(update.Body(), plan)
+	} else {
+		plan.ID = state.ID
+		plan.NextRun = state.NextRun
+	}
+
+	// Save the state:
+	plan.AutomaticUpgrades = state.AutomaticUpgrades
+	response.Diagnostics.Append(r.populatePolicyState(ctx, plan)...)
+	diags = response.State.Set(ctx, plan)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *UpgradePolicyResource) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest,
+	response *tfsdk.DeleteResourceResponse) {
+	// Get the state:
+	state := &UpgradePolicyState{}
+	diags := request.State.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Upgrades that already started can't be cancelled, and the policies of completed upgrades
+	// have already been removed by OCM:
+	resource := r.collection.Cluster(state.Cluster.Value).
+		UpgradePolicies().
+		UpgradePolicy(state.ID.Value)
+	value, err := r.policyState(ctx, resource)
+	if err != nil {
+		sdkErr, ok := err.(*ocmerrors.Error)
+		if ok && sdkErr.Status() == http.StatusNotFound {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't get upgrade policy state",
+			fmt.Sprintf(
+				"Can't get state of upgrade policy '%s' for cluster '%s': %v",
+				state.ID.Value, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	switch value {
+	case cmv1.UpgradePolicyStateValueStarted:
+		response.Diagnostics.AddError(
+			"Can't cancel upgrade",
+			fmt.Sprintf(
+				"Can't cancel the upgrade of cluster '%s' because it has already "+
+					"started, wait till it finishes before changing or removing the "+
+					"upgrade policy '%s'",
+				state.Cluster.Value, state.ID.Value,
+			),
+		)
+		return
+	case cmv1.UpgradePolicyStateValueCompleted:
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	// Send the request to delete the upgrade policy, ignoring the policies that OCM already
+	// removed:
+	_, err = resource.Delete().SendContext(ctx)
+	if err != nil {
+		sdkErr, ok := err.(*ocmerrors.Error)
+		if !ok || sdkErr.Status() != http.StatusNotFound {
+			addClassifiedError(
+				&response.Diagnostics,
+				"Can't delete upgrade policy",
+				fmt.Sprintf(
+					"Can't delete upgrade policy with identifier '%s' for "+
+						"cluster '%s': %v",
+					state.ID.Value, state.Cluster.Value, err,
+				),
+				err,
+			)
+			return
+		}
+	}
+
+	// Tell the user that the pending upgrade won't happen:
+	if !state.AutomaticUpgrades.Value {
+		if upgradePolicyPending(string(value)) {
+			response.Diagnostics.AddWarning(
+				"Upgrade cancelled",
+				fmt.Sprintf(
+					"The upgrade of cluster '%s' to version '%s' scheduled for '%s' "+
+						"has been cancelled",
+					state.Cluster.Value, state.Version.Value, state.NextRun.Value,
+				),
+			)
+		}
+	} else {
+		response.Diagnostics.AddWarning(
+			"Automatic upgrades cancelled",
+			fmt.Sprintf(
+				"The automatic upgrades of cluster '%s' have been cancelled, the "+
+					"cluster won't be upgraded till a new upgrade policy is created",
+				state.Cluster.Value,
+			),
+		)
+	}
+
+	// Remove the state:
+	response.State.RemoveResource(ctx)
+}
+
+func (r *UpgradePolicyResource) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest,
+	response *tfsdk.ImportResourceStateResponse) {
+	// The identifier of the upgrade policy is only unique inside the cluster, so the import
+	// identifier contains both:
+	fields := strings.Split(request.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		response.Diagnostics.AddError(
+			"Invalid import identifier",
+			fmt.Sprintf(
+				"Expected an import identifier like '<cluster_id>,<upgrade_policy_id>' "+
+					"but got '%s'",
+				request.ID,
+			),
+		)
+		return
+	}
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("cluster"),
+		fields[0],
+	)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx,
+		tftypes.NewAttributePath().WithAttributeName("id"),
+		fields[1],
+	)...)
+}
+
+// populateState copies the data from the API object to the Terraform state. The version of
+// automatic policies isn't copied, as it changes with every upgrade.
+func (r *UpgradePolicyResource) populateState(object *cmv1.UpgradePolicy, state *UpgradePolicyState) {
+	state.ID = types.String{
+		Value: object.ID(),
+	}
+	automatic := object.ScheduleType() == automaticScheduleType
+	state.AutomaticUpgrades = types.Bool{
+		Value: automatic,
+	}
+	state.Schedule = types.String{Null: true}
+	if object.Schedule() != "" {
+		state.Schedule = types.String{
+			Value: object.Schedule(),
+		}
+	}
+	if !automatic {
+		state.Version = types.String{
+			Value: object.Version(),
+		}
+	} else {
+		state.Version = types.String{Null: true}
+	}
+	state.NextRun = types.String{Null: true}
+	nextRun, ok := object.GetNextRun()
+	if ok && !nextRun.IsZero() {
+		state.NextRun = types.String{
+			Value: nextRun.UTC().Format(time.RFC3339),
+		}
+	}
+}
+
+// populatePolicyState retrieves the state of the upgrade and copies it to the Terraform state.
+func (r *UpgradePolicyResource) populatePolicyState(ctx context.Context,
+	state *UpgradePolicyState) (diags diag.Diagnostics) {
+	value, err := r.policyState(ctx, r.collection.Cluster(state.Cluster.Value).
+		UpgradePolicies().
+		UpgradePolicy(state.ID.Value))
+	if err != nil {
+		addClassifiedError(
+			&diags,
+			"Can't get upgrade policy state",
+			fmt.Sprintf(
+				"Can't get state of upgrade policy '%s' for cluster '%s': %v",
+				state.ID.Value, state.Cluster.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	state.State = types.String{
+		Value: string(value),
+	}
+	return
+}
+
+// policyState retrieves the state of the upgrade of the given policy.
+func (r *UpgradePolicyResource) policyState(ctx context.Context,
+	resource *cmv1.UpgradePolicyClient) (value cmv1.UpgradePolicyStateValue, err error) {
+	get, err := resource.State().Get().SendContext(ctx)
+	if err != nil {
+		return
+	}
+	value = get.Body().Value()
+	return
+}
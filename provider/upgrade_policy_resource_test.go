@@ -0,0 +1,50 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Upgrade policy", func() {
+	buildCluster := func(version string) *cmv1.Cluster {
+		cluster, err := cmv1.NewCluster().
+			ID("123").
+			Version(cmv1.NewVersion().RawID(version)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	It("Considers the upgrade completed when the cluster runs the version", func() {
+		Expect(clusterVersionReached(buildCluster("4.12.5"), "4.12.5")).To(BeTrue())
+	})
+
+	It("Considers the upgrade completed when the cluster runs a later version", func() {
+		Expect(clusterVersionReached(buildCluster("4.13.0"), "4.12.5")).To(BeTrue())
+	})
+
+	It("Doesn't consider the upgrade completed when the cluster runs an older version", func() {
+		Expect(clusterVersionReached(buildCluster("4.12.1"), "4.12.5")).To(BeFalse())
+	})
+
+	It("Doesn't consider the upgrade completed when the version isn't known", func() {
+		Expect(clusterVersionReached(buildCluster(""), "4.12.5")).To(BeFalse())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+// maintenanceWindowDays are the names of the days of the week that can be used in maintenance
+// windows, for example 'Saturday'.
+var maintenanceWindowDays = func() map[string]time.Weekday {
+	result := map[string]time.Weekday{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		result[day.String()] = day
+	}
+	return result
+}()
+
+// nextMaintenanceWindow returns the first start of the maintenance window, in UTC, that is at
+// least the given delay after the given time.
+func nextMaintenanceWindow(now time.Time, day time.Weekday, hour int,
+	delay time.Duration) time.Time {
+	earliest := now.UTC().Add(delay)
+	result := time.Date(
+		earliest.Year(), earliest.Month(), earliest.Day(),
+		hour, 0, 0, 0, time.UTC,
+	)
+	result = result.AddDate(0, 0, (int(day)-int(result.Weekday())+7)%7)
+	if result.Before(earliest) {
+		result = result.AddDate(0, 0, 7)
+	}
+	return result
+}
+
+// maintenanceWindowStart returns the next start of the given maintenance window.
+func maintenanceWindowStart(window *MaintenanceWindow) time.Time {
+	return nextMaintenanceWindow(
+		time.Now(),
+		maintenanceWindowDays[window.Day.Value],
+		int(window.Hour.Value),
+		defaultUpgradeDelay,
+	)
+}
+
+// sameMaintenanceWindow checks if the given maintenance windows are equal, including when both
+// are missing.
+func sameMaintenanceWindow(a, b *MaintenanceWindow) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Day.Value == b.Day.Value && a.Hour.Value == b.Hour.Value
+}
+
+func maintenanceWindowValidators() []tfsdk.AttributeValidator {
+	return []tfsdk.AttributeValidator{
+		&common.AttributeValidator{
+			Desc: "Validate maintenance window",
+			Validator: func(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+				var window *MaintenanceWindow
+				diag := req.Config.GetAttribute(ctx, req.AttributePath, &window)
+				if diag.HasError() || window == nil {
+					// No attribute to validate
+					return
+				}
+				if !window.Day.Unknown && !window.Day.Null {
+					_, ok := maintenanceWindowDays[window.Day.Value]
+					if !ok {
+						resp.Diagnostics.AddAttributeError(
+							req.AttributePath,
+							"Invalid maintenance window",
+							fmt.Sprintf(
+								"Expected a day of the week like 'Saturday' but got '%s'",
+								window.Day.Value,
+							),
+						)
+					}
+				}
+				if !window.Hour.Unknown && !window.Hour.Null &&
+					(window.Hour.Value < 0 || window.Hour.Value > 23) {
+					resp.Diagnostics.AddAttributeError(
+						req.AttributePath,
+						"Invalid maintenance window",
+						fmt.Sprintf(
+							"Expected an hour between 0 and 23 but got %d",
+							window.Hour.Value,
+						),
+					)
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Maintenance window", func() {
+	// Thursday 2023-06-01 at 10:30 UTC:
+	now := time.Date(2023, time.June, 1, 10, 30, 0, 0, time.UTC)
+
+	It("Starts later in the same week", func() {
+		start := nextMaintenanceWindow(now, time.Saturday, 2, 10*time.Minute)
+		Expect(start).To(Equal(time.Date(2023, time.June, 3, 2, 0, 0, 0, time.UTC)))
+	})
+
+	It("Starts later the same day", func() {
+		start := nextMaintenanceWindow(now, time.Thursday, 22, 10*time.Minute)
+		Expect(start).To(Equal(time.Date(2023, time.June, 1, 22, 0, 0, 0, time.UTC)))
+	})
+
+	It("Moves to the next week when the window already started", func() {
+		start := nextMaintenanceWindow(now, time.Thursday, 10, 10*time.Minute)
+		Expect(start).To(Equal(time.Date(2023, time.June, 8, 10, 0, 0, 0, time.UTC)))
+	})
+
+	It("Moves to the next week when the window starts before the delay", func() {
+		start := nextMaintenanceWindow(now, time.Thursday, 11, 45*time.Minute)
+		Expect(start).To(Equal(time.Date(2023, time.June, 8, 11, 0, 0, 0, time.UTC)))
+	})
+
+	It("Uses UTC for times in other locations", func() {
+		location := time.FixedZone("UTC+3", 3*60*60)
+		start := nextMaintenanceWindow(now.In(location), time.Friday, 0, 10*time.Minute)
+		Expect(start).To(Equal(time.Date(2023, time.June, 2, 0, 0, 0, 0, time.UTC)))
+	})
+})
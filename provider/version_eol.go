@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// defaultVersionEOLWarningDays is the number of days before the end of life of a version when
+// the provider starts warning about it, when the 'version_eol_warning_days' attribute of the
+// provider isn't set. The warnings are disabled by default, as the check retrieves the versions
+// during every refresh.
+const defaultVersionEOLWarningDays = int64(0)
+
+// versionEOLSummary is the summary of the warnings about versions close to their end of life.
+const versionEOLSummary = "OpenShift version is close to its end of life"
+
+// versionEOLWarning returns the text of the warning for a version whose end of life is within
+// the given window, or has already passed. It returns an empty string if there is nothing to
+// warn about, or if the server doesn't know the end of life of the version.
+func versionEOLWarning(version *cmv1.Version, now time.Time, window time.Duration) string {
+	eol, ok := version.GetEndOfLifeTimestamp()
+	if !ok || eol.IsZero() || window <= 0 {
+		return ""
+	}
+	date := eol.UTC().Format("2006-01-02")
+	left := eol.Sub(now)
+	if left <= 0 {
+		return fmt.Sprintf(
+			"Version '%s' reached its end of life on %s and is no longer supported, "+
+				"upgrade the cluster to a supported version",
+			version.RawID(), date,
+		)
+	}
+	if left > window {
+		return ""
+	}
+	return fmt.Sprintf(
+		"Version '%s' reaches its end of life on %s, in %d days, schedule an upgrade "+
+			"to a newer version before that date",
+		version.RawID(), date, int(math.Ceil(left.Hours()/24)),
+	)
+}
+
+// checkVersionEOL adds a warning to the diagnostics if the given version, of the given channel
+// group, is close to its end of life. The check is best effort, failures to retrieve the
+// versions are only logged.
+func (r *ClusterRosaClassicResource) checkVersionEOL(ctx context.Context, rawID, channelGroup string,
+	diags *diag.Diagnostics) {
+	if r.versionEOLWarning <= 0 || rawID == "" {
+		return
+	}
+	if channelGroup == "" {
+		channelGroup = ocm.DefaultChannelGroup
+	}
+	rawID = strings.TrimPrefix(rawID, "openshift-v")
+	versions, err := r.getVersions(r.logger, ctx, channelGroup)
+	if err != nil {
+		r.logger.Debug(ctx, "Can't check the end of life of version '%s': %v", rawID, err)
+		return
+	}
+	for _, version := range versions {
+		if version.RawID() != rawID {
+			continue
+		}
+		warning := versionEOLWarning(version, time.Now(), r.versionEOLWarning)
+		if warning != "" {
+			diags.AddWarning(versionEOLSummary, warning)
+		}
+		return
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Version end of life", func() {
+	now := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	window := 60 * 24 * time.Hour
+
+	buildVersion := func(eol time.Time) *cmv1.Version {
+		builder := cmv1.NewVersion().ID("openshift-v4.11.5").RawID("4.11.5")
+		if !eol.IsZero() {
+			builder.EndOfLifeTimestamp(eol)
+		}
+		version, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		return version
+	}
+
+	It("Doesn't warn about versions far from their end of life", func() {
+		Expect(versionEOLWarning(buildVersion(now.Add(90*24*time.Hour)), now, window)).To(BeEmpty())
+	})
+
+	It("Doesn't warn about versions without end of life", func() {
+		Expect(versionEOLWarning(buildVersion(time.Time{}), now, window)).To(BeEmpty())
+	})
+
+	It("Doesn't warn when disabled", func() {
+		Expect(versionEOLWarning(buildVersion(now.Add(24*time.Hour)), now, 0)).To(BeEmpty())
+	})
+
+	It("Warns about versions close to their end of life", func() {
+		warning := versionEOLWarning(buildVersion(now.Add(30*24*time.Hour)), now, window)
+		Expect(warning).To(ContainSubstring("'4.11.5' reaches its end of life on 2023-07-01, in 30 days"))
+	})
+
+	It("Warns about versions past their end of life", func() {
+		warning := versionEOLWarning(buildVersion(now.Add(-24*time.Hour)), now, window)
+		Expect(warning).To(ContainSubstring("reached its end of life on 2023-05-31"))
+	})
+})
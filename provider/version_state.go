@@ -17,10 +17,41 @@ limitations under the License.
 package provider
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
 type VersionState struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Default            types.Bool   `tfsdk:"default"`
+	EndOfLifeTimestamp types.String `tfsdk:"end_of_life_timestamp"`
+}
+
+// newVersionState converts a version returned by the server into its state. The end of life is
+// null when the server doesn't know it.
+func newVersionState(version *cmv1.Version) *VersionState {
+	state := &VersionState{
+		ID: types.String{
+			Value: version.ID(),
+		},
+		Name: types.String{
+			Value: version.RawID(),
+		},
+		Default: types.Bool{
+			Value: version.Default(),
+		},
+		EndOfLifeTimestamp: types.String{
+			Null: true,
+		},
+	}
+	endOfLife, ok := version.GetEndOfLifeTimestamp()
+	if ok && !endOfLife.IsZero() {
+		state.EndOfLifeTimestamp = types.String{
+			Value: endOfLife.UTC().Format(time.RFC3339),
+		}
+	}
+	return state
 }
@@ -32,6 +32,7 @@ type VersionsDataSourceType struct {
 type VersionsDataSource struct {
 	logger     logging.Logger
 	collection *cmv1.VersionsClient
+	cache      *lookupCache
 }
 
 func (t *VersionsDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
@@ -81,6 +82,19 @@ func (t *VersionsDataSourceType) itemAttributes() map[string]tfsdk.Attribute {
 			Type:        types.StringType,
 			Computed:    true,
 		},
+		"default": {
+			Description: "Indicates if this is the version used by default when creating " +
+				"clusters.",
+			Type:     types.BoolType,
+			Computed: true,
+		},
+		"end_of_life_timestamp": {
+			Description: "Date and time when the version reaches the end of its " +
+				"support, in RFC 3339 format, for example '2024-01-31T00:00:00Z'. Null " +
+				"if the end of life of the version hasn't been announced yet.",
+			Type:     types.StringType,
+			Computed: true,
+		},
 	}
 }
 
@@ -96,6 +110,7 @@ func (t *VersionsDataSourceType) NewDataSource(ctx context.Context,
 	result = &VersionsDataSource{
 		logger:     parent.logger,
 		collection: collection,
+		cache:      parent.cache,
 	}
 	return
 }
@@ -111,26 +126,55 @@ func (s *VersionsDataSource) Read(ctx context.Context, request tfsdk.ReadDataSou
 	}
 
 	// Fetch the list of versions:
-	var listItems []*cmv1.Version
-	listSize := 100
-	listPage := 1
-	listRequest := s.collection.List().Size(listSize)
+	search := "enabled = 't'"
 	if !state.Search.Unknown && !state.Search.Null {
-		listRequest.Search(state.Search.Value)
-	} else {
-		listRequest.Search("enabled = 't'")
+		search = state.Search.Value
 	}
+	order := ""
 	if !state.Order.Unknown && !state.Order.Null {
-		listRequest.Order(state.Order.Value)
+		order = state.Order.Value
+	}
+	listItems, err := s.cache.getVersions(search+"\n"+order, func() ([]*cmv1.Version, error) {
+		return s.listVersions(ctx, search, order)
+	})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Can't list versions",
+			err.Error(),
+		)
+		return
+	}
+
+	// Populate the state:
+	state.Items = make([]*VersionState, len(listItems))
+	for i, listItem := range listItems {
+		state.Items[i] = newVersionState(listItem)
+	}
+	if len(state.Items) == 1 {
+		state.Item = state.Items[0]
+	} else {
+		state.Item = nil
+	}
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// listVersions retrieves all the pages of versions that match the given search and order
+// criteria.
+func (s *VersionsDataSource) listVersions(ctx context.Context, search, order string) ([]*cmv1.Version, error) {
+	var listItems []*cmv1.Version
+	listSize := 100
+	listPage := 1
+	listRequest := s.collection.List().Size(listSize).Search(search)
+	if order != "" {
+		listRequest.Order(order)
 	}
 	for {
 		listResponse, err := listRequest.SendContext(ctx)
 		if err != nil {
-			response.Diagnostics.AddError(
-				"Can't list versions",
-				err.Error(),
-			)
-			return
+			return nil, err
 		}
 		if listItems == nil {
 			listItems = make([]*cmv1.Version, 0, listResponse.Total())
@@ -139,32 +183,11 @@ func (s *VersionsDataSource) Read(ctx context.Context, request tfsdk.ReadDataSou
 			listItems = append(listItems, listItem)
 			return true
 		})
-		if listResponse.Size() < listSize {
+		if listResponse.Items().Len() == 0 || len(listItems) >= listResponse.Total() {
 			break
 		}
 		listPage++
 		listRequest.Page(listPage)
 	}
-
-	// Populate the state:
-	state.Items = make([]*VersionState, len(listItems))
-	for i, listItem := range listItems {
-		state.Items[i] = &VersionState{
-			ID: types.String{
-				Value: listItem.ID(),
-			},
-			Name: types.String{
-				Value: listItem.RawID(),
-			},
-		}
-	}
-	if len(state.Items) == 1 {
-		state.Item = state.Items[0]
-	} else {
-		state.Item = nil
-	}
-
-	// Save the state:
-	diags = response.State.Set(ctx, state)
-	response.Diagnostics.Append(diags...)
+	return listItems, nil
 }
@@ -0,0 +1,296 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	"github.com/terraform-redhat/terraform-provider-ocm/provider/common"
+)
+
+type VPCSubnetsDataSourceType struct {
+}
+
+type VPCSubnetsDataSource struct {
+	logger      logging.Logger
+	awsSettings awsSettings
+}
+
+func (t *VPCSubnetsDataSourceType) GetSchema(ctx context.Context) (result tfsdk.Schema,
+	diags diag.Diagnostics) {
+	result = tfsdk.Schema{
+		Description: "Subnets of an existing AWS VPC, classified as private or public, in " +
+			"the form needed by the 'aws_subnet_ids' and 'availability_zones' attributes " +
+			"of clusters installed in an existing VPC. The subnets are retrieved " +
+			"directly from AWS, using the AWS credentials of the provider.",
+		Attributes: map[string]tfsdk.Attribute{
+			"region": {
+				Description: "AWS region, for example 'us-east-1'.",
+				Type:        types.StringType,
+				Required:    true,
+			},
+			"vpc_id": {
+				Description: "Identifier of the VPC, for example 'vpc-0123456789abcdef0'. " +
+					"At least one of 'vpc_id' or 'tags' is required.",
+				Type:     types.StringType,
+				Optional: true,
+			},
+			"tags": {
+				Description: "Tags that the subnets must have, with the given values.",
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional: true,
+			},
+			"subnet_ids": {
+				Description: "Identifiers of the private and public subnets, for the " +
+					"'aws_subnet_ids' attribute of public clusters.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Computed: true,
+			},
+			"private_subnet_ids": {
+				Description: "Identifiers of the private subnets, for the " +
+					"'aws_subnet_ids' attribute of PrivateLink clusters.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Computed: true,
+			},
+			"public_subnet_ids": {
+				Description: "Identifiers of the public subnets, the ones routed to an " +
+					"internet gateway.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Computed: true,
+			},
+			"availability_zones": {
+				Description: "Availability zones of the private subnets, for the " +
+					"'availability_zones' attribute of the cluster.",
+				Type: types.ListType{
+					ElemType: types.StringType,
+				},
+				Computed: true,
+			},
+			"items": {
+				Description: "Details of the subnets.",
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"id": {
+						Description: "Identifier of the subnet.",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"vpc_id": {
+						Description: "Identifier of the VPC of the subnet.",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"availability_zone": {
+						Description: "Availability zone of the subnet.",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"cidr_block": {
+						Description: "IPv4 CIDR block of the subnet.",
+						Type:        types.StringType,
+						Computed:    true,
+					},
+					"public": {
+						Description: "Indicates if the subnet is routed to an internet gateway.",
+						Type:        types.BoolType,
+						Computed:    true,
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+				Computed: true,
+			},
+		},
+	}
+	return
+}
+
+func (t *VPCSubnetsDataSourceType) NewDataSource(ctx context.Context,
+	p tfsdk.Provider) (result tfsdk.DataSource, diags diag.Diagnostics) {
+	// Cast the provider interface to the specific implementation:
+	parent := p.(*Provider)
+
+	// Create the data source:
+	result = &VPCSubnetsDataSource{
+		logger:      parent.logger,
+		awsSettings: parent.awsSettings,
+	}
+	return
+}
+
+func (s *VPCSubnetsDataSource) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest,
+	response *tfsdk.ReadDataSourceResponse) {
+	// Get the state:
+	state := &VPCSubnetsState{}
+	diags := request.Config.Get(ctx, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	vpcID := ""
+	if !state.VPCID.Unknown && !state.VPCID.Null {
+		vpcID = state.VPCID.Value
+	}
+	tags := map[string]string{}
+	if !state.Tags.Unknown && !state.Tags.Null {
+		for k, v := range state.Tags.Elems {
+			tags[k] = v.(types.String).Value
+		}
+	}
+	if vpcID == "" && len(tags) == 0 {
+		response.Diagnostics.AddError(
+			"Missing subnet filter",
+			"At least one of 'vpc_id' or 'tags' is required",
+		)
+		return
+	}
+
+	// Fetch the subnets:
+	sess, err := buildSession(state.Region.Value, s.awsSettings)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't create AWS session",
+			fmt.Sprintf(
+				"Can't create AWS session for region '%s': %v",
+				state.Region.Value, err,
+			),
+			err,
+		)
+		return
+	}
+	items, err := discoverSubnets(ec2.New(sess), vpcID, tags)
+	if err != nil {
+		addClassifiedError(
+			&response.Diagnostics,
+			"Can't discover subnets",
+			fmt.Sprintf(
+				"Can't discover subnets in region '%s': %v",
+				state.Region.Value, err,
+			),
+			err,
+		)
+		return
+	}
+
+	// Populate the state:
+	var all, private, public, zones []string
+	for _, item := range items {
+		all = append(all, item.ID.Value)
+		if item.Public.Value {
+			public = append(public, item.ID.Value)
+			continue
+		}
+		private = append(private, item.ID.Value)
+		if len(zones) == 0 || zones[len(zones)-1] != item.AvailabilityZone.Value {
+			zones = append(zones, item.AvailabilityZone.Value)
+		}
+	}
+	state.SubnetIDs = common.StringArrayToList(all)
+	state.PrivateSubnetIDs = common.StringArrayToList(private)
+	state.PublicSubnetIDs = common.StringArrayToList(public)
+	state.AvailabilityZones = common.StringArrayToList(zones)
+	state.Items = items
+
+	// Save the state:
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+// discoverSubnets returns the subnets of the given VPC that have the given tags, sorted by
+// availability zone and identifier.
+func discoverSubnets(client ec2iface.EC2API, vpcID string,
+	tags map[string]string) ([]*VPCSubnetState, error) {
+	var filters []*ec2.Filter
+	if vpcID != "" {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: aws.StringSlice([]string{vpcID}),
+		})
+	}
+	for k, v := range tags {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + k),
+			Values: aws.StringSlice([]string{v}),
+		})
+	}
+	var subnets []*ec2.Subnet
+	err := client.DescribeSubnetsPages(
+		&ec2.DescribeSubnetsInput{
+			Filters: filters,
+		},
+		func(page *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+			subnets = append(subnets, page.Subnets...)
+			return true
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(subnets) == 0 {
+		return []*VPCSubnetState{}, nil
+	}
+	public, err := publicSubnets(client, subnets)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(subnets, func(i, j int) bool {
+		zoneI := aws.StringValue(subnets[i].AvailabilityZone)
+		zoneJ := aws.StringValue(subnets[j].AvailabilityZone)
+		if zoneI != zoneJ {
+			return zoneI < zoneJ
+		}
+		return aws.StringValue(subnets[i].SubnetId) < aws.StringValue(subnets[j].SubnetId)
+	})
+	result := make([]*VPCSubnetState, len(subnets))
+	for i, subnet := range subnets {
+		id := aws.StringValue(subnet.SubnetId)
+		result[i] = &VPCSubnetState{
+			ID: types.String{
+				Value: id,
+			},
+			VPCID: types.String{
+				Value: aws.StringValue(subnet.VpcId),
+			},
+			AvailabilityZone: types.String{
+				Value: aws.StringValue(subnet.AvailabilityZone),
+			},
+			CIDRBlock: types.String{
+				Value: aws.StringValue(subnet.CidrBlock),
+			},
+			Public: types.Bool{
+				Value: public[id],
+			},
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("VPC subnets discovery", func() {
+	It("Classifies the subnets of the VPC and sorts them by zone", func() {
+		zonedSubnet := func(id, vpc, zone string) *ec2.Subnet {
+			subnet := fakeSubnet(id, vpc, "10.0.0.0/24")
+			subnet.AvailabilityZone = aws.String(zone)
+			return subnet
+		}
+		client := &fakeSubnetsClient{
+			subnets: []*ec2.Subnet{
+				zonedSubnet("subnet-b-private", "vpc-1", "us-east-1b"),
+				zonedSubnet("subnet-a-public", "vpc-1", "us-east-1a"),
+				zonedSubnet("subnet-a-private", "vpc-1", "us-east-1a"),
+				zonedSubnet("subnet-other", "vpc-2", "us-east-1a"),
+			},
+			tables: []*ec2.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+				},
+				{
+					VpcId: aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{
+						SubnetId: aws.String("subnet-a-public"),
+					}},
+					Routes: []*ec2.Route{{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						GatewayId:            aws.String("igw-1"),
+					}},
+				},
+			},
+		}
+		items, err := discoverSubnets(client, "vpc-1", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(items).To(HaveLen(3))
+		Expect(items[0].ID.Value).To(Equal("subnet-a-private"))
+		Expect(items[0].Public.Value).To(BeFalse())
+		Expect(items[1].ID.Value).To(Equal("subnet-a-public"))
+		Expect(items[1].Public.Value).To(BeTrue())
+		Expect(items[2].ID.Value).To(Equal("subnet-b-private"))
+		Expect(items[2].AvailabilityZone.Value).To(Equal("us-east-1b"))
+	})
+
+	It("Returns an empty list when nothing matches", func() {
+		items, err := discoverSubnets(&fakeSubnetsClient{}, "vpc-1", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(items).To(BeEmpty())
+	})
+})
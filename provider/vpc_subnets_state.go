@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type VPCSubnetsState struct {
+	Region            types.String      `tfsdk:"region"`
+	VPCID             types.String      `tfsdk:"vpc_id"`
+	Tags              types.Map         `tfsdk:"tags"`
+	SubnetIDs         types.List        `tfsdk:"subnet_ids"`
+	PrivateSubnetIDs  types.List        `tfsdk:"private_subnet_ids"`
+	PublicSubnetIDs   types.List        `tfsdk:"public_subnet_ids"`
+	AvailabilityZones types.List        `tfsdk:"availability_zones"`
+	Items             []*VPCSubnetState `tfsdk:"items"`
+}
+
+type VPCSubnetState struct {
+	ID               types.String `tfsdk:"id"`
+	VPCID            types.String `tfsdk:"vpc_id"`
+	AvailabilityZone types.String `tfsdk:"availability_zone"`
+	CIDRBlock        types.String `tfsdk:"cidr_block"`
+	Public           types.Bool   `tfsdk:"public"`
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Available upgrades data source", func() {
+	It("Lists the upgrades of the channel group of the cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "product": {
+				    "id": "rosa"
+				  },
+				  "version": {
+				    "id": "openshift-v4.12.1-fast",
+				    "channel_group": "fast"
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.12.1-fast"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "openshift-v4.12.1-fast",
+				  "raw_id": "4.12.1",
+				  "available_upgrades": [
+				    "4.12.10",
+				    "4.12.2"
+				  ]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				VerifyFormKV(
+					"search",
+					"enabled = 'true' AND channel_group = 'fast' AND "+
+						"raw_id in ('4.12.10', '4.12.2') AND rosa_enabled = 'true'",
+				),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "openshift-v4.12.10-fast",
+				      "raw_id": "4.12.10"
+				    },
+				    {
+				      "id": "openshift-v4.12.2-fast",
+				      "raw_id": "4.12.2"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_available_upgrades" "my_upgrades" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_available_upgrades", "my_upgrades")
+		Expect(resource).To(MatchJQ(`.attributes.current_version`, "openshift-v4.12.1-fast"))
+		Expect(resource).To(MatchJQ(`.attributes.channel_group`, "fast"))
+		Expect(resource).To(MatchJQ(`.attributes.items | length`, 2))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].id`, "openshift-v4.12.2-fast"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].name`, "4.12.2"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].id`, "openshift-v4.12.10-fast"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].name`, "4.12.10"))
+	})
+
+	It("Returns an empty list when there are no upgrades", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "product": {
+				    "id": "osd"
+				  },
+				  "version": {
+				    "id": "openshift-v4.12.1"
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.12.1"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "openshift-v4.12.1",
+				  "raw_id": "4.12.1"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_available_upgrades" "my_upgrades" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_available_upgrades", "my_upgrades")
+		Expect(resource).To(MatchJQ(`.attributes.channel_group`, "stable"))
+		Expect(resource).To(MatchJQ(`.attributes.items | length`, 0))
+	})
+
+	It("Fails if the cluster doesn't exist", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusNotFound, `{
+				  "kind": "Error",
+				  "id": "404",
+				  "href": "/api/clusters_mgmt/v1/errors/404",
+				  "code": "CLUSTERS-MGMT-404",
+				  "reason": "Cluster '123' not found"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_available_upgrades" "my_upgrades" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
@@ -69,6 +69,40 @@ var _ = Describe("Cloud providers data source", func() {
 		Expect(resource).To(MatchJQ(`.attributes.items[1].display_name`, "GCP"))
 	})
 
+	It("Can list cloud providers using the new name", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/cloud_providers"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "aws",
+				      "name": "aws",
+				      "display_name": "AWS"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "rhcs_cloud_providers" "all" {
+		    provider = ocm
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("rhcs_cloud_providers", "all")
+		Expect(resource).To(MatchJQ(`.attributes.items | length`, 1))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].id`, "aws"))
+	})
+
 	It("Can search cloud providers", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster admin password", func() {
+	const idpList = `{
+	  "page": 1,
+	  "size": 2,
+	  "total": 2,
+	  "items": [
+	    {
+	      "id": "456",
+	      "name": "github",
+	      "type": "GithubIdentityProvider"
+	    },
+	    {
+	      "id": "789",
+	      "name": "cluster-admin",
+	      "type": "HTPasswdIdentityProvider"
+	    }
+	  ]
+	}`
+	const userList = `{
+	  "page": 1,
+	  "size": 1,
+	  "total": 1,
+	  "items": [
+	    {
+	      "id": "a",
+	      "username": "cluster-admin"
+	    }
+	  ]
+	}`
+
+	It("Generates and sets a new password", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/identity_providers"),
+				RespondWithJSON(http.StatusOK, idpList),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/789/htpasswd_users",
+				),
+				RespondWithJSON(http.StatusOK, userList),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPatch,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/789/htpasswd_users/a",
+				),
+				VerifyJQ(`.password | length`, 23),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "a",
+				  "username": "cluster-admin"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_admin_password" "admin" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_admin_password", "admin")
+		Expect(resource).To(MatchJQ(`.attributes.id`, "a"))
+		Expect(resource).To(MatchJQ(`.attributes.password | length`, 23))
+	})
+
+	It("Fails if the identity provider isn't 'htpasswd'", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/identity_providers"),
+				RespondWithJSON(http.StatusOK, idpList),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_admin_password" "admin" {
+		    cluster           = "123"
+		    identity_provider = "github"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails if the user doesn't exist", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/identity_providers"),
+				RespondWithJSON(http.StatusOK, idpList),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/789/htpasswd_users",
+				),
+				RespondWithJSON(http.StatusOK, userList),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_admin_password" "admin" {
+		    cluster  = "123"
+		    username = "other-admin"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster credentials data source", func() {
+	It("Returns the credentials of the cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "state": "ready",
+				  "api": {
+				    "url": "https://api.my-cluster.example.com:6443"
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/credentials"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kubeconfig": "apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: bXktY2E=\n",
+				  "admin": {
+				    "user": "kubeadmin",
+				    "password": "my-password"
+				  }
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_credentials" "my_credentials" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_credentials", "my_credentials")
+		Expect(resource).To(MatchJQ(".attributes.host", "https://api.my-cluster.example.com:6443"))
+		Expect(resource).To(MatchJQ(".attributes.cluster_ca_certificate", "my-ca"))
+		Expect(resource).To(MatchJQ(".attributes.token", nil))
+		Expect(resource).To(MatchJQ(".attributes.admin_username", "kubeadmin"))
+		Expect(resource).To(MatchJQ(".attributes.admin_password", "my-password"))
+	})
+
+	It("Returns null values when OCM doesn't have the credentials", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "state": "ready",
+				  "api": {
+				    "url": "https://api.my-cluster.example.com:6443"
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/credentials"),
+				RespondWithJSON(http.StatusNotFound, `{
+				  "kind": "Error",
+				  "id": "404",
+				  "href": "/api/clusters_mgmt/v1/errors/404",
+				  "code": "CLUSTERS-MGMT-404",
+				  "reason": "Credentials for cluster '123' not found"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_credentials" "my_credentials" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_credentials", "my_credentials")
+		Expect(resource).To(MatchJQ(".attributes.host", "https://api.my-cluster.example.com:6443"))
+		Expect(resource).To(MatchJQ(".attributes.cluster_ca_certificate", nil))
+		Expect(resource).To(MatchJQ(".attributes.kubeconfig", nil))
+		Expect(resource).To(MatchJQ(".attributes.admin_username", nil))
+		Expect(resource).To(MatchJQ(".attributes.admin_password", nil))
+	})
+
+	It("Fails if the cluster isn't ready", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "state": "installing"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_credentials" "my_credentials" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Requires the password when the user is given", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_credentials" "my_credentials" {
+		    cluster  = "123"
+		    username = "my-admin"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster logs data source", func() {
+	It("Returns the last lines of the install log", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/logs/install"),
+				VerifyFormKV("tail", "2"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "Log",
+				  "id": "install",
+				  "content": "level=info msg=\"Creating infrastructure\"\nlevel=error msg=\"Quota exceeded\"\n"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_logs" "my_logs" {
+		    cluster = "123"
+		    tail    = 2
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_logs", "my_logs")
+		Expect(resource).To(MatchJQ(".attributes.available", true))
+		Expect(resource).To(MatchJQ(
+			".attributes.content",
+			"level=info msg=\"Creating infrastructure\"\nlevel=error msg=\"Quota exceeded\"\n",
+		))
+	})
+
+	It("Reports that the uninstall log isn't available yet", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/logs/uninstall"),
+				RespondWithJSON(http.StatusNotFound, `{
+				  "kind": "Error",
+				  "id": "404",
+				  "href": "/api/clusters_mgmt/v1/errors/404",
+				  "code": "CLUSTERS-MGMT-404",
+				  "reason": "Log 'uninstall' not found"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_logs" "my_logs" {
+		    cluster = "123"
+		    type    = "uninstall"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_logs", "my_logs")
+		Expect(resource).To(MatchJQ(".attributes.available", false))
+		Expect(resource).To(MatchJQ(".attributes.content", ""))
+	})
+})
@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("OSD AWS cluster", func() {
+	// This is the cluster that will be returned by the server when asked to create or retrieve
+	// a cluster. Note that the server doesn't return the access keys.
+	const template = `{
+	  "id": "123",
+	  "product": {
+	    "id": "osd"
+	  },
+	  "name": "my-cluster",
+	  "cloud_provider": {
+	    "id": "aws"
+	  },
+	  "region": {
+	    "id": "us-east-1"
+	  },
+	  "multi_az": false,
+	  "properties": {},
+	  "api": {
+	    "url": "https://my-api.example.com"
+	  },
+	  "console": {
+	    "url": "https://my-console.example.com"
+	  },
+	  "nodes": {
+	    "compute": 3,
+	    "compute_machine_type": {
+	      "id": "m5.xlarge"
+	    }
+	  },
+	  "ccs": {
+	    "enabled": true
+	  },
+	  "aws": {
+	    "account_id": "123456789012"
+	  },
+	  "network": {
+	    "machine_cidr": "10.0.0.0/16",
+	    "service_cidr": "172.30.0.0/16",
+	    "pod_cidr": "10.128.0.0/14",
+	    "host_prefix": 23
+	  },
+	  "version": {
+	    "id": "openshift-v4.12.3"
+	  },
+	  "state": "ready"
+	}`
+
+	It("Creates a CCS cluster with access keys", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(`.name`, "my-cluster"),
+				VerifyJQ(`.product.id`, "osd"),
+				VerifyJQ(`.cloud_provider.id`, "aws"),
+				VerifyJQ(`.region.id`, "us-east-1"),
+				VerifyJQ(`.ccs.enabled`, true),
+				VerifyJQ(`.aws.account_id`, "123456789012"),
+				VerifyJQ(`.aws.access_key_id`, "my-key"),
+				VerifyJQ(`.aws.secret_access_key`, "my-secret"),
+				RespondWithJSON(http.StatusCreated, template),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_osd_aws" "my_cluster" {
+		    name                  = "my-cluster"
+		    cloud_region          = "us-east-1"
+		    aws_account_id        = "123456789012"
+		    aws_access_key_id     = "my-key"
+		    aws_secret_access_key = "my-secret"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_osd_aws", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.product", "osd"))
+		Expect(resource).To(MatchJQ(".attributes.cloud_provider", "aws"))
+		Expect(resource).To(MatchJQ(".attributes.ccs_enabled", true))
+		Expect(resource).To(MatchJQ(".attributes.aws_access_key_id", "my-key"))
+		Expect(resource).To(MatchJQ(".attributes.aws_secret_access_key", "my-secret"))
+	})
+
+	It("Requires the access keys", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_osd_aws" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-east-1"
+		    aws_account_id = "123456789012"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Rejects an invalid account identifier", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_osd_aws" "my_cluster" {
+		    name                  = "my-cluster"
+		    cloud_region          = "us-east-1"
+		    aws_account_id        = "my-account"
+		    aws_access_key_id     = "my-key"
+		    aws_secret_access_key = "my-secret"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
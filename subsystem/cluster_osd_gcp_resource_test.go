@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("OSD GCP cluster", func() {
+	// This is the cluster that will be returned by the server when asked to create or retrieve
+	// a cluster.
+	const template = `{
+	  "id": "123",
+	  "product": {
+	    "id": "osd"
+	  },
+	  "name": "my-cluster",
+	  "cloud_provider": {
+	    "id": "gcp"
+	  },
+	  "region": {
+	    "id": "us-east1"
+	  },
+	  "multi_az": false,
+	  "billing_model": "standard",
+	  "properties": {},
+	  "api": {
+	    "url": "https://my-api.example.com"
+	  },
+	  "console": {
+	    "url": "https://my-console.example.com"
+	  },
+	  "dns": {
+	    "base_domain": "mydomain.com"
+	  },
+	  "infra_id": "my-cluster-abcd",
+	  "nodes": {
+	    "compute": 3,
+	    "compute_machine_type": {
+	      "id": "custom-4-16384"
+	    },
+	    "availability_zones": [
+	      "us-east1-b"
+	    ]
+	  },
+	  "ccs": {
+	    "enabled": true
+	  },
+	  "gcp": {
+	    "project_id": "my-project"
+	  },
+	  "network": {
+	    "machine_cidr": "10.0.0.0/16",
+	    "service_cidr": "172.30.0.0/16",
+	    "pod_cidr": "10.128.0.0/14",
+	    "host_prefix": 23
+	  },
+	  "version": {
+	    "id": "openshift-v4.12.3",
+	    "raw_id": "4.12.3"
+	  },
+	  "state": "ready"
+	}`
+
+	// serviceAccount is the Terraform expression that generates the JSON key of the service
+	// account.
+	const serviceAccount = `jsonencode({
+	  type           = "service_account"
+	  project_id     = "my-project"
+	  private_key_id = "123"
+	  private_key    = "my-private-key"
+	  client_email   = "osd-ccs-admin@my-project.iam.gserviceaccount.com"
+	  client_id      = "456"
+	  token_uri      = "https://oauth2.googleapis.com/token"
+	})`
+
+	It("Creates a cluster with the service account", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(`.name`, "my-cluster"),
+				VerifyJQ(`.product.id`, "osd"),
+				VerifyJQ(`.cloud_provider.id`, "gcp"),
+				VerifyJQ(`.region.id`, "us-east1"),
+				VerifyJQ(`.ccs.enabled`, true),
+				VerifyJQ(`.gcp.type`, "service_account"),
+				VerifyJQ(`.gcp.project_id`, "my-project"),
+				VerifyJQ(`.gcp.private_key_id`, "123"),
+				VerifyJQ(`.gcp.private_key`, "my-private-key"),
+				VerifyJQ(`.gcp.client_email`, "osd-ccs-admin@my-project.iam.gserviceaccount.com"),
+				RespondWithJSON(http.StatusCreated, template),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_osd_gcp" "my_cluster" {
+		    name                = "my-cluster"
+		    cloud_region        = "us-east1"
+		    gcp_service_account = ` + serviceAccount + `
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_osd_gcp", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.id", "123"))
+		Expect(resource).To(MatchJQ(".attributes.gcp_project_id", "my-project"))
+		Expect(resource).To(MatchJQ(".attributes.domain", "my-cluster.mydomain.com"))
+		Expect(resource).To(MatchJQ(".attributes.current_version", "4.12.3"))
+		Expect(resource).To(MatchJQ(".attributes.compute_machine_type", "custom-4-16384"))
+		Expect(resource).To(MatchJQ(".attributes.availability_zones | length", 1))
+	})
+
+	It("Creates a cluster in an existing VPC", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(`.gcp_network.vpc_name`, "my-vpc"),
+				VerifyJQ(`.gcp_network.control_plane_subnet`, "my-control-plane-subnet"),
+				VerifyJQ(`.gcp_network.compute_subnet`, "my-compute-subnet"),
+				RespondWithPatchedJSON(http.StatusCreated, template, `[
+				  {
+				    "op": "add",
+				    "path": "/gcp_network",
+				    "value": {
+				      "vpc_name": "my-vpc",
+				      "control_plane_subnet": "my-control-plane-subnet",
+				      "compute_subnet": "my-compute-subnet"
+				    }
+				  }
+				]`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_osd_gcp" "my_cluster" {
+		    name                = "my-cluster"
+		    cloud_region        = "us-east1"
+		    gcp_service_account = ` + serviceAccount + `
+		    gcp_network = {
+		      vpc_name             = "my-vpc"
+		      control_plane_subnet = "my-control-plane-subnet"
+		      compute_subnet       = "my-compute-subnet"
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_osd_gcp", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.gcp_network.vpc_name", "my-vpc"))
+	})
+
+	It("Rejects a service account that isn't valid", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_osd_gcp" "my_cluster" {
+		    name                = "my-cluster"
+		    cloud_region        = "us-east1"
+		    gcp_service_account = "my-key"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
@@ -18,6 +18,8 @@ package provider
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
 	. "github.com/onsi/gomega"                         // nolint
@@ -26,12 +28,30 @@ import (
 	"github.com/terraform-redhat/terraform-provider-ocm/build"
 )
 
+// trustBundle is a PEM encoded certificate used as additional trust bundle.
+const trustBundle = `-----BEGIN CERTIFICATE-----
+MIIBjDCCATOgAwIBAgIUEjg8wCeZncDlPBL/6GdbV2H9xDkwCgYIKoZIzj0EAwIw
+HDEaMBgGA1UEAwwRcHJveHkuZXhhbXBsZS5jb20wHhcNMjYxMDE2MDgxODQ3WhcN
+MzYxMDEzMDgxODQ3WjAcMRowGAYDVQQDDBFwcm94eS5leGFtcGxlLmNvbTBZMBMG
+ByqGSM49AgEGCCqGSM49AwEHA0IABEL0ZmCLJG1DvaSU6orjPNihr689JpfvEtgf
+bQKO0tm+UPwj97CwbVHachdr3sj4N09+/lMJq+ApcQr/3VvAc+2jUzBRMB0GA1Ud
+DgQWBBT5fnaFHsLqgS/5fdjY3CFmY8USSzAfBgNVHSMEGDAWgBT5fnaFHsLqgS/5
+fdjY3CFmY8USSzAPBgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0cAMEQCIGXx
+86aFkE7v6yVihiqcnkCxbzNzCpIeI7dYTsDVMkBaAiBE2JEeAAIFhLgE+JJYsXCn
+SV+F9EJMcjittdBu8zEfQw==
+-----END CERTIFICATE-----
+`
+
+// quotedTrustBundle is the trust bundle quoted so that it can be used inside JSON and HCL.
+var quotedTrustBundle = strconv.Quote(trustBundle)
+
 var _ = Describe("Cluster creation", func() {
 	// This is the cluster that will be returned by the server when asked to create or retrieve
 	// a cluster.
 	template := `{
 	  "id": "123",
 	  "name": "my-cluster",
+	  "infra_id": "my-cluster-8xk2p",
 	  "region": {
 	    "id": "us-west-1"
 	  },
@@ -126,7 +146,14 @@ var _ = Describe("Cluster creation", func() {
 									"master_role_arn" : "",
 									"worker_role_arn" : ""
 								  },
-								  "operator_role_prefix" : "test"
+								  "operator_role_prefix" : "test",
+								  "operator_iam_roles" : [
+									{
+									  "name": "cloud-credentials",
+									  "namespace": "openshift-ingress-operator",
+									  "role_arn": "arn:aws:iam::123:role/test-openshift-ingress-operator-cloud-credentials"
+									}
+								  ]
 							  }
 						  }
 						},
@@ -166,6 +193,13 @@ var _ = Describe("Cluster creation", func() {
 			}
 		  `)
 			Expect(terraform.Apply()).To(BeZero())
+
+			// Check that the operator roles attached to the cluster are exported:
+			resource := terraform.Resource("ocm_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(
+				`.attributes.sts.operator_role_arns["openshift-ingress-operator/cloud-credentials"]`,
+				"arn:aws:iam::123:role/test-openshift-ingress-operator-cloud-credentials",
+			))
 		})
 		It("appends the channel group when on a non-default channel", func() {
 			server.AppendHandlers(
@@ -355,7 +389,72 @@ var _ = Describe("Cluster creation", func() {
 		  }
 		`)
 		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_rosa_classic", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.infra_id", "my-cluster-8xk2p"))
+		Expect(resource).To(MatchJQ(".attributes.current_version", "4.8.0"))
+		Expect(resource).To(MatchJQ(".attributes.console_url", "https://my-console.example.com"))
 	})
+	Context("Dry run", func() {
+		const source = `
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			dry_run        = true
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`
+
+		It("Sends the cluster in dry run mode when planning", func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+					RespondWithJSON(http.StatusOK, versionListPage1),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("dryRun", "true"),
+					VerifyJQ(`.name`, "my-cluster"),
+					RespondWith(http.StatusNoContent, nil),
+				),
+			)
+			terraform.Source(source)
+			Expect(terraform.Plan()).To(BeZero())
+		})
+
+		It("Fails the plan when the server rejects the cluster", func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+					RespondWithJSON(http.StatusOK, versionListPage1),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("dryRun", "true"),
+					RespondWithJSON(http.StatusBadRequest, `{
+					  "kind": "Error",
+					  "id": "400",
+					  "href": "/api/clusters_mgmt/v1/errors/400",
+					  "code": "CLUSTERS-MGMT-400",
+					  "reason": "Cluster name 'my-cluster' already exists"
+					}`),
+				),
+			)
+			terraform.Source(source)
+			Expect(terraform.Apply()).ToNot(BeZero())
+		})
+	})
+
 	It("Creates basic cluster with properties", func() {
 		prop_key := "my_prop_key"
 		prop_val := "my_prop_val"
@@ -586,6 +685,92 @@ var _ = Describe("Cluster creation", func() {
 
 		})
 
+		It("Replaces the cluster when the region changes", func() {
+			terraform.Source(`
+				  resource "ocm_cluster_rosa_classic" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-west-1"
+					aws_account_id = "123"
+					disable_waiting_in_destroy = true
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							master_role_arn = "",
+							worker_role_arn = "",
+						}
+					}
+				  }
+			`)
+			Expect(terraform.Apply()).To(BeZero())
+
+			// The cluster should be deleted and created again instead of patched:
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+					RespondWithJSON(http.StatusOK, versionListPage1),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+					VerifyJQ(`.region.id`, "us-east-1"),
+					RespondWithPatchedJSON(http.StatusCreated, template, `[
+					{
+					  "op": "replace",
+					  "path": "/region/id",
+					  "value": "us-east-1"
+					},
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "sts" : {
+							  "oidc_endpoint_url": "https://oidc_endpoint_url",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"master_role_arn" : "",
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					},
+					{
+					  "op": "add",
+					  "path": "/nodes",
+					  "value": {
+						"compute": 3,
+						"compute_machine_type": {
+							"id": "r5.xlarge"
+						}
+					  }
+					}]`),
+				),
+			)
+			terraform.Source(`
+				  resource "ocm_cluster_rosa_classic" "my_cluster" {
+					name           = "my-cluster"
+					cloud_region   = "us-east-1"
+					aws_account_id = "123"
+					disable_waiting_in_destroy = true
+					sts = {
+						operator_role_prefix = "test"
+						role_arn = "",
+						support_role_arn = "",
+						instance_iam_roles = {
+							master_role_arn = "",
+							worker_role_arn = "",
+						}
+					}
+				  }
+			`)
+			Expect(terraform.Apply()).To(BeZero())
+			resource := terraform.Resource("ocm_cluster_rosa_classic", "my_cluster")
+			Expect(resource).To(MatchJQ(`.attributes.cloud_region`, "us-east-1"))
+		})
+
 		It("Wait in destroy resource but use the default timeout", func() {
 			server.AppendHandlers(
 				CombineHandlers(
@@ -868,7 +1053,7 @@ var _ = Describe("Cluster creation", func() {
 				VerifyJQ(`.product.id`, "rosa"),
 				VerifyJQ(`.proxy.http_proxy`, "http://proxy.com"),
 				VerifyJQ(`.proxy.https_proxy`, "https://proxy.com"),
-				VerifyJQ(`.additional_trust_bundle`, "123"),
+				VerifyJQ(`.additional_trust_bundle`, trustBundle),
 				RespondWithPatchedJSON(http.StatusOK, template, `[
 					{
 					  "op": "add",
@@ -899,7 +1084,7 @@ var _ = Describe("Cluster creation", func() {
 					  "op": "add",
 					  "path": "/",
 					  "value": {
-						  "additional_trust_bundle" : "123"
+						  "additional_trust_bundle" : `+quotedTrustBundle+`
 					  }
 					},
 					{
@@ -924,7 +1109,7 @@ var _ = Describe("Cluster creation", func() {
 			proxy = {
 				http_proxy = "http://proxy.com",
 				https_proxy = "https://proxy.com",
-				additional_trust_bundle = "123",
+				additional_trust_bundle = ` + quotedTrustBundle + `,
 			}
 			sts = {
 				operator_role_prefix = "test"
@@ -975,7 +1160,7 @@ var _ = Describe("Cluster creation", func() {
 					  "op": "add",
 					  "path": "/",
 					  "value": {
-						  "additional_trust_bundle" : "123"
+						  "additional_trust_bundle" : `+quotedTrustBundle+`
 					  }
 					},
 					{
@@ -993,7 +1178,7 @@ var _ = Describe("Cluster creation", func() {
 				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
 				VerifyJQ(`.proxy.https_proxy`, "https://proxy2.com"),
 				VerifyJQ(`.proxy.no_proxy`, "test"),
-				VerifyJQ(`.additional_trust_bundle`, "123"),
+				VerifyJQ(`.additional_trust_bundle`, trustBundle),
 				RespondWithPatchedJSON(http.StatusOK, template, `[
 					{
 					  "op": "add",
@@ -1024,7 +1209,7 @@ var _ = Describe("Cluster creation", func() {
 					  "op": "add",
 					  "path": "/",
 					  "value": {
-						  "additional_trust_bundle" : "123"
+						  "additional_trust_bundle" : `+quotedTrustBundle+`
 					  }
 					},
 					{
@@ -1049,7 +1234,7 @@ var _ = Describe("Cluster creation", func() {
 			proxy = {
 				https_proxy = "https://proxy2.com",
 				no_proxy = "test"
-				additional_trust_bundle = "123",
+				additional_trust_bundle = ` + quotedTrustBundle + `,
 			}
 			sts = {
 				operator_role_prefix = "test"
@@ -1066,7 +1251,7 @@ var _ = Describe("Cluster creation", func() {
 		resource := terraform.Resource("ocm_cluster_rosa_classic", "my_cluster")
 		Expect(resource).To(MatchJQ(`.attributes.proxy.https_proxy`, "https://proxy2.com"))
 		Expect(resource).To(MatchJQ(`.attributes.proxy.no_proxy`, "test"))
-		Expect(resource).To(MatchJQ(`.attributes.proxy.additional_trust_bundle`, "123"))
+		Expect(resource).To(MatchJQ(`.attributes.proxy.additional_trust_bundle`, trustBundle))
 	})
 
 	It("Except to fail on proxy validators", func() {
@@ -1078,7 +1263,7 @@ var _ = Describe("Cluster creation", func() {
 			aws_account_id = "123"
 			proxy = {
 				no_proxy = "test1, test2"
-				additional_trust_bundle = "123",
+				additional_trust_bundle = ` + quotedTrustBundle + `,
 			}
 			sts = {
 				operator_role_prefix = "test"
@@ -1364,11 +1549,11 @@ var _ = Describe("Cluster creation", func() {
 						  "sts" : {
 							  "oidc_endpoint_url": "https://oidc_endpoint_url",
 							  "thumbprint": "111111",
-							  "role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
-							  "support_role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Support-Role",
+							  "role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+							  "support_role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
 							  "instance_iam_roles" : {
-								"master_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-ControlPlane-Role",
-								"worker_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-Worker-Role"
+								"master_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+								"worker_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
 							  },
 							  "operator_role_prefix" : "terraform-operator"
 						  }
@@ -1406,11 +1591,11 @@ var _ = Describe("Cluster creation", func() {
 						  "sts" : {
 							  "oidc_endpoint_url": "https://oidc_endpoint_url",
 							  "thumbprint": "111111",
-							  "role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
-							  "support_role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Support-Role",
+							  "role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+							  "support_role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
 							  "instance_iam_roles" : {
-								"master_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-ControlPlane-Role",
-								"worker_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-Worker-Role"
+								"master_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+								"worker_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
 							  },
 							  "operator_role_prefix" : "terraform-operator"
 						  }
@@ -1441,7 +1626,7 @@ var _ = Describe("Cluster creation", func() {
 		resource "ocm_cluster_rosa_classic" "my_cluster" {
 			name           = "my-cluster"	
 			cloud_region   = "us-west-1"
-			aws_account_id = "123"
+			aws_account_id = "123456789012"
 			autoscaling_enabled = "true"
 			min_replicas = "3"
 			max_replicas = "4"
@@ -1450,11 +1635,11 @@ var _ = Describe("Cluster creation", func() {
 				"label_key2" = "label_value2"
 			}
 			sts = {
-				role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
-				support_role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-Support-Role",
+				role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+				support_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
 				instance_iam_roles = {
-				  master_role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-ControlPlane-Role",
-				  worker_role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-Worker-Role"
+				  master_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+				  worker_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
 				},
 				"operator_role_prefix" : "terraform-operator"
 			}
@@ -1475,11 +1660,11 @@ var _ = Describe("Cluster creation", func() {
 						  "sts" : {
 							  "oidc_endpoint_url": "https://oidc_endpoint_url",
 							  "thumbprint": "111111",
-							  "role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
-							  "support_role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Support-Role",
+							  "role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+							  "support_role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
 							  "instance_iam_roles" : {
-								"master_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-ControlPlane-Role",
-								"worker_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-Worker-Role"
+								"master_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+								"worker_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
 							  },
 							  "operator_role_prefix" : "terraform-operator"
 						  }
@@ -1515,11 +1700,11 @@ var _ = Describe("Cluster creation", func() {
 						  "sts" : {
 							  "oidc_endpoint_url": "https://oidc_endpoint_url",
 							  "thumbprint": "111111",
-							  "role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
-							  "support_role_arn": "arn:aws:iam::account-id:role/ManagedOpenShift-Support-Role",
+							  "role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+							  "support_role_arn": "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
 							  "instance_iam_roles" : {
-								"master_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-ControlPlane-Role",
-								"worker_role_arn" : "arn:aws:iam::account-id:role/ManagedOpenShift-Worker-Role"
+								"master_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+								"worker_role_arn" : "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
 							  },
 							  "operator_role_prefix" : "terraform-operator"
 						  }
@@ -1547,18 +1732,18 @@ var _ = Describe("Cluster creation", func() {
 		resource "ocm_cluster_rosa_classic" "my_cluster" {
 			name           = "my-cluster"	
 			cloud_region   = "us-west-1"
-			aws_account_id = "123" 
+			aws_account_id = "123456789012" 
 			replicas = 4
 			default_mp_labels = {
 				"label_key1" = "label_value1", 
 				"label_key2" = "label_value2"
 			}
 			sts = {
-				role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-Installer-Role",
-				support_role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-Support-Role",
+				role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+				support_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
 				instance_iam_roles = {
-				  master_role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-ControlPlane-Role",
-				  worker_role_arn = "arn:aws:iam::account-id:role/ManagedOpenShift-Worker-Role"
+				  master_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+				  worker_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
 				},
 				"operator_role_prefix" : "terraform-operator"
 			}
@@ -1839,6 +2024,129 @@ var _ = Describe("Cluster creation", func() {
 		Expect(terraform.Apply()).ToNot(BeZero())
 	})
 
+	It("Creates cluster with network type", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				RespondWithJSON(http.StatusOK, versionListPage1),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(`.network.type`, "OVNKubernetes"),
+				RespondWithPatchedJSON(http.StatusCreated, template, `[
+					{
+					  "op": "add",
+					  "path": "/network/type",
+					  "value": "OVNKubernetes"
+					},
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "sts" : {
+							  "oidc_endpoint_url": "https://oidc_endpoint_url",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"master_role_arn" : "",
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					},
+					{
+					  "op": "add",
+					  "path": "/nodes",
+					  "value": {
+						"compute": 3,
+						"compute_machine_type": {
+							"id": "r5.xlarge"
+						}
+					  }
+					}]`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			version        = "4.11.1"
+			network_type   = "OVNKubernetes"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+		resource := terraform.Resource("ocm_cluster_rosa_classic", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.network_type", "OVNKubernetes"))
+	})
+
+	It("Fails to create cluster with a network type not supported by the version", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				RespondWithJSON(http.StatusOK, versionListPage1),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			version        = "4.10.1"
+			network_type   = "OVNKubernetes"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("Fails to create cluster with an unknown network type", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			network_type   = "Calico"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
 	It("Fails to create cluster with http tokens with not supported value", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -1903,4 +2211,292 @@ var _ = Describe("Cluster creation", func() {
 		// expect to get an error
 		Expect(terraform.Apply()).ToNot(BeZero())
 	})
+
+	It("Fails to create cluster with role ARNs of a different account", func() {
+		// The server isn't prepared because the validation happens before calling it.
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123456789012"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "arn:aws:iam::210987654321:role/ManagedOpenShift-Installer-Role",
+				support_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
+				instance_iam_roles = {
+					master_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-ControlPlane-Role",
+					worker_role_arn = "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails to create cluster with an invalid KMS key ARN", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			kms_key_arn    = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails to create cluster with a name that isn't a valid DNS label", func() {
+		// The server isn't prepared because the validation happens before calling it.
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "My_Cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails to create cluster with an invalid property key", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			properties = {
+				"my key" = "my value"
+			}
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Creates cluster billed through the AWS Marketplace", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				RespondWithJSON(http.StatusOK, versionListPage1),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(`.billing_model`, "marketplace-aws"),
+				RespondWithPatchedJSON(http.StatusCreated, template, `[
+					{
+					  "op": "add",
+					  "path": "/billing_model",
+					  "value": "marketplace-aws"
+					},
+					{
+					  "op": "add",
+					  "path": "/aws",
+					  "value": {
+						  "sts" : {
+							  "oidc_endpoint_url": "https://oidc_endpoint_url",
+							  "thumbprint": "111111",
+							  "role_arn": "",
+							  "support_role_arn": "",
+							  "instance_iam_roles" : {
+								"master_role_arn" : "",
+								"worker_role_arn" : ""
+							  },
+							  "operator_role_prefix" : "test"
+						  }
+					  }
+					},
+					{
+					  "op": "add",
+					  "path": "/nodes",
+					  "value": {
+						"compute": 3,
+						"compute_machine_type": {
+							"id": "r5.xlarge"
+						}
+					  }
+					}]`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			billing_model  = "marketplace-aws"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+		resource := terraform.Resource("ocm_cluster_rosa_classic", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.billing_model", "marketplace-aws"))
+	})
+
+	It("Fails to create cluster with an unknown billing model", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			billing_model  = "free"
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Ignores line ending differences in the additional trust bundle", func() {
+		// The bundle of the configuration uses Windows line endings and indentation, it is sent
+		// normalized and the value returned by the server doesn't cause a difference:
+		crlfTrustBundle := strings.ReplaceAll("  "+trustBundle, "\n", "\r\n")
+		cluster := `[
+			{
+			  "op": "add",
+			  "path": "/aws",
+			  "value": {
+				  "sts" : {
+					  "oidc_endpoint_url": "https://oidc_endpoint_url",
+					  "thumbprint": "111111",
+					  "role_arn": "",
+					  "support_role_arn": "",
+					  "instance_iam_roles" : {
+						"master_role_arn" : "",
+						"worker_role_arn" : ""
+					  },
+					  "operator_role_prefix" : "test"
+				  }
+			  }
+			},
+			{
+			  "op": "add",
+			  "path": "/proxy",
+			  "value": {
+				  "http_proxy" : "http://proxy.com"
+			  }
+			},
+			{
+			  "op": "add",
+			  "path": "/additional_trust_bundle",
+			  "value": ` + quotedTrustBundle + `
+			},
+			{
+			  "op": "add",
+			  "path": "/nodes",
+			  "value": {
+				"compute": 3,
+				"compute_machine_type": {
+					"id": "r5.xlarge"
+				}
+			  }
+			}]`
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				RespondWithJSON(http.StatusOK, versionListPage1),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(`.additional_trust_bundle`, trustBundle),
+				RespondWithPatchedJSON(http.StatusCreated, template, cluster),
+			),
+		)
+		source := `
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			proxy = {
+				http_proxy = "http://proxy.com",
+				additional_trust_bundle = ` + strconv.Quote(crlfTrustBundle) + `,
+			}
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`
+		terraform.Source(source)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Applying again only reads the cluster, the server would fail any update:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, template, cluster),
+			),
+		)
+		Expect(terraform.Apply()).To(BeZero())
+	})
+
+	It("Fails to create cluster with an additional trust bundle that isn't PEM", func() {
+		terraform.Source(`
+		  resource "ocm_cluster_rosa_classic" "my_cluster" {
+		    name           = "my-cluster"
+		    cloud_region   = "us-west-1"
+			aws_account_id = "123"
+			proxy = {
+				http_proxy = "http://proxy.com",
+				additional_trust_bundle = "123",
+			}
+			sts = {
+				operator_role_prefix = "test"
+				role_arn = "",
+				support_role_arn = "",
+				instance_iam_roles = {
+					master_role_arn = "",
+					worker_role_arn = "",
+				}
+			}
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
 })
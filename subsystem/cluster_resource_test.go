@@ -121,6 +121,11 @@ var _ = Describe("Cluster creation", func() {
 
 	It("Sets compute nodes and machine type", func() {
 		// Prepare the server:
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
 		server.AppendHandlers(
 			CombineHandlers(
 				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
@@ -149,6 +154,27 @@ var _ = Describe("Cluster creation", func() {
 		Expect(resource).To(MatchJQ(".attributes.compute_machine_type", "r5.xlarge"))
 	})
 
+	It("Suggests similar machine types when the machine type doesn't exist", func() {
+		// Prepare the server:
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name                 = "my-cluster"
+			product		   		 = "osd"
+		    cloud_provider       = "aws"
+		    cloud_region         = "us-west-1"
+		    compute_machine_type = "r5xlarge"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
 	It("Creates CCS cluster", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -284,6 +310,52 @@ var _ = Describe("Cluster creation", func() {
 		Expect(resource).To(MatchJQ(".attributes.version", "openshift-v4.8.1"))
 	})
 
+	It("Sets expiration timestamp", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(".expiration_timestamp", "2023-08-01T12:00:00Z"),
+				RespondWithPatchedJSON(http.StatusOK, template, `[
+				  {
+				    "op": "add",
+				    "path": "/expiration_timestamp",
+				    "value": "2023-08-01T12:00:00Z"
+				  }
+				]`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name                 = "my-cluster"
+		    product              = "osd"
+		    cloud_provider       = "aws"
+		    cloud_region         = "us-west-1"
+		    expiration_timestamp = "2023-08-01T14:00:00+02:00"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check that the configured value is kept, as it is the same time:
+		resource := terraform.Resource("ocm_cluster", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.expiration_timestamp", "2023-08-01T14:00:00+02:00"))
+	})
+
+	It("Rejects an invalid expiration timestamp", func() {
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name                 = "my-cluster"
+		    product              = "osd"
+		    cloud_provider       = "aws"
+		    cloud_region         = "us-west-1"
+		    expiration_timestamp = "tomorrow"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
 	It("Fails if the cluster already exists", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -308,4 +380,215 @@ var _ = Describe("Cluster creation", func() {
 		`)
 		Expect(terraform.Apply()).ToNot(BeZero())
 	})
+
+	It("Deletes the cluster when it fails to install and cleanup is enabled", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				RespondWithPatchedJSON(http.StatusCreated, template, `[
+				  {
+				    "op": "replace",
+				    "path": "/state",
+				    "value": "installing"
+				  }
+				]`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, template, `[
+				  {
+				    "op": "replace",
+				    "path": "/state",
+				    "value": "error"
+				  },
+				  {
+				    "op": "add",
+				    "path": "/status",
+				    "value": {
+				      "provision_error_code": "OCM3055",
+				      "provision_error_message": "Insufficient AWS quota"
+				    }
+				  }
+				]`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusNoContent, "{}"),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusNotFound, "{}"),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name               = "my-cluster"
+		    product            = "osd"
+		    cloud_provider     = "aws"
+		    cloud_region       = "us-west-1"
+		    cleanup_on_failure = true
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+		Expect(server.ReceivedRequests()).To(HaveLen(4))
+	})
+
+	It("Resumes waiting for a cluster that isn't ready yet", func() {
+		installing := `[
+		  {
+		    "op": "replace",
+		    "path": "/state",
+		    "value": "installing"
+		  }
+		]`
+
+		// Create the cluster without waiting, so that it is saved while still installing:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				RespondWithPatchedJSON(http.StatusCreated, template, installing),
+			),
+		)
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name           = "my-cluster"
+		    product        = "osd"
+		    cloud_provider = "aws"
+		    cloud_region   = "us-west-1"
+		    wait           = false
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+		resource := terraform.Resource("ocm_cluster", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.state", "installing"))
+
+		// Enable waiting, the next apply should wait till the cluster is ready:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, template, installing),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, template, installing),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, template),
+			),
+		)
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name           = "my-cluster"
+		    product        = "osd"
+		    cloud_provider = "aws"
+		    cloud_region   = "us-west-1"
+		    wait           = true
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+		resource = terraform.Resource("ocm_cluster", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.state", "ready"))
+	})
+
+	It("Sets and updates load balancer and storage quotas", func() {
+		quotas := `[
+		  {
+		    "op": "add",
+		    "path": "/load_balancer_quota",
+		    "value": 4
+		  },
+		  {
+		    "op": "add",
+		    "path": "/storage_quota",
+		    "value": {
+		      "unit": "B",
+		      "value": 644245094400
+		    }
+		  }
+		]`
+
+		// Create the cluster:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				VerifyJQ(".load_balancer_quota", 4.0),
+				VerifyJQ(".storage_quota.unit", "B"),
+				VerifyJQ(".storage_quota.value", 644245094400.0),
+				RespondWithPatchedJSON(http.StatusCreated, template, quotas),
+			),
+		)
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name                = "my-cluster"
+		    product             = "osd"
+		    cloud_provider      = "aws"
+		    cloud_region        = "us-west-1"
+		    load_balancer_quota = 4
+		    storage_quota       = 600
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+		resource := terraform.Resource("ocm_cluster", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.load_balancer_quota", 4.0))
+		Expect(resource).To(MatchJQ(".attributes.storage_quota", 600.0))
+
+		// Increase the load balancer quota:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, template, quotas),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+				VerifyJQ(".load_balancer_quota", 8.0),
+				VerifyJQ(".storage_quota", nil),
+				RespondWithPatchedJSON(http.StatusOK, template, `[
+				  {
+				    "op": "add",
+				    "path": "/load_balancer_quota",
+				    "value": 8
+				  },
+				  {
+				    "op": "add",
+				    "path": "/storage_quota",
+				    "value": {
+				      "unit": "B",
+				      "value": 644245094400
+				    }
+				  }
+				]`),
+			),
+		)
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name                = "my-cluster"
+		    product             = "osd"
+		    cloud_provider      = "aws"
+		    cloud_region        = "us-west-1"
+		    load_balancer_quota = 8
+		    storage_quota       = 600
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+		resource = terraform.Resource("ocm_cluster", "my_cluster")
+		Expect(resource).To(MatchJQ(".attributes.load_balancer_quota", 8.0))
+	})
+
+	It("Rejects quotas for clusters with customer cloud subscription", func() {
+		terraform.Source(`
+		  resource "ocm_cluster" "my_cluster" {
+		    name                = "my-cluster"
+		    product             = "osd"
+		    cloud_provider      = "aws"
+		    cloud_region        = "us-west-1"
+		    ccs_enabled         = true
+		    load_balancer_quota = 4
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
 })
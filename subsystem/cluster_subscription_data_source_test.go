@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster subscription data source", func() {
+	It("Returns the subscription of the cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "subscription": {
+				    "kind": "SubscriptionLink",
+				    "id": "456",
+				    "href": "/api/accounts_mgmt/v1/subscriptions/456"
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions/456"),
+				VerifyFormKV("fetchAccounts", "true"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "456",
+				  "status": "Active",
+				  "plan": {
+				    "id": "MOA"
+				  },
+				  "support_level": "Premium",
+				  "service_level": "L1-L3",
+				  "usage": "Production",
+				  "system_units": "Cores/vCPU",
+				  "managed": true,
+				  "cluster_billing_model": "marketplace-aws",
+				  "billing_marketplace_account": "123456789012",
+				  "organization_id": "789",
+				  "creator": {
+				    "username": "my-user",
+				    "email": "my-user@example.com"
+				  },
+				  "display_name": "my-cluster",
+				  "external_cluster_id": "abc",
+				  "created_at": "2023-05-01T10:00:00Z"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_subscription" "my_subscription" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_cluster_subscription", "my_subscription")
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".attributes.status", "Active"))
+		Expect(resource).To(MatchJQ(".attributes.plan", "MOA"))
+		Expect(resource).To(MatchJQ(".attributes.support_level", "Premium"))
+		Expect(resource).To(MatchJQ(".attributes.usage", "Production"))
+		Expect(resource).To(MatchJQ(".attributes.managed", true))
+		Expect(resource).To(MatchJQ(".attributes.cluster_billing_model", "marketplace-aws"))
+		Expect(resource).To(MatchJQ(".attributes.billing_marketplace_account", "123456789012"))
+		Expect(resource).To(MatchJQ(".attributes.creator", "my-user"))
+		Expect(resource).To(MatchJQ(".attributes.creator_email", "my-user@example.com"))
+		Expect(resource).To(MatchJQ(".attributes.created_at", "2023-05-01T10:00:00Z"))
+	})
+
+	It("Fails if the cluster doesn't have a subscription", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_cluster_subscription" "my_subscription" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
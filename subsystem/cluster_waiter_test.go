@@ -192,4 +192,60 @@ var _ = Describe("Cluster creation", func() {
 		resource := terraform.Resource("ocm_cluster_wait", "rosa_cluster")
 		Expect(resource).To(MatchJQ(`.attributes.ready`, false))
 	})
+
+	It("Create cluster waiter that waits for the compute nodes", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, templateReadyState, `[
+				  {
+				    "op": "add",
+				    "path": "/nodes",
+				    "value": {
+				      "compute": 3
+				    }
+				  },
+				  {
+				    "op": "add",
+				    "path": "/status",
+				    "value": {
+				      "current_compute": 0
+				    }
+				  }
+				]`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithPatchedJSON(http.StatusOK, templateReadyState, `[
+				  {
+				    "op": "add",
+				    "path": "/nodes",
+				    "value": {
+				      "compute": 3
+				    }
+				  },
+				  {
+				    "op": "add",
+				    "path": "/status",
+				    "value": {
+				      "current_compute": 3
+				    }
+				  }
+				]`),
+			),
+		)
+
+		terraform.Source(`
+				resource "ocm_cluster_wait" "rosa_cluster" {
+				  cluster                = "123"
+				  timeout                = 1
+				  wait_for_compute_nodes = true
+				}
+			`)
+
+		Expect(terraform.Apply()).To(BeZero())
+		resource := terraform.Resource("ocm_cluster_wait", "rosa_cluster")
+		Expect(resource).To(MatchJQ(`.attributes.ready`, true))
+	})
 })
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Clusters data source", func() {
+	It("Lists the clusters that match the criteria", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				VerifyFormKV("search", "name like 'prod-%' and product.id = 'rosa' and properties.team = 'payments'"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "123",
+				      "name": "prod-1",
+				      "external_id": "abc",
+				      "product": {
+				        "id": "rosa"
+				      },
+				      "cloud_provider": {
+				        "id": "aws"
+				      },
+				      "region": {
+				        "id": "us-east-1"
+				      },
+				      "multi_az": true,
+				      "state": "ready",
+				      "version": {
+				        "id": "openshift-v4.12.3",
+				        "raw_id": "4.12.3"
+				      },
+				      "api": {
+				        "url": "https://api.prod-1.example.com:6443"
+				      },
+				      "console": {
+				        "url": "https://console.prod-1.example.com"
+				      },
+				      "properties": {
+				        "team": "payments"
+				      }
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_clusters" "my_clusters" {
+		    name    = "prod-%"
+		    product = "rosa"
+		    properties = {
+		      team = "payments"
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_clusters", "my_clusters")
+		Expect(resource).To(MatchJQ(".attributes.items | length", 1))
+		Expect(resource).To(MatchJQ(".attributes.items[0].id", "123"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].name", "prod-1"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].external_id", "abc"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].cloud_region", "us-east-1"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].multi_az", true))
+		Expect(resource).To(MatchJQ(".attributes.items[0].current_version", "4.12.3"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].properties.team", "payments"))
+	})
+
+	It("Returns an empty list when nothing matches", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				VerifyFormKV("search", "state = 'error'"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 0,
+				  "total": 0,
+				  "items": []
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_clusters" "my_clusters" {
+		    state = "error"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_clusters", "my_clusters")
+		Expect(resource).To(MatchJQ(".attributes.items | length", 0))
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Default ingress", func() {
+	const ingresses = `{
+	  "page": 1,
+	  "size": 2,
+	  "total": 2,
+	  "items": [
+	    {
+	      "id": "abcd",
+	      "default": false,
+	      "dns_name": "apps2.my-cluster.example.com"
+	    },
+	    {
+	      "id": "efgh",
+	      "default": true,
+	      "dns_name": "apps.my-cluster.example.com"
+	    }
+	  ]
+	}`
+
+	BeforeEach(func() {
+		// The provider waits for the cluster to be ready before looking for the ingress:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready"
+				}`),
+			),
+		)
+	})
+
+	It("Reads the default ingress when no settings are given", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/ingresses"),
+				RespondWithJSON(http.StatusOK, ingresses),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_default_ingress" "default" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_default_ingress", "default")
+		Expect(resource).To(MatchJQ(".attributes.id", "efgh"))
+		Expect(resource).To(MatchJQ(".attributes.dns_name", "apps.my-cluster.example.com"))
+	})
+
+	It("Restricts the routes admitted by the default ingress", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/ingresses"),
+				RespondWithJSON(http.StatusOK, ingresses),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/ingresses/efgh"),
+				VerifyJSON(`{
+				  "kind": "Ingress",
+				  "route_selectors": {
+				    "shard": "public"
+				  }
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "efgh",
+				  "default": true,
+				  "dns_name": "apps.my-cluster.example.com",
+				  "route_selectors": {
+				    "shard": "public"
+				  }
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_default_ingress" "default" {
+		    cluster         = "123"
+		    route_selectors = {
+		      "shard" = "public"
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_default_ingress", "default")
+		Expect(resource).To(MatchJQ(".attributes.route_selectors.shard", "public"))
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Default machine pool", func() {
+	BeforeEach(func() {
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+
+		// The provider waits for the cluster to be ready before looking for the pool:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools/worker"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "worker",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 2,
+				  "labels": {
+				    "tier": "web"
+				  }
+				}`),
+			),
+		)
+	})
+
+	It("Adopts the default machine pool and enables autoscaling", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/machine_pools/worker"),
+				VerifyJSON(`{
+				  "kind": "MachinePool",
+				  "id": "worker",
+				  "autoscaling": {
+				    "kind": "MachinePoolAutoscaling",
+				    "max_replicas": 6,
+				    "min_replicas": 3
+				  },
+				  "taints": [
+				    {
+				      "key": "dedicated",
+				      "value": "web",
+				      "effect": "PreferNoSchedule"
+				    }
+				  ]
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "worker",
+				  "instance_type": "r5.xlarge",
+				  "autoscaling": {
+				    "max_replicas": 6,
+				    "min_replicas": 3
+				  },
+				  "labels": {
+				    "tier": "web"
+				  },
+				  "taints": [
+				    {
+				      "key": "dedicated",
+				      "value": "web",
+				      "effect": "PreferNoSchedule"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_default_machine_pool" "worker" {
+		    cluster             = "123"
+		    autoscaling_enabled = true
+		    min_replicas        = 3
+		    max_replicas        = 6
+		    taints = [
+		      {
+		        key           = "dedicated"
+		        value         = "web"
+		        schedule_type = "PreferNoSchedule"
+		      },
+		    ]
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_default_machine_pool", "worker")
+		Expect(resource).To(MatchJQ(".attributes.id", "worker"))
+		Expect(resource).To(MatchJQ(".attributes.name", "worker"))
+		Expect(resource).To(MatchJQ(".attributes.machine_type", "r5.xlarge"))
+		Expect(resource).To(MatchJQ(".attributes.min_replicas", float64(3)))
+		Expect(resource).To(MatchJQ(".attributes.max_replicas", float64(6)))
+		Expect(resource).To(MatchJQ(".attributes.labels.tier", "web"))
+		Expect(resource).To(MatchJQ(".attributes.taints[0].schedule_type", "PreferNoSchedule"))
+	})
+
+	It("Doesn't delete the default machine pool when destroyed", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/machine_pools/worker"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "worker",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 3,
+				  "labels": {
+				    "tier": "web"
+				  }
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_default_machine_pool" "worker" {
+		    cluster  = "123"
+		    replicas = 3
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Destroying only refreshes the pool, no delete request is sent:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools/worker"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "worker",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 3,
+				  "labels": {
+				    "tier": "web"
+				  }
+				}`),
+			),
+		)
+		Expect(terraform.Destroy()).To(BeZero())
+	})
+
+	It("Fails to change the machine type of the default machine pool", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_default_machine_pool" "worker" {
+		    cluster      = "123"
+		    machine_type = "m6g.xlarge"
+		    replicas     = 2
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
@@ -85,6 +85,184 @@ var _ = Describe("Identity provider creation", func() {
 		Expect(terraform.Apply()).To(BeZero())
 	})
 
+	It("Can create a 'htpasswd' identity provider with several users and update them", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers",
+				),
+				VerifyJSON(`{
+				  "kind": "IdentityProvider",
+				  "type": "HTPasswdIdentityProvider",
+				  "mapping_method": "claim",
+				  "name": "my-ip",
+				  "htpasswd": {
+				    "users": {
+				      "items": [
+				        {
+				          "username": "user-1",
+				          "password": "password-1"
+				        },
+				        {
+				          "username": "user-2",
+				          "password": "password-2"
+				        }
+				      ]
+				    }
+				  }
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "456",
+				  "name": "my-ip",
+				  "mapping_method": "claim",
+				  "htpasswd": {}
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_identity_provider" "my_ip" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    htpasswd = {
+		      users = [
+		        {
+		          username = "user-1"
+		          password = "password-1"
+		        },
+		        {
+		          username = "user-2"
+		          password = "password-2"
+		        },
+		      ]
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Change the password of the first user, remove the second and add a third one:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers",
+				),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "IdentityProviderList",
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "456",
+				      "name": "my-ip",
+				      "mapping_method": "claim",
+				      "htpasswd": {}
+				    }
+				  ]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/456/htpasswd_users",
+				),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "a",
+				      "username": "user-1"
+				    },
+				    {
+				      "id": "b",
+				      "username": "user-2"
+				    }
+				  ]
+				}`),
+			),
+		)
+		server.RouteToHandler(
+			http.MethodPost,
+			"/api/clusters_mgmt/v1/clusters/123/identity_providers/456/htpasswd_users",
+			CombineHandlers(
+				VerifyJSON(`{
+				  "kind": "HTPasswdUser",
+				  "username": "user-3",
+				  "password": "password-3"
+				}`),
+				RespondWithJSON(http.StatusCreated, `{
+				  "id": "c",
+				  "username": "user-3"
+				}`),
+			),
+		)
+		server.RouteToHandler(
+			http.MethodPatch,
+			"/api/clusters_mgmt/v1/clusters/123/identity_providers/456/htpasswd_users/a",
+			CombineHandlers(
+				VerifyJSON(`{
+				  "kind": "HTPasswdUser",
+				  "password": "new-password-1"
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "a",
+				  "username": "user-1"
+				}`),
+			),
+		)
+		server.RouteToHandler(
+			http.MethodDelete,
+			"/api/clusters_mgmt/v1/clusters/123/identity_providers/456/htpasswd_users/b",
+			RespondWithJSON(http.StatusNoContent, "{}"),
+		)
+		terraform.Source(`
+		  resource "ocm_identity_provider" "my_ip" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    htpasswd = {
+		      users = [
+		        {
+		          username = "user-1"
+		          password = "new-password-1"
+		        },
+		        {
+		          username = "user-3"
+		          password = "password-3"
+		        },
+		      ]
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+	})
+
+	It("Should fail with both a single user and a list of users", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_identity_provider" "my_ip" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    htpasswd = {
+		      username = "my-user"
+		      password = "my-password"
+		      users = [
+		        {
+		          username = "user-1"
+		          password = "password-1"
+		        },
+		      ]
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
 	It("Can create a 'gitlab' identity provider", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -633,3 +811,83 @@ var _ = Describe("Identity provider creation", func() {
 		Expect(terraform.Apply()).ToNot(BeZero())
 	})
 })
+
+var _ = Describe("Identity provider import", func() {
+	It("Can import a 'htpasswd' identity provider with the names of its users", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/identity_providers"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "IdentityProviderList",
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "456",
+				      "name": "my-ip",
+				      "mapping_method": "lookup",
+				      "htpasswd": {}
+				    }
+				  ]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/identity_providers/456/htpasswd_users",
+				),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "a",
+				      "username": "user-1"
+				    },
+				    {
+				      "id": "b",
+				      "username": "user-2"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the import command:
+		terraform.Source(`
+		  resource "ocm_identity_provider" "my_ip" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    htpasswd = {
+		      users = []
+		    }
+		  }
+		`)
+		Expect(terraform.Import("ocm_identity_provider.my_ip", "123,456")).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_identity_provider", "my_ip")
+		Expect(resource).To(MatchJQ(".attributes.cluster", "123"))
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".attributes.name", "my-ip"))
+		Expect(resource).To(MatchJQ(".attributes.mapping_method", "lookup"))
+		Expect(resource).To(MatchJQ(".attributes.htpasswd.users[0].username", "user-1"))
+		Expect(resource).To(MatchJQ(".attributes.htpasswd.users[1].username", "user-2"))
+	})
+
+	It("Fails to import an identity provider without the cluster identifier", func() {
+		terraform.Source(`
+		  resource "ocm_identity_provider" "my_ip" {
+		    cluster = "123"
+		    name    = "my-ip"
+		    htpasswd = {
+		      users = []
+		    }
+		  }
+		`)
+		Expect(terraform.Import("ocm_identity_provider.my_ip", "456")).ToNot(BeZero())
+	})
+})
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Ingresses data source", func() {
+	It("Returns the default ingress first", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/ingresses"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "abcd",
+				      "default": false,
+				      "dns_name": "apps2.my-cluster.example.com",
+				      "listening": "internal",
+				      "route_selectors": {
+				        "route": "internal"
+				      }
+				    },
+				    {
+				      "id": "efgh",
+				      "default": true,
+				      "dns_name": "apps.my-cluster.example.com",
+				      "listening": "external"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_ingresses" "my_ingresses" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_ingresses", "my_ingresses")
+		Expect(resource).To(MatchJQ(".attributes.items | length", 2))
+		Expect(resource).To(MatchJQ(".attributes.items[0].id", "efgh"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].default", true))
+		Expect(resource).To(MatchJQ(".attributes.items[0].listening", "external"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].id", "abcd"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].default", false))
+		Expect(resource).To(MatchJQ(".attributes.items[1].listening", "internal"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].route_selectors.route", "internal"))
+	})
+})
@@ -25,8 +25,46 @@ import (
 	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
 )
 
+const machineTypes = `{
+  "page": 1,
+  "size": 3,
+  "total": 3,
+  "items": [
+    {
+      "id": "r5.xlarge",
+      "cpu": {
+        "value": 4,
+        "unit": "vCPU"
+      }
+    },
+    {
+      "id": "m6g.xlarge",
+      "cpu": {
+        "value": 4,
+        "unit": "vCPU"
+      }
+    },
+    {
+      "id": "g4dn.12xlarge",
+      "category": "accelerated_computing",
+      "cpu": {
+        "value": 48,
+        "unit": "vCPU"
+      }
+    }
+  ]
+}`
+
 var _ = Describe("Machine pool creation", func() {
 	BeforeEach(func() {
+		// The machine types are retrieved when planning and when applying, so they are always
+		// available:
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+
 		// The first thing that the provider will do for any operation on machine pools
 		// is check that the cluster is ready, so we always need to prepare the server to
 		// respond to that:
@@ -61,7 +99,7 @@ var _ = Describe("Machine pool creation", func() {
 				  "replicas": 10,
 				  "taints": [
 					  {
-						"effect": "effect1",
+						"effect": "NoSchedule",
 						"key": "key1",
 						"value": "value1"
 					  }
@@ -94,7 +132,7 @@ var _ = Describe("Machine pool creation", func() {
 				{
 					key = "key1",
 					value = "value1",
-					schedule_type = "effect1",
+					schedule_type = "NoSchedule",
 				},
 		    ]
 		  }
@@ -166,18 +204,25 @@ var _ = Describe("Machine pool creation", func() {
 		server.AppendHandlers(
 			// First get is for the Read function
 			CombineHandlers(
-				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool"),
-				RespondWithJSON(http.StatusOK, `
-				{
-				  "id": "my-pool",
-				  "kind": "MachinePool",
-				  "href": "/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool",
-				  "autoscaling": {
-				  	"kind": "MachinePoolAutoscaling",
-				  	"max_replicas": 2,
-				  	"min_replicas": 0
-				  },
-				  "instance_type": "r5.xlarge"
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "MachinePoolList",
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "my-pool",
+				      "kind": "MachinePool",
+				      "href": "/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool",
+				      "autoscaling": {
+				      	"kind": "MachinePoolAutoscaling",
+				      	"max_replicas": 2,
+				      	"min_replicas": 0
+				      },
+				      "instance_type": "r5.xlarge"
+				    }
+				  ]
 				}`),
 			),
 			// Second get is for the Update function
@@ -236,6 +281,76 @@ var _ = Describe("Machine pool creation", func() {
 		Expect(resource).To(MatchJQ(".attributes.replicas", float64(10)))
 	})
 
+	It("Ignores the replicas changed by the autoscaler", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/machine_pools",
+				),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "my-pool",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 2,
+				  "autoscaling": {
+				    "max_replicas": 4,
+				    "min_replicas": 2
+				  }
+				}`),
+			),
+		)
+
+		// Run the apply command to create the machine pool resource:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster                     = "123"
+		    name                        = "my-pool"
+		    machine_type                = "r5.xlarge"
+		    autoscaling_enabled         = true
+		    min_replicas                = 2
+		    max_replicas                = 4
+		    ignore_autoscaling_replicas = true
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_machine_pool", "my_pool")
+		Expect(resource).To(MatchJQ(".attributes.replicas", nil))
+
+		// The autoscaler added nodes, but applying again only reads the machine pool, the
+		// server would fail any update:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "MachinePoolList",
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "my-pool",
+				      "instance_type": "r5.xlarge",
+				      "replicas": 3,
+				      "autoscaling": {
+				        "max_replicas": 4,
+				        "min_replicas": 2
+				      }
+				    }
+				  ]
+				}`),
+			),
+		)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource = terraform.Resource("ocm_machine_pool", "my_pool")
+		Expect(resource).To(MatchJQ(".attributes.replicas", nil))
+		Expect(resource).To(MatchJQ(".attributes.max_replicas", float64(4)))
+	})
+
 	It("Can create machine pool with compute nodes using spot instances with max spot price of 0.5", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -262,7 +377,7 @@ var _ = Describe("Machine pool creation", func() {
 				  "replicas": 10,
 				  "taints": [
 					  {
-						"effect": "effect1",
+						"effect": "NoSchedule",
 						"key": "key1",
 						"value": "value1"
 					  }
@@ -302,7 +417,7 @@ var _ = Describe("Machine pool creation", func() {
 				{
 					key = "key1",
 					value = "value1",
-					schedule_type = "effect1",
+					schedule_type = "NoSchedule",
 				},
 		    ]
 		  }
@@ -346,7 +461,7 @@ var _ = Describe("Machine pool creation", func() {
 				  "replicas": 10,
 				  "taints": [
 					  {
-						"effect": "effect1",
+						"effect": "NoSchedule",
 						"key": "key1",
 						"value": "value1"
 					  }
@@ -384,7 +499,7 @@ var _ = Describe("Machine pool creation", func() {
 				{
 					key = "key1",
 					value = "value1",
-					schedule_type = "effect1",
+					schedule_type = "NoSchedule",
 				},
 		    ]
 		  }
@@ -402,3 +517,513 @@ var _ = Describe("Machine pool creation", func() {
 		Expect(resource).To(MatchJQ(".attributes.use_spot_instances", true))
 	})
 })
+
+var _ = Describe("Machine pool import", func() {
+	BeforeEach(func() {
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+	})
+
+	It("Can import a machine pool using the cluster and pool identifiers", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "MachinePoolList",
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "my-pool",
+				      "instance_type": "r5.xlarge",
+				      "autoscaling": {
+				        "max_replicas": 4,
+				        "min_replicas": 2
+				      },
+				      "labels": {
+				        "tier": "web"
+				      },
+				      "taints": [
+				        {
+				          "key": "dedicated",
+				          "value": "web",
+				          "effect": "NoSchedule"
+				        }
+				      ],
+				      "aws": {
+				        "spot_market_options": {
+				          "max_price": 0.5
+				        }
+				      }
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the import command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "r5.xlarge"
+		  }
+		`)
+		Expect(terraform.Import("ocm_machine_pool.my_pool", "123,my-pool")).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_machine_pool", "my_pool")
+		Expect(resource).To(MatchJQ(".attributes.cluster", "123"))
+		Expect(resource).To(MatchJQ(".attributes.id", "my-pool"))
+		Expect(resource).To(MatchJQ(".attributes.name", "my-pool"))
+		Expect(resource).To(MatchJQ(".attributes.machine_type", "r5.xlarge"))
+		Expect(resource).To(MatchJQ(".attributes.autoscaling_enabled", true))
+		Expect(resource).To(MatchJQ(".attributes.min_replicas", float64(2)))
+		Expect(resource).To(MatchJQ(".attributes.max_replicas", float64(4)))
+		Expect(resource).To(MatchJQ(".attributes.labels.tier", "web"))
+		Expect(resource).To(MatchJQ(".attributes.taints[0].key", "dedicated"))
+		Expect(resource).To(MatchJQ(".attributes.taints[0].schedule_type", "NoSchedule"))
+		Expect(resource).To(MatchJQ(".attributes.use_spot_instances", true))
+		Expect(resource).To(MatchJQ(".attributes.max_spot_price", 0.5))
+	})
+
+	It("Fails to import a machine pool without the cluster identifier", func() {
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "r5.xlarge"
+		  }
+		`)
+		Expect(terraform.Import("ocm_machine_pool.my_pool", "my-pool")).ToNot(BeZero())
+	})
+})
+
+var _ = Describe("Machine pool labels and taints", func() {
+	It("Fails to plan a machine pool with a reserved label", func() {
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "r5.xlarge"
+		    replicas     = 2
+		    labels = {
+		      "kubernetes.io/role" = "infra"
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails to plan a machine pool with an invalid taint effect", func() {
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "r5.xlarge"
+		    replicas     = 2
+		    taints = [
+		      {
+		        key           = "dedicated",
+		        value         = "gpu",
+		        schedule_type = "Never",
+		      },
+		    ]
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
+
+var _ = Describe("Machine pool GPUs", func() {
+	BeforeEach(func() {
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+	})
+
+	It("Can create machine pool with GPU nodes", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/machine_pools",
+				),
+				VerifyJQ(`.instance_type`, "g4dn.12xlarge"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "my-pool",
+				  "instance_type": "g4dn.12xlarge",
+				  "replicas": 2
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "g4dn.12xlarge"
+		    replicas     = 2
+		  }
+
+		  output "gpus" {
+		    value = ocm_machine_pool.my_pool.gpus
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_machine_pool", "my_pool")
+		Expect(resource).To(MatchJQ(".attributes.machine_type_category", "accelerated_computing"))
+		Expect(resource).To(MatchJQ(".attributes.gpus", 4.0))
+	})
+
+	It("Fails to plan a machine pool with an unknown machine type", func() {
+		// The server isn't prepared because the machine type is checked before creating it.
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "g4dn.13xlarge"
+		    replicas     = 2
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
+
+var _ = Describe("Machine pool availability zone", func() {
+	BeforeEach(func() {
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+	})
+
+	It("Can create machine pool in one zone of a multi-AZ cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready",
+				  "multi_az": true,
+				  "nodes": {
+				    "availability_zones": [
+				      "us-east-1a",
+				      "us-east-1b",
+				      "us-east-1c"
+				    ]
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/machine_pools",
+				),
+				VerifyJQ(`.availability_zones[0]`, "us-east-1b"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "my-pool",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 2,
+				  "availability_zones": [
+				    "us-east-1b"
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster           = "123"
+		    name              = "my-pool"
+		    machine_type      = "r5.xlarge"
+		    replicas          = 2
+		    availability_zone = "us-east-1b"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_machine_pool", "my_pool")
+		Expect(resource).To(MatchJQ(".attributes.availability_zone", "us-east-1b"))
+		Expect(resource).To(MatchJQ(".attributes.subnet_id", nil))
+	})
+
+	It("Fails to create machine pool in a zone that isn't used by the cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready",
+				  "multi_az": true,
+				  "nodes": {
+				    "availability_zones": [
+				      "us-east-1a",
+				      "us-east-1b",
+				      "us-east-1c"
+				    ]
+				  }
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster           = "123"
+		    name              = "my-pool"
+		    machine_type      = "r5.xlarge"
+		    replicas          = 2
+		    availability_zone = "us-east-1d"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
+
+var _ = Describe("Machine pool deletion", func() {
+	BeforeEach(func() {
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool",
+			RespondWithJSON(http.StatusOK, `{
+			  "id": "my-pool",
+			  "instance_type": "r5.xlarge",
+			  "replicas": 2
+			}`),
+		)
+
+		// Create the machine pool:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/machine_pools",
+				),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "my-pool",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 2
+				}`),
+			),
+		)
+	})
+
+	It("Refuses to delete the last worker pool", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "r5.xlarge"
+		    replicas     = 2
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// The other pool of the cluster is tainted, so it can't run workloads:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools"),
+				RespondWithJSON(http.StatusOK, `{
+				  "kind": "MachinePoolList",
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "infra",
+				      "replicas": 2,
+				      "taints": [
+				        {
+				          "key": "node-role.kubernetes.io/infra",
+				          "effect": "NoSchedule"
+				        }
+				      ]
+				    },
+				    {
+				      "id": "my-pool",
+				      "replicas": 2
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the destroy command:
+		Expect(terraform.Destroy()).ToNot(BeZero())
+	})
+
+	It("Deletes the last worker pool when forced", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "my-pool"
+		    machine_type = "r5.xlarge"
+		    replicas     = 2
+		    force        = true
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/machine_pools/my-pool"),
+				RespondWithJSON(http.StatusOK, "{}"),
+			),
+		)
+
+		// Run the destroy command:
+		Expect(terraform.Destroy()).To(BeZero())
+	})
+})
+
+var _ = Describe("Infra machine pools", func() {
+	BeforeEach(func() {
+		server.RouteToHandler(
+			http.MethodGet,
+			"/api/clusters_mgmt/v1/machine_types",
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+	})
+
+	It("Can create an infra machine pool in an OSD cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready",
+				  "product": {
+				    "id": "osd"
+				  }
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/clusters/123/machine_pools",
+				),
+				VerifyJSON(`{
+				  "kind": "MachinePool",
+				  "id": "infra",
+				  "instance_type": "r5.xlarge",
+				  "labels": {
+				    "node-role.kubernetes.io/infra": ""
+				  },
+				  "replicas": 3,
+				  "taints": [
+				    {
+				      "effect": "NoSchedule",
+				      "key": "node-role.kubernetes.io/infra"
+				    }
+				  ]
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "infra",
+				  "instance_type": "r5.xlarge",
+				  "replicas": 3,
+				  "labels": {
+				    "node-role.kubernetes.io/infra": ""
+				  },
+				  "taints": [
+				    {
+				      "effect": "NoSchedule",
+				      "key": "node-role.kubernetes.io/infra"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "infra"
+		    machine_type = "r5.xlarge"
+		    replicas     = 3
+		    infra        = true
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state, the label and taint added by the provider aren't part of it:
+		resource := terraform.Resource("ocm_machine_pool", "my_pool")
+		Expect(resource).To(MatchJQ(".attributes.infra", true))
+		Expect(resource).To(MatchJQ(".attributes.labels", nil))
+		Expect(resource).To(MatchJQ(".attributes.taints", nil))
+	})
+
+	It("Fails to create an infra machine pool in a ROSA cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready",
+				  "product": {
+				    "id": "rosa"
+				  }
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_machine_pool" "my_pool" {
+		    cluster      = "123"
+		    name         = "infra"
+		    machine_type = "r5.xlarge"
+		    replicas     = 3
+		    infra        = true
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+})
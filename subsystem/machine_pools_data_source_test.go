@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Machine pools data source", func() {
+	It("Returns all the machine pools of the cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "worker",
+				      "instance_type": "r5.xlarge",
+				      "replicas": 3,
+				      "availability_zones": [
+				        "us-east-1a"
+				      ]
+				    },
+				    {
+				      "id": "monitoring",
+				      "instance_type": "m5.2xlarge",
+				      "autoscaling": {
+				        "min_replicas": 1,
+				        "max_replicas": 4
+				      },
+				      "labels": {
+				        "role": "monitoring"
+				      },
+				      "taints": [
+				        {
+				          "key": "monitoring",
+				          "value": "true",
+				          "effect": "NoSchedule"
+				        }
+				      ],
+				      "aws": {
+				        "spot_market_options": {
+				          "max_price": 0.5
+				        }
+				      }
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_machine_pools" "my_pools" {
+		    cluster = "123"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_machine_pools", "my_pools")
+		Expect(resource).To(MatchJQ(".attributes.items | length", 2))
+		Expect(resource).To(MatchJQ(".attributes.items[0].id", "worker"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].machine_type", "r5.xlarge"))
+		Expect(resource).To(MatchJQ(".attributes.items[0].replicas", 3.0))
+		Expect(resource).To(MatchJQ(".attributes.items[0].autoscaling_enabled", false))
+		Expect(resource).To(MatchJQ(".attributes.items[0].availability_zones[0]", "us-east-1a"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].id", "monitoring"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].replicas", nil))
+		Expect(resource).To(MatchJQ(".attributes.items[1].autoscaling_enabled", true))
+		Expect(resource).To(MatchJQ(".attributes.items[1].min_replicas", 1.0))
+		Expect(resource).To(MatchJQ(".attributes.items[1].max_replicas", 4.0))
+		Expect(resource).To(MatchJQ(".attributes.items[1].use_spot_instances", true))
+		Expect(resource).To(MatchJQ(".attributes.items[1].max_spot_price", 0.5))
+		Expect(resource).To(MatchJQ(".attributes.items[1].labels.role", "monitoring"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].taints[0].key", "monitoring"))
+		Expect(resource).To(MatchJQ(".attributes.items[1].taints[0].schedule_type", "NoSchedule"))
+	})
+})
@@ -231,6 +231,11 @@ func (r *TerraformRunner) Validate() int {
 	return r.Run("validate")
 }
 
+// Plan runs the `plan` command.
+func (r *TerraformRunner) Plan() int {
+	return r.Run("plan")
+}
+
 // Apply runs the `apply` command.
 func (r *TerraformRunner) Apply() int {
 	return r.Run("apply", "-auto-approve")
@@ -241,6 +246,11 @@ func (r *TerraformRunner) Destroy() int {
 	return r.Run("destroy", "-auto-approve")
 }
 
+// Import runs the `import` command.
+func (r *TerraformRunner) Import(args ...string) int {
+	return r.Run(append([]string{"import"}, args...)...)
+}
+
 // State returns the reads the Terraform state and returns the result of parsing
 // it as a JSON document.
 func (r *TerraformRunner) State() interface{} {
@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Quota cost data source", func() {
+	BeforeEach(func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/machine_types"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "id": "m5.xlarge",
+				      "cpu": {
+				        "value": 4,
+				        "unit": "vCPU"
+				      },
+				      "cloud_provider": {
+				        "id": "aws"
+				      },
+				      "generic_name": "standard-4"
+				    }
+				  ]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "456",
+				  "organization": {
+				    "id": "789"
+				  }
+				}`),
+			),
+		)
+	})
+
+	It("Reports the quota needed by the cluster", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/789/quota_cost"),
+				VerifyFormKV("fetchRelatedResources", "true"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "quota_id": "cluster|byoc|moa|marketplace",
+				      "allowed": 0,
+				      "consumed": 0,
+				      "related_resources": [
+				        {
+				          "resource_type": "cluster",
+				          "product": "ROSA",
+				          "byoc": "byoc",
+				          "availability_zone_type": "any",
+				          "billing_model": "marketplace",
+				          "cost": 1
+				        }
+				      ]
+				    },
+				    {
+				      "quota_id": "compute.node|cpu|byoc|moa",
+				      "allowed": 20,
+				      "consumed": 4,
+				      "related_resources": [
+				        {
+				          "resource_type": "compute.node",
+				          "resource_name": "standard-4",
+				          "product": "ROSA",
+				          "byoc": "byoc",
+				          "availability_zone_type": "any",
+				          "billing_model": "standard",
+				          "cost": 4
+				        }
+				      ]
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_quota_cost" "my_cost" {
+		    compute_machine_type = "m5.xlarge"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_quota_cost", "my_cost")
+		Expect(resource).To(MatchJQ(`.attributes.sufficient`, true))
+		Expect(resource).To(MatchJQ(`.attributes.items | length`, 1))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].quota_id`, "compute.node|cpu|byoc|moa"))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].required`, 8.0))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].allowed`, 20.0))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].consumed`, 4.0))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].remaining`, 16.0))
+	})
+
+	It("Reports that the quota isn't enough", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/789/quota_cost"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 1,
+				  "total": 1,
+				  "items": [
+				    {
+				      "quota_id": "compute.node|cpu|byoc|moa",
+				      "allowed": 20,
+				      "consumed": 16,
+				      "related_resources": [
+				        {
+				          "resource_type": "compute.node",
+				          "resource_name": "standard-4",
+				          "product": "ROSA",
+				          "byoc": "byoc",
+				          "availability_zone_type": "any",
+				          "billing_model": "standard",
+				          "cost": 4
+				        }
+				      ]
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_quota_cost" "my_cost" {
+		    compute_machine_type = "m5.xlarge"
+		    multi_az             = true
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_quota_cost", "my_cost")
+		Expect(resource).To(MatchJQ(`.attributes.sufficient`, false))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].required`, 12.0))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].remaining`, 4.0))
+	})
+})
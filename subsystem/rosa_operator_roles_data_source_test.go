@@ -158,6 +158,72 @@ var _ = Describe("ROSA Operator IAM roles data source", func() {
 			[]string{"system:serviceaccount:openshift-cloud-network-config-controller:cloud-network-config-controller"},
 		)
 	})
+
+	It("Calculates the ARNs of the operator roles", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/aws_inquiries/sts_credential_requests"),
+				RespondWithJSON(http.StatusOK, getStsCredentialRequests),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_rosa_operator_roles" "operator_roles" {
+		    operator_role_prefix = "terraform-operator"
+		    aws_account_id       = "123456789012"
+		    path                 = "/openshift/"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state, the roles are sorted by namespace:
+		resource := terraform.Resource("ocm_rosa_operator_roles", "operator_roles")
+		Expect(resource).To(MatchJQ(
+			".attributes.operator_iam_roles[0].role_arn",
+			"arn:aws:iam::123456789012:role/openshift/"+
+				"terraform-operator-openshift-cloud-network-config-controller-clo",
+		))
+		Expect(resource).To(MatchJQ(
+			".attributes.operator_iam_roles[1].role_arn",
+			"arn:aws:iam::123456789012:role/openshift/"+
+				"terraform-operator-openshift-cluster-csi-drivers-ebs-cloud-crede",
+		))
+	})
+
+	It("Doesn't calculate the ARNs without the account", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/aws_inquiries/sts_credential_requests"),
+				RespondWithJSON(http.StatusOK, getStsCredentialRequests),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_rosa_operator_roles" "operator_roles" {
+		    operator_role_prefix = "terraform-operator"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_rosa_operator_roles", "operator_roles")
+		Expect(resource).To(MatchJQ(".attributes.operator_iam_roles[0].role_arn", nil))
+	})
+
+	It("Fails with a path that doesn't end with a slash", func() {
+		terraform.Source(`
+		  data "ocm_rosa_operator_roles" "operator_roles" {
+		    operator_role_prefix = "terraform-operator"
+		    aws_account_id       = "123456789012"
+		    path                 = "/openshift"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
 })
 
 func compareResultOfRoles(resource interface{}, index int, name, namespace, policyName, roleName string, serviceAccountLen int, serviceAccounts []string) {
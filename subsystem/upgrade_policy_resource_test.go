@@ -0,0 +1,338 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"             // nolint
+	. "github.com/onsi/gomega"                         // nolint
+	. "github.com/onsi/gomega/ghttp"                   // nolint
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Upgrade policy", func() {
+	BeforeEach(func() {
+		// The provider waits for the cluster to be ready before creating the policy:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+				  "id": "123",
+				  "name": "my-cluster",
+				  "state": "ready",
+				  "version": {
+				    "id": "openshift-v4.12.3",
+				    "raw_id": "4.12.3",
+				    "available_upgrades": [
+				      "4.12.4",
+				      "4.12.5"
+				    ]
+				  }
+				}`),
+			),
+		)
+	})
+
+	It("Creates an automatic upgrade policy", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+				VerifyJSON(`{
+				  "kind": "UpgradePolicy",
+				  "schedule_type": "automatic",
+				  "schedule": "0 2 * * 6",
+				  "upgrade_type": "OSD"
+				}`),
+				RespondWithJSON(http.StatusCreated, `{
+				  "id": "456",
+				  "schedule_type": "automatic",
+				  "schedule": "0 2 * * 6",
+				  "upgrade_type": "OSD",
+				  "next_run": "2023-06-03T02:00:00Z"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+				RespondWithJSON(http.StatusOK, `{
+				  "value": "scheduled"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_upgrade_policy" "my_policy" {
+		    cluster            = "123"
+		    automatic_upgrades = true
+		    schedule           = "0 2 * * 6"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_upgrade_policy", "my_policy")
+		Expect(resource).To(MatchJQ(".attributes.id", "456"))
+		Expect(resource).To(MatchJQ(".attributes.automatic_upgrades", true))
+		Expect(resource).To(MatchJQ(".attributes.schedule", "0 2 * * 6"))
+		Expect(resource).To(MatchJQ(".attributes.version", nil))
+		Expect(resource).To(MatchJQ(".attributes.next_run", "2023-06-03T02:00:00Z"))
+		Expect(resource).To(MatchJQ(".attributes.state", "scheduled"))
+	})
+
+	It("Creates a manual upgrade policy", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+				VerifyJSON(`{
+				  "kind": "UpgradePolicy",
+				  "schedule_type": "manual",
+				  "version": "4.12.5",
+				  "next_run": "2023-06-01T02:00:00Z",
+				  "upgrade_type": "OSD"
+				}`),
+				RespondWithJSON(http.StatusCreated, `{
+				  "id": "456",
+				  "schedule_type": "manual",
+				  "version": "4.12.5",
+				  "next_run": "2023-06-01T02:00:00Z",
+				  "upgrade_type": "OSD"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+				RespondWithJSON(http.StatusOK, `{
+				  "value": "scheduled"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_upgrade_policy" "my_policy" {
+		    cluster  = "123"
+		    version  = "4.12.5"
+		    next_run = "2023-06-01T02:00:00Z"
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_upgrade_policy", "my_policy")
+		Expect(resource).To(MatchJQ(".attributes.automatic_upgrades", false))
+		Expect(resource).To(MatchJQ(".attributes.version", "4.12.5"))
+		Expect(resource).To(MatchJQ(".attributes.next_run", "2023-06-01T02:00:00Z"))
+		Expect(resource).To(MatchJQ(".attributes.state", "scheduled"))
+	})
+
+	It("Schedules a manual upgrade in the maintenance window", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+				VerifyJQ(`.schedule_type`, "manual"),
+				VerifyJQ(`.version`, "4.12.5"),
+				VerifyJQ(`.next_run | endswith("T02:00:00Z")`, true),
+				RespondWithJSON(http.StatusCreated, `{
+				  "id": "456",
+				  "schedule_type": "manual",
+				  "version": "4.12.5",
+				  "next_run": "2023-06-03T02:00:00Z",
+				  "upgrade_type": "OSD"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+				RespondWithJSON(http.StatusOK, `{
+				  "value": "scheduled"
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_upgrade_policy" "my_policy" {
+		    cluster = "123"
+		    version = "4.12.5"
+		    maintenance_window = {
+		      day  = "Saturday"
+		      hour = 2
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_upgrade_policy", "my_policy")
+		Expect(resource).To(MatchJQ(".attributes.next_run", "2023-06-03T02:00:00Z"))
+		Expect(resource).To(MatchJQ(".attributes.maintenance_window.day", "Saturday"))
+	})
+
+	It("Fails with an unknown maintenance window day", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_upgrade_policy" "my_policy" {
+		    cluster = "123"
+		    version = "4.12.5"
+		    maintenance_window = {
+		      day  = "Caturday"
+		      hour = 2
+		    }
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails to upgrade to a version that isn't available", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_upgrade_policy" "my_policy" {
+		    cluster = "123"
+		    version = "4.13.0"
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	It("Fails to create automatic upgrades without schedule", func() {
+		// Run the apply command:
+		terraform.Source(`
+		  resource "ocm_upgrade_policy" "my_policy" {
+		    cluster            = "123"
+		    automatic_upgrades = true
+		  }
+		`)
+		Expect(terraform.Apply()).ToNot(BeZero())
+	})
+
+	Context("Removal", func() {
+		const policy = `{
+		  "id": "456",
+		  "schedule_type": "manual",
+		  "version": "4.12.5",
+		  "next_run": "2023-06-01T02:00:00Z",
+		  "upgrade_type": "OSD"
+		}`
+
+		BeforeEach(func() {
+			// Create the policy:
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+					RespondWithJSON(http.StatusCreated, policy),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+					RespondWithJSON(http.StatusOK, `{
+					  "value": "scheduled"
+					}`),
+				),
+			)
+			terraform.Source(`
+			  resource "ocm_upgrade_policy" "my_policy" {
+			    cluster  = "123"
+			    version  = "4.12.5"
+			    next_run = "2023-06-01T02:00:00Z"
+			  }
+			`)
+			Expect(terraform.Apply()).To(BeZero())
+		})
+
+		It("Cancels the scheduled upgrade", func() {
+			// Prepare the server:
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, policy),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+					RespondWithJSON(http.StatusOK, `{
+					  "value": "scheduled"
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+					RespondWithJSON(http.StatusOK, `{
+					  "value": "scheduled"
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456"),
+					RespondWithJSON(http.StatusNoContent, "{}"),
+				),
+			)
+
+			// Run the destroy command:
+			Expect(terraform.Destroy()).To(BeZero())
+		})
+
+		It("Rejects a schedule for a manual upgrade", func() {
+			// Prepare the server:
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, policy),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+					RespondWithJSON(http.StatusOK, `{
+					  "value": "scheduled"
+					}`),
+				),
+			)
+
+			// Run the apply command:
+			terraform.Source(`
+			  resource "ocm_upgrade_policy" "my_policy" {
+			    cluster  = "123"
+			    version  = "4.12.5"
+			    next_run = "2023-06-01T02:00:00Z"
+			    schedule = "0 2 * * 6"
+			  }
+			`)
+			Expect(terraform.Apply()).ToNot(BeZero())
+		})
+
+		It("Refuses to cancel an upgrade that already started", func() {
+			// Prepare the server:
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456"),
+					RespondWithJSON(http.StatusOK, policy),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+					RespondWithJSON(http.StatusOK, `{
+					  "value": "started"
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies/456/state"),
+					RespondWithJSON(http.StatusOK, `{
+					  "value": "started"
+					}`),
+				),
+			)
+
+			// Run the destroy command:
+			Expect(terraform.Destroy()).ToNot(BeZero())
+		})
+	})
+})
@@ -66,6 +66,46 @@ var _ = Describe("Versions data source", func() {
 		Expect(resource).To(MatchJQ(`.attributes.items[1].name`, "4.8.2"))
 	})
 
+	It("Exposes the support lifecycle of the versions", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 2,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "openshift-v4.8.1",
+				      "raw_id": "4.8.1",
+				      "end_of_life_timestamp": "2024-01-31T00:00:00Z"
+				    },
+				    {
+				      "id": "openshift-v4.8.2",
+				      "raw_id": "4.8.2",
+				      "default": true
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_versions" "my_versions" {
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_versions", "my_versions")
+		Expect(resource).To(MatchJQ(`.attributes.items[0].default`, false))
+		Expect(resource).To(MatchJQ(`.attributes.items[0].end_of_life_timestamp`, "2024-01-31T00:00:00Z"))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].default`, true))
+		Expect(resource).To(MatchJQ(`.attributes.items[1].end_of_life_timestamp`, nil))
+	})
+
 	It("Can search versions", func() {
 		// Prepare the server:
 		server.AppendHandlers(
@@ -201,4 +241,50 @@ var _ = Describe("Versions data source", func() {
 		resource := terraform.Resource("ocm_versions", "my_versions")
 		Expect(resource).To(MatchJQ(`.attributes.item`, nil))
 	})
+
+	It("Retrieves all the pages even if they are smaller than requested", func() {
+		// Prepare the server:
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 1,
+				  "size": 1,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "openshift-v4.8.1",
+				      "raw_id": "4.8.1"
+				    }
+				  ]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				VerifyFormKV("page", "2"),
+				RespondWithJSON(http.StatusOK, `{
+				  "page": 2,
+				  "size": 1,
+				  "total": 2,
+				  "items": [
+				    {
+				      "id": "openshift-v4.8.2",
+				      "raw_id": "4.8.2"
+				    }
+				  ]
+				}`),
+			),
+		)
+
+		// Run the apply command:
+		terraform.Source(`
+		  data "ocm_versions" "my_versions" {
+		  }
+		`)
+		Expect(terraform.Apply()).To(BeZero())
+
+		// Check the state:
+		resource := terraform.Resource("ocm_versions", "my_versions")
+		Expect(resource).To(MatchJQ(`.attributes.items[1].id`, "openshift-v4.8.2"))
+	})
 })